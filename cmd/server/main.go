@@ -11,20 +11,20 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/yourusername/hireiq-api/internal/config"
 	"github.com/yourusername/hireiq-api/internal/handler"
 	"github.com/yourusername/hireiq-api/internal/middleware"
+	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/requestid"
 	"github.com/yourusername/hireiq-api/internal/service"
 )
 
 func main() {
 	// ── Logging ──────────────────────────────────────────
-	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	if os.Getenv("ENV") == "development" {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
@@ -34,11 +34,21 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to load config")
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid config")
+	}
+	if cfg.LogTimeFormat == "unix" {
+		zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	} else {
+		// Same format as timestamps in API responses
+		zerolog.TimeFieldFormat = time.RFC3339
+		zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
+	}
 	log.Info().Str("env", cfg.Env).Str("port", cfg.Port).Msg("Starting HireIQ API")
 
 	// ── Database ─────────────────────────────────────────
 	ctx := context.Background()
-	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	pool, err := repository.NewPool(ctx, cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to database")
 	}
@@ -49,153 +59,310 @@ func main() {
 	}
 	log.Info().Msg("Database connected")
 
+	// Feed and job list reads go to the replica when one is configured. Reads
+	// fall back to the primary while it's unreachable, so it isn't required
+	// at startup.
+	readPool := pool
+	if cfg.DatabaseReadURL != "" {
+		readPool, err = repository.NewPool(ctx, cfg.DatabaseReadURL)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to connect to read replica")
+		}
+		defer readPool.Close()
+
+		if err := readPool.Ping(ctx); err != nil {
+			log.Warn().Err(err).Msg("Read replica unreachable, reading from the primary until it recovers")
+		} else {
+			log.Info().Msg("Read replica connected")
+		}
+	}
+	reads := repository.NewReadPool(pool, readPool)
+
 	// ── Repositories ─────────────────────────────────────
 	userRepo := repository.NewUserRepo(pool)
-	jobRepo := repository.NewJobRepo(pool)
+	jobRepo := repository.NewJobRepo(pool, reads)
 	appRepo := repository.NewApplicationRepo(pool)
-	_ = repository.NewNoteRepo(pool) // Notes handler not yet implemented
+	noteRepo := repository.NewNoteRepo(pool, cfg.MaxNotesPerJob)
 	contactRepo := repository.NewContactRepo(pool)
-	feedRepo := repository.NewFeedRepo(pool)
+	feedRepo := repository.NewFeedRepo(pool, reads)
 	stripeCustomerRepo := repository.NewStripeCustomerRepo(pool)
 	subscriptionRepo := repository.NewSubscriptionRepo(pool)
+	apiTokenRepo := repository.NewAPITokenRepo(pool)
+	aiUsageRepo := repository.NewAIUsageRepo(pool)
+	notificationRepo := repository.NewNotificationRepo(pool)
+	snapshotRepo := repository.NewCompetitiveSnapshotRepo(pool)
+	alertRepo := repository.NewJobAlertRepo(pool)
+	questionRepo := repository.NewInterviewQuestionRepo(pool)
 
 	// ── Services ──────────────────────────────────────────
-	claudeClient := service.NewClaudeClient(cfg.ClaudeAPIKey, cfg.ClaudeBaseURL)
-	yahooClient := service.NewYahooFinanceClient()
-	jsearchClient := service.NewJSearchClient(cfg.RapidAPIKey)
-	remotiveClient := service.NewRemotiveClient()
-	adzunaClient := service.NewAdzunaClient(cfg.AdzunaAppID, cfg.AdzunaAppKey)
-	feedService := service.NewFeedService(jsearchClient, remotiveClient, adzunaClient, feedRepo, userRepo)
+	if err := service.ValidateCritiqueRubric(cfg.CritiqueRubric); err != nil {
+		log.Fatal().Err(err).Msg("Invalid CRITIQUE_RUBRIC_FILE")
+	}
+	claudeClient := service.NewClaudeClient(cfg.ClaudeAPIKey, cfg.ClaudeBaseURL, cfg.CritiqueRubric, cfg.ClaudeTimeout, service.AIConcurrency{
+		MaxInFlight: cfg.AIMaxInFlight,
+		MaxQueued:   cfg.AIMaxQueued,
+	})
+	yahooClient := service.NewYahooFinanceClient(cfg.FinanceTimeout)
+	brandService := service.NewBrandService(cfg.BrandLogoURL)
+	jsearchClient := service.NewJSearchClient(cfg.RapidAPIKey, cfg.JobSourceTimeout)
+	remotiveClient := service.NewRemotiveClient(cfg.JobSourceTimeout)
+	adzunaClient := service.NewAdzunaClient(cfg.AdzunaAppID, cfg.AdzunaAppKey, cfg.JobSourceTimeout)
+	urlFetcher := service.NewURLFetcher(service.ScraperConfig{
+		UserAgents:     cfg.ScraperUserAgents,
+		Accept:         cfg.ScraperAccept,
+		AcceptLanguage: cfg.ScraperAcceptLanguage,
+		SpoofBrowser:   cfg.ScraperSpoofBrowser,
+		PlainHosts:     cfg.ScraperPlainHosts,
+		Timeout:        cfg.ScraperTimeout,
+	})
+	feedService := service.NewFeedService(jsearchClient, remotiveClient, adzunaClient, feedRepo, userRepo, subscriptionRepo, alertRepo, service.RefreshThrottle{
+		Free:    cfg.FeedRefreshIntervalFree,
+		Pro:     cfg.FeedRefreshIntervalPro,
+		ProPlus: cfg.FeedRefreshIntervalProPlus,
+	}, cfg.FeedMaxQueriesPerSource, service.NewStaticRates(cfg.CurrencyRates))
 	stripeService := service.NewStripeService(cfg, stripeCustomerRepo, subscriptionRepo, userRepo)
+	shareSigner := service.NewShareSigner(cfg.ShareSigningSecret)
+	emailSender := service.NewEmailSender(cfg)
+	followUpReminder := service.NewFollowUpReminder(appRepo, emailSender, cfg.FrontendURL)
+
+	// Application status changes fan out to these subscribers (add email/Slack here)
+	statusEvents := service.NewStatusEventBus(
+		service.NewNotificationSubscriber(notificationRepo, jobRepo),
+	)
 
 	// ── Handlers ─────────────────────────────────────────
-	resumeHandler := handler.NewResumeHandler(claudeClient, jobRepo)
+	resumeHandler := handler.NewResumeHandler(claudeClient, jobRepo, userRepo, feedService)
 	authHandler := handler.NewAuthHandler(userRepo)
-	profileHandler := handler.NewProfileHandler(userRepo, feedService)
-	jobHandler := handler.NewJobHandler(jobRepo, appRepo)
-	parseHandler := handler.NewParseHandler(claudeClient)
-	feedHandler := handler.NewFeedHandler(feedService, feedRepo, claudeClient, userRepo)
+	profileHandler := handler.NewProfileHandler(userRepo, feedService, service.NewSkillSuggester(feedRepo), stripeService)
+	jobHandler := handler.NewJobHandler(jobRepo, appRepo, contactRepo, brandService, statusEvents)
+	parseHandler := handler.NewParseHandler(claudeClient, urlFetcher, jobRepo)
+	feedHandler := handler.NewFeedHandler(feedService, feedRepo, claudeClient, userRepo, jobRepo, brandService, statusEvents, cfg.FeedMinScore)
 	companyHandler := handler.NewCompanyHandler(yahooClient, claudeClient)
 	compareHandler := handler.NewCompareHandler(claudeClient, jobRepo, userRepo)
-	appHandler := handler.NewApplicationHandler(appRepo, jobRepo)
+	noteHandler := handler.NewNoteHandler(noteRepo, jobRepo)
+	appHandler := handler.NewApplicationHandler(appRepo, jobRepo, statusEvents, cfg.FollowUpMaxPast)
 	contactHandler := handler.NewContactHandler(contactRepo)
-	networkHandler := handler.NewNetworkHandler(jobRepo, contactRepo)
+	networkHandler := handler.NewNetworkHandler(jobRepo, contactRepo, questionRepo)
+	questionHandler := handler.NewQuestionHandler(questionRepo, appRepo, jobRepo)
 	billingHandler := handler.NewBillingHandler(stripeService, subscriptionRepo)
+	healthHandler := handler.NewHealthHandler(pool, readPool, cfg)
+	tokenHandler := handler.NewTokenHandler(apiTokenRepo)
+	alertHandler := handler.NewAlertHandler(alertRepo, feedService.MaxAlerts())
+	quotaHandler := handler.NewQuotaHandler(aiUsageRepo, subscriptionRepo)
+	coverLetterHandler := handler.NewCoverLetterHandler(claudeClient, jobRepo, userRepo)
+	interviewHandler := handler.NewInterviewHandler(claudeClient, jobRepo, userRepo)
+	skillGapHandler := handler.NewSkillGapHandler(claudeClient, jobRepo, userRepo)
+	competitionHandler := handler.NewCompetitionHandler(jobRepo, snapshotRepo)
+	shareHandler := handler.NewShareHandler(jobRepo, shareSigner, cfg.FrontendURL)
+	timelineHandler := handler.NewTimelineHandler(jobRepo, appRepo, noteRepo)
+	exportHandler := handler.NewExportHandler(userRepo, jobRepo, appRepo, noteRepo, contactRepo, questionRepo)
+	maintenance := middleware.NewMaintenanceMode(cfg.MaintenanceMode)
+	adminHandler := handler.NewAdminHandler(maintenance)
 	// ── Middleware ────────────────────────────────────────
-	authMiddleware, err := middleware.NewAuthMiddleware(cfg.FirebaseProjectID)
+	authMiddleware, err := middleware.NewAuthMiddleware(cfg.FirebaseProjectID, apiTokenRepo, cfg.FirebaseCheckDisabled, cfg.FirebaseTokenCacheSize)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize Firebase auth")
 	}
 	rateLimiter := middleware.NewRateLimiter(cfg.RateLimitRPS)
+	aiRateLimiter := middleware.NewBucketRateLimiter("ai", cfg.RateLimitAIPerMin, true)
 
 	// ── Router ───────────────────────────────────────────
 	if cfg.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
-		// Block wildcard CORS origins in production
-		for _, origin := range cfg.AllowedOrigins {
-			if origin == "*" {
-				log.Fatal().Msg("CORS: wildcard origin '*' is not allowed in production")
-			}
-		}
 	}
 
 	r := gin.New()
+	// ClientIP (rate limiting, request logs) only honors X-Forwarded-For from
+	// these proxies; with none configured it can't be spoofed by clients
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal().Err(err).Msg("Invalid TRUSTED_PROXIES")
+	}
 	r.Use(gin.Recovery())
-	r.Use(requestLogger())
+	r.Use(middleware.RequestID())
+	r.Use(requestLogger(time.Duration(cfg.SlowRequestMS) * time.Millisecond))
+	r.Use(middleware.ResponseEnvelope(cfg.ResponseEnvelope))
 
 	// CORS
+	exactOrigins, originPatterns := middleware.SplitOrigins(cfg.AllowedOrigins)
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     cfg.AllowedOrigins,
+		AllowOrigins:     exactOrigins,
+		AllowOriginFunc:  middleware.WildcardOrigins(originPatterns),
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "If-None-Match", requestid.Header},
+		ExposeHeaders:    []string{"Content-Length", "ETag", requestid.Header},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	// Health check (unauthenticated)
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "hireiq-api",
-			"time":    time.Now().UTC(),
-		})
-	})
+	// After CORS, so browsers can read the maintenance response
+	r.Use(maintenance.Middleware())
+
+	// Health check (unauthenticated) — pings the DB, 503 if unreachable
+	r.GET("/health", healthHandler.Check)
+	r.GET("/readyz", healthHandler.Check)
+
+	// Operator endpoints (shared-secret auth) — not served unless ADMIN_TOKEN is set
+	if cfg.AdminToken != "" {
+		admin := r.Group("/admin", middleware.RequireAdminToken(cfg.AdminToken))
+		admin.GET("/maintenance", adminHandler.GetMaintenance)
+		admin.PUT("/maintenance", adminHandler.SetMaintenance)
+	}
 
 	// Stripe webhook (unauthenticated — verified by Stripe signature)
 	r.POST("/billing/webhook", billingHandler.HandleWebhook)
 
+	// Unsigned webhook replay for local subscription testing — never served outside development
+	if cfg.Env == "development" {
+		r.POST("/billing/webhook/test", billingHandler.TestWebhook)
+		log.Warn().Msg("Dev-only route enabled: POST /billing/webhook/test (no signature verification)")
+	}
+
+	// Shared job links (unauthenticated — verified by HMAC signature, rate limited by IP)
+	r.GET("/shared/jobs/:token", rateLimiter.Limit(), shareHandler.GetSharedJob)
+
 	// ── Authenticated Routes ─────────────────────────────
 	api := r.Group("/", authMiddleware.Authenticate(), rateLimiter.Limit())
 	{
 		// After auth middleware verifies Firebase token, resolve internal user ID
 		api.Use(resolveUserID(userRepo))
+		api.Use(middleware.ReadYourWrites(reads))
+
+		// API token scopes — GET routes need only read, which every token has.
+		// Firebase sessions bypass these checks.
+		jobsWrite := middleware.RequireScope(model.ScopeJobsWrite)
+		contactsWrite := middleware.RequireScope(model.ScopeContactsWrite)
+		sessionOnly := middleware.RequireSession()
+		// Billing, API tokens, and share links need a verified email when
+		// REQUIRE_VERIFIED_EMAIL is set
+		verifiedEmail := middleware.RequireVerifiedEmail(cfg.RequireVerifiedEmail)
 
 		// Auth
-		api.POST("/auth/google", authHandler.GoogleSignIn)
+		api.POST("/auth/google", sessionOnly, authHandler.GoogleSignIn)
 
 		// Profile
 		api.GET("/profile", profileHandler.GetProfile)
-		api.PUT("/profile", profileHandler.UpdateProfile)
-		api.PUT("/profile/skills", profileHandler.UpdateSkills)
+		api.PUT("/profile", sessionOnly, profileHandler.UpdateProfile)
+		api.DELETE("/profile", sessionOnly, profileHandler.DeleteProfile)
+		api.GET("/profile/export", sessionOnly, exportHandler.ExportAccount)
+		api.PUT("/profile/skills", sessionOnly, profileHandler.UpdateSkills)
 		api.GET("/profile/roles", profileHandler.GetRoleSuggestions)
+		api.GET("/profile/skills/suggestions", profileHandler.GetSkillSuggestions)
+
+		// Personal API tokens (managed from a signed-in session only)
+		api.GET("/tokens", sessionOnly, tokenHandler.List)
+		api.POST("/tokens", sessionOnly, verifiedEmail, tokenHandler.Create)
+		api.DELETE("/tokens/:id", sessionOnly, tokenHandler.Revoke)
 
 		// Billing (subscription management)
 		api.GET("/billing/subscription", billingHandler.GetSubscription)
-		api.POST("/billing/checkout", billingHandler.CreateCheckout)
-		api.POST("/billing/portal", billingHandler.CreatePortal)
+		api.POST("/billing/checkout", sessionOnly, verifiedEmail, billingHandler.CreateCheckout)
+		api.POST("/billing/portal", sessionOnly, verifiedEmail, billingHandler.CreatePortal)
 
 		// Jobs
 		api.GET("/jobs", jobHandler.ListJobs)
-		api.POST("/jobs", jobHandler.CreateJob)
+		api.GET("/jobs/counts", jobHandler.CountJobs)
+		api.POST("/jobs", jobsWrite, jobHandler.CreateJob)
 		api.GET("/jobs/:id", jobHandler.GetJob)
-		api.PUT("/jobs/:id", jobHandler.UpdateJob)
-		api.DELETE("/jobs/:id", jobHandler.DeleteJob)
-		api.POST("/jobs/:id/bookmark", jobHandler.ToggleBookmark)
-		api.PATCH("/jobs/:id/status", jobHandler.UpdateJobStatus)
+		api.PUT("/jobs/:id", jobsWrite, jobHandler.UpdateJob)
+		api.PATCH("/jobs/:id", jobsWrite, jobHandler.PatchJob)
+		api.DELETE("/jobs/:id", jobsWrite, jobHandler.DeleteJob)
+		api.POST("/jobs/:id/unarchive", jobsWrite, jobHandler.UnarchiveJob)
+		api.POST("/jobs/:id/duplicate", jobsWrite, jobHandler.DuplicateJob)
+		api.POST("/jobs/:id/bookmark", jobsWrite, jobHandler.ToggleBookmark)
+		api.PATCH("/jobs/:id/status", jobsWrite, jobHandler.UpdateJobStatus)
+		api.POST("/jobs/:id/share", jobsWrite, verifiedEmail, shareHandler.CreateShare)
+		api.GET("/jobs/:id/competition", competitionHandler.Get)
+		api.GET("/jobs/:id/export", exportHandler.ExportJob)
+		api.GET("/jobs/:id/timeline", timelineHandler.Get)
+		api.GET("/jobs/detect-source", parseHandler.DetectSource)
 
 		// Feed (discover)
 		api.GET("/feed", feedHandler.GetFeed)
-		api.POST("/feed/refresh", feedHandler.RefreshFeed)
-		api.POST("/feed/:id/dismiss", feedHandler.DismissFeedJob)
-		api.POST("/feed/:id/save", feedHandler.SaveFeedJob)
+		api.POST("/feed/refresh", jobsWrite, feedHandler.RefreshFeed)
+		api.GET("/feed/refresh/status", feedHandler.GetRefreshStatus)
+		api.GET("/feed/skill-demand", feedHandler.SkillDemand)
+		api.GET("/feed/salary-insights", feedHandler.SalaryInsights)
+		api.POST("/feed/:id/dismiss", jobsWrite, feedHandler.DismissFeedJob)
+		api.POST("/feed/:id/undismiss", jobsWrite, feedHandler.UndismissFeedJob)
+		api.POST("/feed/dismiss/bulk", jobsWrite, feedHandler.BulkDismissFeedJobs)
+		api.POST("/feed/:id/snooze", jobsWrite, feedHandler.SnoozeFeedJob)
+		api.POST("/feed/:id/save", jobsWrite, feedHandler.SaveFeedJob)
+		api.POST("/feed/:id/apply", jobsWrite, feedHandler.ApplyFeedJob)
+		api.POST("/feed/save/bulk", jobsWrite, feedHandler.BulkSaveFeedJobs)
+		api.POST("/feed/apply/bulk", jobsWrite, feedHandler.BulkApplyFeedJobs)
+
+		// Job alerts (saved searches run on each feed refresh)
+		api.GET("/alerts", alertHandler.List)
+		api.POST("/alerts", jobsWrite, alertHandler.Create)
+		api.DELETE("/alerts/:id", jobsWrite, alertHandler.Delete)
 
 		// Applications (pipeline tracking)
 		api.GET("/jobs/:id/application", appHandler.Get)
-		api.POST("/jobs/:id/application", appHandler.Create)
-		api.PUT("/jobs/:id/application/status", appHandler.UpdateStatus)
-		api.PUT("/jobs/:id/application/details", appHandler.UpdateDetails)
+		api.POST("/jobs/:id/application", jobsWrite, appHandler.Create)
+		api.PUT("/jobs/:id/application/status", jobsWrite, appHandler.UpdateStatus)
+		api.PUT("/jobs/:id/application/details", jobsWrite, appHandler.UpdateDetails)
+		api.DELETE("/jobs/:id/application", jobsWrite, appHandler.Delete)
 		api.GET("/jobs/:id/application/history", appHandler.GetHistory)
-
-		// Notes (TODO: implement handlers)
-		// api.GET("/jobs/:id/notes", noteHandler.List)
-		// api.POST("/jobs/:id/notes", noteHandler.Create)
-		// api.DELETE("/jobs/:id/notes/:noteId", noteHandler.Delete)
+		api.GET("/applications", appHandler.List)
+		api.GET("/applications/followups", appHandler.ListFollowUps)
+		api.GET("/applications/funnel", appHandler.GetFunnel)
+		api.POST("/applications/history/batch", appHandler.GetHistoryBatch)
+		api.GET("/applications/:id", appHandler.GetByID)
+		api.GET("/applications/:id/history", appHandler.GetHistoryByID)
+
+		// Notes
+		api.GET("/jobs/:id/notes", noteHandler.List)
+		api.POST("/jobs/:id/notes", jobsWrite, noteHandler.Create)
+		api.DELETE("/jobs/:id/notes/:noteId", jobsWrite, noteHandler.Delete)
 
 		// Contacts
 		api.GET("/contacts", contactHandler.List)
-		api.POST("/contacts", contactHandler.Create)
-		api.POST("/contacts/import/linkedin", contactHandler.ImportLinkedIn)
-		api.PUT("/contacts/:id", contactHandler.Update)
-		api.DELETE("/contacts/:id", contactHandler.Delete)
+		api.POST("/contacts", contactsWrite, contactHandler.Create)
+		api.POST("/contacts/import/linkedin", contactsWrite, contactHandler.ImportLinkedIn)
+		api.PUT("/contacts/:id", contactsWrite, contactHandler.Update)
+		api.DELETE("/contacts/:id", contactsWrite, contactHandler.Delete)
+
+		// Interview question bank
+		api.GET("/interview-questions", questionHandler.List)
+		api.POST("/interview-questions", jobsWrite, questionHandler.Create)
+		api.PUT("/interview-questions/:id", jobsWrite, questionHandler.Update)
+		api.DELETE("/interview-questions/:id", jobsWrite, questionHandler.Delete)
 
 		// Network (company aggregation)
 		api.GET("/network/companies", networkHandler.ListCompanies)
 		api.GET("/network/companies/:company/detail", networkHandler.GetCompanyDetail)
+		api.GET("/network/companies/:company/questions", networkHandler.GetCompanyQuestions)
+		api.GET("/jobs/:id/contacts", networkHandler.GetJobContacts)
 
 		// ── Pro+ features (require Pro plan) ─────────────
 		requirePro := middleware.RequirePlan("pro", subscriptionRepo)
+		// AI calls are expensive — tighter per-minute bucket, scaled by plan (must follow requirePro)
+		aiLimit := aiRateLimiter.Limit()
+		// Daily AI quotas per category, also after requirePro
+		quota := func(category string) gin.HandlerFunc {
+			return middleware.RequireAIQuota(category, aiUsageRepo)
+		}
 
-		api.POST("/jobs/parse", requirePro, parseHandler.ParseJobPosting)
-		api.POST("/ai/compare", requirePro, compareHandler.Compare)
-		api.POST("/feed/compare", requirePro, feedHandler.CompareFeedJobs)
-		api.GET("/company/intel", requirePro, companyHandler.GetIntel)
+		api.GET("/ai/quota", quotaHandler.GetQuota)
+		api.POST("/jobs/parse", requirePro, aiLimit, quota(model.AICategoryParse), parseHandler.ParseJobPosting)
+		api.POST("/jobs/parse/batch", requirePro, aiLimit, quota(model.AICategoryParse), parseHandler.ParseBatch)
+		api.POST("/jobs/:id/refresh", jobsWrite, requirePro, aiLimit, quota(model.AICategoryParse), parseHandler.RefreshJob)
+		// Skill gap analysis is free; only its ?suggest=true Claude mode is gated
+		suggesting := func(mw gin.HandlerFunc) gin.HandlerFunc { return middleware.WhenQuery("suggest", "true", mw) }
+		api.POST("/jobs/:id/skill-gap", suggesting(requirePro), suggesting(aiLimit), suggesting(quota(model.AICategorySkillGap)), skillGapHandler.Analyze)
+		api.POST("/ai/compare", requirePro, aiLimit, quota(model.AICategoryCompare), compareHandler.Compare)
+		api.GET("/feed/queries", requirePro, feedHandler.PreviewQueries)
+		api.POST("/feed/compare", requirePro, aiLimit, quota(model.AICategoryCompare), feedHandler.CompareFeedJobs)
+		api.GET("/company/intel", requirePro, aiLimit, quota(model.AICategoryCompanyIntel), companyHandler.GetIntel)
+		api.POST("/company/intel/batch", requirePro, aiLimit, quota(model.AICategoryCompanyIntel), companyHandler.GetIntelBatch)
+		api.POST("/ai/cover-letter", requirePro, aiLimit, quota(model.AICategoryCoverLetter), coverLetterHandler.Generate)
+		api.POST("/ai/interview-prep", requirePro, aiLimit, quota(model.AICategoryInterview), interviewHandler.Prep)
 
 		// Resume
 		api.POST("/resume/upload", resumeHandler.Upload)
-		api.POST("/resume/critique", requirePro, resumeHandler.Critique)
-		api.POST("/resume/fix", requirePro, resumeHandler.Fix)
-		api.POST("/resume/parse-profile", requirePro, resumeHandler.ParseToProfile)
+		api.POST("/resume/critique", requirePro, aiLimit, quota(model.AICategoryResume), resumeHandler.Critique)
+		api.POST("/resume/fix", requirePro, aiLimit, quota(model.AICategoryResume), resumeHandler.Fix)
+		api.POST("/resume/parse-profile", requirePro, aiLimit, quota(model.AICategoryResume), resumeHandler.ParseToProfile)
 	}
 
 	// ── Server ───────────────────────────────────────────
@@ -207,6 +374,35 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// ── Background jobs ──────────────────────────────────
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	if cfg.StripeSecretKey != "" && cfg.StripeReconcileInterval > 0 {
+		stripeService.StartReconcileLoop(bgCtx, cfg.StripeReconcileInterval)
+		log.Info().Dur("interval", cfg.StripeReconcileInterval).Msg("Stripe subscription reconciliation enabled")
+	}
+
+	if cfg.LivenessCheckInterval > 0 && cfg.LivenessMaxChecks > 0 {
+		service.NewLivenessChecker(feedRepo, cfg.LivenessMaxChecks).StartLoop(bgCtx, cfg.LivenessCheckInterval)
+		log.Info().Dur("interval", cfg.LivenessCheckInterval).Int("maxChecks", cfg.LivenessMaxChecks).Msg("Feed liveness checks enabled")
+	}
+
+	if cfg.FeedSchedulerHour >= 0 && cfg.FeedSchedulerHour < 24 {
+		service.NewFeedScheduler(feedService, feedRepo, cfg.FeedSchedulerConcurrency, cfg.FeedSchedulerSourceRPM).
+			StartDailyLoop(bgCtx, cfg.FeedSchedulerHour)
+		log.Info().
+			Int("hourUTC", cfg.FeedSchedulerHour).
+			Int("concurrency", cfg.FeedSchedulerConcurrency).
+			Int("sourceRPM", cfg.FeedSchedulerSourceRPM).
+			Msg("Scheduled feed refresh enabled")
+	}
+
+	if cfg.FollowUpRemindHour >= 0 && cfg.FollowUpRemindHour < 24 {
+		followUpReminder.StartDailyLoop(bgCtx, cfg.FollowUpRemindHour)
+		log.Info().Int("hourUTC", cfg.FollowUpRemindHour).Bool("smtp", cfg.SMTPHost != "").Msg("Follow-up reminders enabled")
+	}
+
 	// Graceful shutdown
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -222,6 +418,7 @@ func main() {
 	<-quit
 
 	log.Info().Msg("Shutting down server...")
+	stopBackground()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -235,6 +432,12 @@ func main() {
 // resolveUserID maps Firebase UID to internal user UUID for all subsequent handlers
 func resolveUserID(userRepo *repository.UserRepo) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// API token auth already resolved the user
+		if middleware.GetUserID(c) != "" {
+			c.Next()
+			return
+		}
+
 		firebaseUID := middleware.GetFirebaseUID(c)
 		if firebaseUID == "" {
 			c.Next()
@@ -255,8 +458,10 @@ func resolveUserID(userRepo *repository.UserRepo) gin.HandlerFunc {
 	}
 }
 
-// requestLogger logs every request with zerolog
-func requestLogger() gin.HandlerFunc {
+// requestLogger logs every request with zerolog. Requests slower than
+// slowThreshold (0 disables) are tagged slow=true and logged one level higher,
+// so they can be alerted on.
+func requestLogger(slowThreshold time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -265,21 +470,32 @@ func requestLogger() gin.HandlerFunc {
 
 		latency := time.Since(start)
 		status := c.Writer.Status()
+		slow := slowThreshold > 0 && latency > slowThreshold
 
-		event := log.Info()
+		level := zerolog.InfoLevel
 		if status >= 400 {
-			event = log.Warn()
+			level = zerolog.WarnLevel
 		}
 		if status >= 500 {
-			event = log.Error()
+			level = zerolog.ErrorLevel
+		}
+		if slow && level < zerolog.ErrorLevel {
+			level++
 		}
 
-		event.
+		event := log.WithLevel(level).
 			Str("method", c.Request.Method).
 			Str("path", path).
 			Int("status", status).
 			Dur("latency", latency).
 			Str("ip", c.ClientIP()).
-			Msg(fmt.Sprintf("%s %s", c.Request.Method, path))
+			Str("requestId", middleware.GetRequestID(c))
+		if userID := middleware.GetUserID(c); userID != "" {
+			event = event.Str("userId", userID)
+		}
+		if slow {
+			event = event.Bool("slow", true)
+		}
+		event.Msg(fmt.Sprintf("%s %s", c.Request.Method, path))
 	}
 }
@@ -0,0 +1,47 @@
+// Package apierror defines the JSON error body every endpoint returns,
+// {"error": {"code": "...", "message": "..."}}, and the stable codes clients
+// can branch on. Messages are human-readable and may change; codes don't.
+package apierror
+
+import "github.com/gin-gonic/gin"
+
+// Error codes
+const (
+	Unauthenticated   = "unauthenticated"    // missing or invalid credentials (401)
+	InvalidInput      = "invalid_input"      // malformed or out-of-range request (400, 413, 422)
+	NotFound          = "not_found"          // no such resource for this user (404)
+	Forbidden         = "forbidden"          // authenticated but not allowed (403)
+	InsufficientScope = "insufficient_scope" // API token lacks the route's scope (403)
+	EmailUnverified   = "email_unverified"   // the route needs a verified email address (403)
+	AccountDisabled   = "account_disabled"   // the Firebase account is disabled or deleted (403)
+	Conflict          = "conflict"           // clashes with current state, e.g. a stale update or a limit (409)
+	UpgradeRequired   = "upgrade_required"   // needs a higher plan (402)
+	RateLimited       = "rate_limited"       // too many requests; see Retry-After (429)
+	AIQuotaExceeded   = "ai_quota_exceeded"  // daily AI quota used up (429)
+	AIFailed          = "ai_failed"          // the AI call failed or returned something unusable (500)
+	UpstreamFailed    = "upstream_failed"    // a third-party fetch failed (502)
+	Unavailable       = "unavailable"        // maintenance mode, a full AI queue, or a dependency is down (503)
+	Internal          = "internal"           // anything else on our side (500)
+)
+
+// Error is the value of the "error" key in an error response
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// New returns an error value for responses that carry extra keys beside
+// "error" (e.g. a limit or the current version of a record)
+func New(code, message string) Error {
+	return Error{Code: code, Message: message}
+}
+
+// Respond writes {"error": {code, message}} with the given status
+func Respond(c *gin.Context, status int, code, message string) {
+	c.JSON(status, gin.H{"error": New(code, message)})
+}
+
+// Abort is Respond for middleware: it also stops the handler chain
+func Abort(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{"error": New(code, message)})
+}
@@ -6,33 +6,92 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	// Server
 	Port string
-	Env  string // development, staging, production
+	Env  string // development, staging, production (the default when unset)
 
 	// Database
-	DatabaseURL string
+	DatabaseURL     string
+	DatabaseReadURL string // optional read replica for feed and job list queries
 
 	// Firebase
 	FirebaseProjectID string
+	// Look up each Firebase user (cached briefly) so disabled accounts are
+	// cut off before their ID tokens expire
+	FirebaseCheckDisabled bool
+	// How many verified ID tokens to remember until they expire, so bursts
+	// from one client skip signature checks; 0 disables
+	FirebaseTokenCacheSize int
+	// Require a verified email for sensitive routes (billing, API tokens, share links)
+	RequireVerifiedEmail bool
 
 	// Claude API
 	ClaudeAPIKey  string
 	ClaudeBaseURL string
+	// Concurrent Claude calls (0 = unlimited) and how many more may wait for
+	// a slot before AI requests get 503
+	AIMaxInFlight int
+	AIMaxQueued   int
 
 	// Job Feed
 	RapidAPIKey  string
 	AdzunaAppID  string
 	AdzunaAppKey string
 
+	// Minimum time between non-forced feed refreshes, per plan
+	FeedRefreshIntervalFree    time.Duration
+	FeedRefreshIntervalPro     time.Duration
+	FeedRefreshIntervalProPlus time.Duration
+
+	// Default relevance floor for GET /feed: jobs scoring below it are hidden
+	// unless the request passes ?minScore (0 shows everything)
+	FeedMinScore int
+
+	// Cap on search queries each feed source (JSearch, Remotive, Adzuna) runs per refresh
+	FeedMaxQueriesPerSource int
+
+	// Nightly refresh of recently active users' feeds
+	FeedSchedulerHour        int // UTC hour of the run; negative disables
+	FeedSchedulerConcurrency int // users refreshed in parallel
+	FeedSchedulerSourceRPM   int // queries per minute to each source, shared across the run (0 = unlimited)
+
+	// Background check of saved / top-of-feed apply URLs for taken-down postings (0 disables)
+	LivenessCheckInterval time.Duration
+	LivenessMaxChecks     int // apply URLs requested per pass
+
+	// USD per unit overrides for salary currency conversion in match scoring
+	CurrencyRates map[string]float64
+
+	// Logo endpoint for filling in missing company logos; the company domain is
+	// appended (empty disables enrichment)
+	BrandLogoURL string
+
 	// Cloud Storage
 	StorageBucket string
 
+	// Scraping (job posting URL fetches)
+	ScraperUserAgents     []string // rotated per request; empty uses the built-in browser pool
+	ScraperAccept         string
+	ScraperAcceptLanguage string
+	ScraperSpoofBrowser   bool     // false sends a plain bot user-agent everywhere
+	ScraperPlainHosts     []string // hosts that get the plain user-agent even when spoofing
+
+	// Outbound request timeouts; all clients share one pooled transport
+	ClaudeTimeout    time.Duration
+	JobSourceTimeout time.Duration // JSearch, Remotive, Adzuna
+	ScraperTimeout   time.Duration // job posting URL fetches
+	FinanceTimeout   time.Duration // Yahoo Finance company intel
+
+	// Requests slower than this are logged with slow=true at a raised level (0 disables)
+	SlowRequestMS int
+
 	// Rate Limiting
-	RateLimitRPS int
+	RateLimitRPS      int
+	RateLimitAIPerMin int // per-user AI endpoint allowance, scaled up by plan
 
 	// Stripe
 	StripeSecretKey      string
@@ -43,8 +102,48 @@ type Config struct {
 	StripePriceProPlusAn string // Stripe Price ID for Pro+ annual
 	FrontendURL          string
 
-	// CORS
+	// How often to reconcile subscriptions against Stripe (catches missed webhooks); 0 disables
+	StripeReconcileInterval time.Duration
+
+	// Notes per job before POST /jobs/:id/notes starts returning 409 (0 = unlimited)
+	MaxNotesPerJob int
+
+	// Job sharing — HMAC secret for public share links (sharing disabled if empty)
+	ShareSigningSecret string
+
+	// Email (follow-up reminders) — emails are only logged if SMTPHost is empty
+	SMTPHost           string
+	SMTPPort           int
+	SMTPUsername       string
+	SMTPPassword       string
+	SMTPFrom           string
+	FollowUpRemindHour int // UTC hour of the daily follow-up reminder run; negative disables
+	// How far in the past a follow-up date may be set; older dates are rejected
+	FollowUpMaxPast time.Duration
+
+	// CORS — exact origins, plus "https://*.example.com" patterns where "*"
+	// is one subdomain label (for preview deploys)
 	AllowedOrigins []string
+
+	// Resume critique rubric (categories, severities, scoring guidance) read
+	// from CRITIQUE_RUBRIC_FILE; empty uses the built-in rubric
+	CritiqueRubric string
+
+	// Wrap list responses as {"data", "meta"} by default; clients can pick
+	// either shape per request with an Accept media type
+	ResponseEnvelope bool
+
+	// Maintenance mode: mutating routes return 503 from startup (toggle at
+	// runtime with PUT /admin/maintenance, authorized by AdminToken)
+	MaintenanceMode bool
+	AdminToken      string
+
+	// IPs/CIDRs of load balancers allowed to set X-Forwarded-For; empty trusts
+	// none, so the client IP is always the connection's remote address
+	TrustedProxies []string
+
+	// Log timestamp format: "rfc3339" (UTC, matching API responses) or "unix"
+	LogTimeFormat string
 }
 
 func Load() (*Config, error) {
@@ -53,16 +152,45 @@ func Load() (*Config, error) {
 
 	cfg := &Config{
 		Port:           getEnv("PORT", "8080"),
-		Env:            getEnv("ENV", "development"),
+		Env:            getEnv("ENV", "production"),
 		DatabaseURL:    getEnv("DATABASE_URL", ""),
+		DatabaseReadURL: getEnv("DATABASE_READ_URL", ""),
 		FirebaseProjectID: getEnv("FIREBASE_PROJECT_ID", ""),
+		FirebaseCheckDisabled: getEnvBool("FIREBASE_CHECK_DISABLED", true),
+		RequireVerifiedEmail: getEnvBool("REQUIRE_VERIFIED_EMAIL", false),
+		FirebaseTokenCacheSize: getEnvInt("FIREBASE_TOKEN_CACHE_SIZE", 10000),
 		ClaudeAPIKey:   getEnv("CLAUDE_API_KEY", ""),
 		ClaudeBaseURL:  getEnv("CLAUDE_BASE_URL", "https://api.anthropic.com"),
+		AIMaxInFlight:  getEnvInt("AI_MAX_IN_FLIGHT", 8),
+		AIMaxQueued:    getEnvInt("AI_MAX_QUEUED", 32),
 		RapidAPIKey:    getEnv("RAPIDAPI_KEY", ""),
 		AdzunaAppID:   getEnv("ADZUNA_APP_ID", ""),
 		AdzunaAppKey:  getEnv("ADZUNA_APP_KEY", ""),
+		FeedRefreshIntervalFree:    getEnvDuration("FEED_REFRESH_INTERVAL_FREE", 6*time.Hour),
+		FeedRefreshIntervalPro:     getEnvDuration("FEED_REFRESH_INTERVAL_PRO", 2*time.Hour),
+		FeedRefreshIntervalProPlus: getEnvDuration("FEED_REFRESH_INTERVAL_PRO_PLUS", time.Hour),
+		FeedMaxQueriesPerSource:    getEnvInt("FEED_MAX_QUERIES_PER_SOURCE", 6),
+		FeedMinScore:               getEnvInt("FEED_MIN_SCORE", 40),
+		FeedSchedulerHour:          getEnvInt("FEED_SCHEDULER_HOUR", -1),
+		FeedSchedulerConcurrency:   getEnvInt("FEED_SCHEDULER_CONCURRENCY", 4),
+		FeedSchedulerSourceRPM:     getEnvInt("FEED_SCHEDULER_SOURCE_RPM", 30),
+		LivenessCheckInterval:      getEnvDuration("LIVENESS_CHECK_INTERVAL", 0),
+		LivenessMaxChecks:          getEnvInt("LIVENESS_MAX_CHECKS", 100),
+		CurrencyRates:              getEnvRates("CURRENCY_RATES"),
+		BrandLogoURL:               getEnv("BRAND_LOGO_URL", "https://logo.clearbit.com/"),
 		StorageBucket:  getEnv("STORAGE_BUCKET", ""),
+		ScraperUserAgents:     getEnvList("SCRAPER_USER_AGENTS", "|"),
+		ScraperAccept:         getEnv("SCRAPER_ACCEPT", ""),
+		ScraperAcceptLanguage: getEnv("SCRAPER_ACCEPT_LANGUAGE", ""),
+		ScraperSpoofBrowser:   getEnvBool("SCRAPER_SPOOF_BROWSER", true),
+		ScraperPlainHosts:     getEnvList("SCRAPER_PLAIN_HOSTS", ","),
+		ClaudeTimeout:         getEnvDuration("CLAUDE_TIMEOUT", 30*time.Second),
+		JobSourceTimeout:      getEnvDuration("JOB_SOURCE_TIMEOUT", 20*time.Second),
+		ScraperTimeout:        getEnvDuration("SCRAPER_TIMEOUT", 20*time.Second),
+		FinanceTimeout:        getEnvDuration("FINANCE_TIMEOUT", 15*time.Second),
+		SlowRequestMS:       getEnvInt("SLOW_REQUEST_MS", 2000),
 		RateLimitRPS:        getEnvInt("RATE_LIMIT_RPS", 10),
+		RateLimitAIPerMin:   getEnvInt("RATE_LIMIT_AI_PER_MIN", 5),
 		StripeSecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
 		StripeWebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
 		StripePriceProMo:    getEnv("STRIPE_PRICE_PRO_MONTHLY", ""),
@@ -70,10 +198,49 @@ func Load() (*Config, error) {
 		StripePriceProPlusMo: getEnv("STRIPE_PRICE_PROPLUS_MONTHLY", ""),
 		StripePriceProPlusAn: getEnv("STRIPE_PRICE_PROPLUS_ANNUAL", ""),
 		FrontendURL:         getEnv("FRONTEND_URL", "http://localhost:5173"),
-		AllowedOrigins: []string{
-			"http://localhost:5173",
-			"https://hireiq.app",
-		},
+		StripeReconcileInterval: getEnvDuration("STRIPE_RECONCILE_INTERVAL", 6*time.Hour),
+		ShareSigningSecret:  getEnv("SHARE_SIGNING_SECRET", ""),
+		MaxNotesPerJob:      getEnvInt("MAX_NOTES_PER_JOB", 200),
+		SMTPHost:            getEnv("SMTP_HOST", ""),
+		SMTPPort:            getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:        getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:        getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:            getEnv("SMTP_FROM", "HireIQ <noreply@hireiq.app>"),
+		FollowUpRemindHour:  getEnvInt("FOLLOWUP_REMIND_HOUR", 13),
+		FollowUpMaxPast:     getEnvDuration("FOLLOWUP_MAX_PAST", 24*time.Hour),
+		AllowedOrigins: getEnvList("ALLOWED_ORIGINS", ","),
+		ResponseEnvelope: getEnvBool("RESPONSE_ENVELOPE", false),
+		TrustedProxies: getEnvList("TRUSTED_PROXIES", ","),
+		MaintenanceMode: getEnvBool("MAINTENANCE_MODE", false),
+		AdminToken:      getEnv("ADMIN_TOKEN", ""),
+		LogTimeFormat:   strings.ToLower(getEnv("LOG_TIME_FORMAT", "rfc3339")),
+	}
+
+	if !getEnvBool("BRAND_ENRICHMENT_ENABLED", true) {
+		cfg.BrandLogoURL = ""
+	}
+
+	if path := getEnv("CRITIQUE_RUBRIC_FILE", ""); path != "" {
+		rubric, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("CRITIQUE_RUBRIC_FILE: %w", err)
+		}
+		cfg.CritiqueRubric = string(rubric)
+	}
+
+	if len(cfg.AllowedOrigins) == 0 {
+		cfg.AllowedOrigins = defaultAllowedOrigins
+	}
+	for i, origin := range cfg.AllowedOrigins {
+		origin = strings.TrimSuffix(origin, "/")
+		if err := validateOrigin(origin); err != nil {
+			return nil, fmt.Errorf("ALLOWED_ORIGINS: %w", err)
+		}
+		cfg.AllowedOrigins[i] = origin
+	}
+
+	if cfg.LogTimeFormat != "rfc3339" && cfg.LogTimeFormat != "unix" {
+		return nil, fmt.Errorf("LOG_TIME_FORMAT: %q must be rfc3339 or unix", cfg.LogTimeFormat)
 	}
 
 	if cfg.DatabaseURL == "" {
@@ -83,6 +250,63 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Validate checks for settings that can't be defaulted. Outside production
+// missing keys only disable features; in production every key a core feature
+// depends on is required, and all missing ones are reported together.
+func (c *Config) Validate() error {
+	if c.Env != "production" {
+		return nil
+	}
+
+	required := []struct{ key, value string }{
+		{"FIREBASE_PROJECT_ID", c.FirebaseProjectID},
+		{"CLAUDE_API_KEY", c.ClaudeAPIKey},
+		{"STRIPE_SECRET_KEY", c.StripeSecretKey},
+		{"STRIPE_WEBHOOK_SECRET", c.StripeWebhookSecret},
+	}
+
+	var missing []string
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			missing = append(missing, r.key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required config for production: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// defaultAllowedOrigins are the CORS origins used when ALLOWED_ORIGINS is unset
+var defaultAllowedOrigins = []string{
+	"http://localhost:5173",
+	"https://hireiq.app",
+}
+
+// validateOrigin checks a CORS origin is "scheme://host[:port]" or a
+// subdomain pattern "scheme://*.host[:port]". A bare "*" is rejected: CORS
+// runs with credentials allowed, so it would let any site make
+// authenticated requests.
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return fmt.Errorf("%q isn't allowed because requests carry credentials; list each origin instead", origin)
+	}
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || (scheme != "http" && scheme != "https") {
+		return fmt.Errorf("%q must start with http:// or https://", origin)
+	}
+	if host == "" || strings.ContainsAny(host, "/?#") {
+		return fmt.Errorf("%q must be a scheme and host with no path", origin)
+	}
+	if strings.Contains(host, "*") {
+		rest, isPattern := strings.CutPrefix(host, "*.")
+		if !isPattern || rest == "" || strings.Contains(rest, "*") {
+			return fmt.Errorf("%q: a wildcard must be a single leading subdomain, like https://*.example.com", origin)
+		}
+	}
+	return nil
+}
+
 // loadEnvFile reads a .env file and sets environment variables.
 // Silently skips if the file doesn't exist (production uses real env vars).
 func loadEnvFile(path string) {
@@ -132,3 +356,54 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvBool(key string, fallback bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// getEnvDuration parses Go duration strings like "90m" or "2h"
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if d, err := time.ParseDuration(val); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// getEnvList splits a delimited env var into trimmed, non-empty values.
+// User-agent strings contain commas, so callers choose the separator.
+func getEnvList(key, sep string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(val, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// getEnvRates parses "GBP=1.27,EUR=1.08" into a code -> rate map, skipping
+// malformed entries
+func getEnvRates(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, pair := range getEnvList(key, ",") {
+		code, rate, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(rate), 64); err == nil && f > 0 {
+			rates[strings.ToUpper(strings.TrimSpace(code))] = f
+		}
+	}
+	return rates
+}
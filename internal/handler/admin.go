@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
+)
+
+// AdminHandler serves operator endpoints, guarded by ADMIN_TOKEN rather than
+// user auth
+type AdminHandler struct {
+	maintenance *middleware.MaintenanceMode
+}
+
+func NewAdminHandler(maintenance *middleware.MaintenanceMode) *AdminHandler {
+	return &AdminHandler{maintenance: maintenance}
+}
+
+type setMaintenanceRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// GetMaintenance handles GET /admin/maintenance
+func (h *AdminHandler) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"enabled": h.maintenance.Enabled()})
+}
+
+// SetMaintenance handles PUT /admin/maintenance
+// Turns read-only maintenance mode on or off for this instance.
+func (h *AdminHandler) SetMaintenance(c *gin.Context) {
+	var req setMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Enabled == nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "enabled (true or false) is required")
+		return
+	}
+
+	h.maintenance.Set(*req.Enabled)
+	log.Warn().Bool("enabled", *req.Enabled).Str("ip", c.ClientIP()).Msg("Maintenance mode changed")
+
+	c.JSON(http.StatusOK, gin.H{"enabled": *req.Enabled})
+}
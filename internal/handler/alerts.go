@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+type AlertHandler struct {
+	alertRepo *repository.JobAlertRepo
+	// maxAlerts caps saved searches per user at what a feed refresh runs;
+	// each one adds a query per source to every refresh
+	maxAlerts int
+}
+
+func NewAlertHandler(alertRepo *repository.JobAlertRepo, maxAlerts int) *AlertHandler {
+	return &AlertHandler{alertRepo: alertRepo, maxAlerts: maxAlerts}
+}
+
+type createAlertRequest struct {
+	Name      string `json:"name"`
+	Keywords  string `json:"keywords"`
+	Location  string `json:"location"`  // "" = anywhere, "remote" = remote only
+	SalaryMin int    `json:"salaryMin"` // in the user's salary currency; 0 = no floor
+}
+
+// List handles GET /alerts
+func (h *AlertHandler) List(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	alerts, err := h.alertRepo.ListByUser(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list job alerts")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list alerts")
+		return
+	}
+
+	if alerts == nil {
+		alerts = []model.JobAlert{}
+	}
+
+	respondList(c, alerts, gin.H{"limit": h.maxAlerts})
+}
+
+// Create handles POST /alerts
+// Saves a search that every feed refresh runs alongside the profile queries.
+func (h *AlertHandler) Create(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req createAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Keywords = strings.TrimSpace(req.Keywords)
+	req.Location = strings.TrimSpace(req.Location)
+	if req.Keywords == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "keywords is required")
+		return
+	}
+	if req.Name == "" {
+		req.Name = req.Keywords
+	}
+	if req.SalaryMin < 0 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "salaryMin must not be negative")
+		return
+	}
+
+	count, err := h.alertRepo.CountByUser(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count job alerts")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create alert")
+		return
+	}
+	if count >= h.maxAlerts {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": apierror.New(apierror.Conflict, "Job alert limit reached"),
+			"limit": h.maxAlerts,
+		})
+		return
+	}
+
+	created, err := h.alertRepo.Create(c.Request.Context(), &model.JobAlert{
+		UserID:    userID,
+		Name:      req.Name,
+		Keywords:  req.Keywords,
+		Location:  req.Location,
+		SalaryMin: req.SalaryMin,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create job alert")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create alert")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// Delete handles DELETE /alerts/:id
+// Jobs the alert already found stay in the feed.
+func (h *AlertHandler) Delete(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid alert ID")
+		return
+	}
+
+	deleted, err := h.alertRepo.Delete(c.Request.Context(), alertID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete job alert")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to delete alert")
+		return
+	}
+	if !deleted {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Alert not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
@@ -1,23 +1,30 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
 )
 
 type ApplicationHandler struct {
 	appRepo *repository.ApplicationRepo
 	jobRepo *repository.JobRepo
+	events  *service.StatusEventBus
+	// followUpMaxPast is how far in the past a follow-up date may be set
+	followUpMaxPast time.Duration
 }
 
-func NewApplicationHandler(appRepo *repository.ApplicationRepo, jobRepo *repository.JobRepo) *ApplicationHandler {
-	return &ApplicationHandler{appRepo: appRepo, jobRepo: jobRepo}
+func NewApplicationHandler(appRepo *repository.ApplicationRepo, jobRepo *repository.JobRepo, events *service.StatusEventBus, followUpMaxPast time.Duration) *ApplicationHandler {
+	return &ApplicationHandler{appRepo: appRepo, jobRepo: jobRepo, events: events, followUpMaxPast: followUpMaxPast}
 }
 
 // Get returns the application for a specific job
@@ -25,20 +32,20 @@ func NewApplicationHandler(appRepo *repository.ApplicationRepo, jobRepo *reposit
 func (h *ApplicationHandler) Get(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
 	app, err := h.appRepo.FindByJobID(c.Request.Context(), userID, jobID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get application")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get application"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get application")
 		return
 	}
 
@@ -51,18 +58,19 @@ func (h *ApplicationHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, app)
 }
 
-// Create creates a new application for a job
+// Create creates a new application for a job (201). If the job already has
+// one, it's returned unchanged with 200.
 // POST /jobs/:id/application
 func (h *ApplicationHandler) Create(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
@@ -72,9 +80,10 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		NextStep     string  `json:"nextStep"`
 		FollowUpDate *string `json:"followUpDate"`
 		FollowUpType string  `json:"followUpType"`
+		Outcome      string  `json:"outcome"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
@@ -84,7 +93,12 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		status = model.StatusApplied
 	}
 	if !model.ValidStatus(status) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid status")
+		return
+	}
+	outcome, err := resolveOutcome(status, req.Outcome)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
 		return
 	}
 
@@ -96,12 +110,10 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 			appliedAt = &t
 		}
 	}
-	var followUpDate *time.Time
-	if req.FollowUpDate != nil {
-		t, err := time.Parse(time.RFC3339, *req.FollowUpDate)
-		if err == nil {
-			followUpDate = &t
-		}
+	followUpDate, err := parseFollowUpDate(req.FollowUpDate, nil, h.followUpMaxPast)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
+		return
 	}
 
 	app := &model.Application{
@@ -112,12 +124,19 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		NextStep:     req.NextStep,
 		FollowUpDate: followUpDate,
 		FollowUpType: req.FollowUpType,
+		Outcome:      outcome,
 	}
 
-	created, err := h.appRepo.Create(c.Request.Context(), app)
+	created, isNew, err := h.appRepo.Create(c.Request.Context(), app)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create application")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create application"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create application")
+		return
+	}
+
+	// The job is already tracked (e.g. a double submit): return it as is
+	if !isNew {
+		c.JSON(http.StatusOK, created)
 		return
 	}
 
@@ -126,35 +145,53 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		log.Warn().Err(syncErr).Msg("Failed to sync job status after application create")
 	}
 
+	h.events.Publish(c.Request.Context(), model.StatusChangeEvent{
+		UserID:        userID,
+		ApplicationID: created.ID,
+		JobID:         jobID,
+		ToStatus:      created.Status,
+		Outcome:       created.Outcome,
+		ChangedAt:     created.CreatedAt,
+	})
+
 	c.JSON(http.StatusCreated, created)
 }
 
-// UpdateStatus changes the application status and records history
+// UpdateStatus changes the application status and records history.
+// An optional outcome closes the application out ("ghosted" can be set
+// while the status stays "applied"); moving to an open status without one
+// reopens it.
 // PUT /jobs/:id/application/status
 func (h *ApplicationHandler) UpdateStatus(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
 	var req struct {
-		Status string `json:"status" binding:"required"`
-		Note   string `json:"note"`
+		Status  string `json:"status" binding:"required"`
+		Outcome string `json:"outcome"`
+		Note    string `json:"note"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Status is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Status is required")
 		return
 	}
 
 	if !model.ValidStatus(req.Status) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid status")
+		return
+	}
+	outcome, err := resolveOutcome(req.Status, req.Outcome)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
 		return
 	}
 
@@ -162,41 +199,156 @@ func (h *ApplicationHandler) UpdateStatus(c *gin.Context) {
 	app, err := h.appRepo.FindByJobID(c.Request.Context(), userID, jobID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to find application")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find application"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to find application")
 		return
 	}
 	if app == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
 		return
 	}
 
-	updated, err := h.appRepo.UpdateStatus(c.Request.Context(), app.ID, userID, req.Status, req.Note)
+	updated, changed, err := h.appRepo.UpdateStatus(c.Request.Context(), app.ID, userID, req.Status, outcome, req.Note)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to update application status")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update status"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update status")
 		return
 	}
 
-	// Sync jobs.status to keep Kanban board consistent
+	// Sync jobs.status to keep Kanban board consistent. Done for no-op changes
+	// too, so a job whose status drifted from its application is repaired.
 	if syncErr := h.jobRepo.UpdateStatus(c.Request.Context(), jobID, userID, req.Status); syncErr != nil {
 		log.Warn().Err(syncErr).Msg("Failed to sync job status after application status update")
 	}
+	if !changed {
+		c.JSON(http.StatusOK, updated)
+		return
+	}
+
+	h.events.Publish(c.Request.Context(), model.StatusChangeEvent{
+		UserID:        userID,
+		ApplicationID: app.ID,
+		JobID:         jobID,
+		FromStatus:    app.Status,
+		ToStatus:      updated.Status,
+		Outcome:       updated.Outcome,
+		Note:          req.Note,
+		ChangedAt:     updated.UpdatedAt,
+	})
 
 	c.JSON(http.StatusOK, updated)
 }
 
+// Delete removes the job's application (e.g. one added by mistake) with its
+// status history and moves the job back to "saved". The job itself and its
+// notes are kept.
+// DELETE /jobs/:id/application
+func (h *ApplicationHandler) Delete(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	app, err := h.appRepo.FindByJobID(c.Request.Context(), userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find application")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to delete application")
+		return
+	}
+	if app == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
+		return
+	}
+
+	deleted, err := h.appRepo.Delete(c.Request.Context(), app.ID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete application")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to delete application")
+		return
+	}
+	if !deleted {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+// resolveOutcome validates an outcome against the status it's set with.
+// Rejected and withdrawn statuses imply their outcome; accepting or declining
+// requires an offer.
+func resolveOutcome(status, outcome string) (string, error) {
+	if !model.ValidOutcome(outcome) {
+		return "", errors.New("Invalid outcome")
+	}
+
+	switch status {
+	case model.StatusRejected, model.StatusWithdrawn:
+		if outcome != "" && outcome != status {
+			return "", fmt.Errorf("Outcome must be %q for status %q", status, status)
+		}
+		return status, nil
+	}
+
+	if (outcome == model.OutcomeAccepted || outcome == model.OutcomeDeclinedOffer) && status != model.StatusOffer {
+		return "", fmt.Errorf("Outcome %q requires status %q", outcome, model.StatusOffer)
+	}
+	return outcome, nil
+}
+
+// parseFollowUpDate parses an optional RFC3339 follow-up date. Dates further
+// in the past than maxPast are rejected: they'd sit in the overdue list
+// forever, and are almost always a typo'd year. A date equal to current (the
+// one already stored) is accepted regardless, so an overdue follow-up doesn't
+// block editing the rest of the application.
+func parseFollowUpDate(s *string, current *time.Time, maxPast time.Duration) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return nil, errors.New("followUpDate must be an RFC3339 timestamp")
+	}
+	if current != nil && t.Equal(*current) {
+		return &t, nil
+	}
+	if t.Before(time.Now().Add(-maxPast)) {
+		return nil, fmt.Errorf("followUpDate can't be more than %s in the past", formatMaxPast(maxPast))
+	}
+	return &t, nil
+}
+
+// formatMaxPast renders a duration for error messages: whole days as "1 day"
+// or "3 days", anything else as Go prints it
+func formatMaxPast(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 day"
+	case d > day && d%day == 0:
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
+
 // UpdateDetails updates follow-up fields without changing status
 // PUT /jobs/:id/application/details
 func (h *ApplicationHandler) UpdateDetails(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
@@ -207,7 +359,7 @@ func (h *ApplicationHandler) UpdateDetails(c *gin.Context) {
 		FollowUpUrgent bool    `json:"followUpUrgent"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
@@ -215,21 +367,18 @@ func (h *ApplicationHandler) UpdateDetails(c *gin.Context) {
 	app, err := h.appRepo.FindByJobID(c.Request.Context(), userID, jobID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to find application")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find application"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to find application")
 		return
 	}
 	if app == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
 		return
 	}
 
-	// Parse optional follow-up date
-	var followUpDate *time.Time
-	if req.FollowUpDate != nil {
-		t, err := time.Parse(time.RFC3339, *req.FollowUpDate)
-		if err == nil {
-			followUpDate = &t
-		}
+	followUpDate, err := parseFollowUpDate(req.FollowUpDate, app.FollowUpDate, h.followUpMaxPast)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
+		return
 	}
 
 	updated, err := h.appRepo.UpdateDetails(
@@ -238,7 +387,7 @@ func (h *ApplicationHandler) UpdateDetails(c *gin.Context) {
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to update application details")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update details"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update details")
 		return
 	}
 
@@ -250,13 +399,13 @@ func (h *ApplicationHandler) UpdateDetails(c *gin.Context) {
 func (h *ApplicationHandler) GetHistory(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
@@ -264,18 +413,18 @@ func (h *ApplicationHandler) GetHistory(c *gin.Context) {
 	app, err := h.appRepo.FindByJobID(c.Request.Context(), userID, jobID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to find application")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find application"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to find application")
 		return
 	}
 	if app == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
 		return
 	}
 
 	history, err := h.appRepo.GetHistory(c.Request.Context(), app.ID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get application history")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get history"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get history")
 		return
 	}
 
@@ -283,5 +432,233 @@ func (h *ApplicationHandler) GetHistory(c *gin.Context) {
 		history = []model.StatusHistory{}
 	}
 
-	c.JSON(http.StatusOK, history)
+	respondList(c, history, nil)
+}
+
+// GetByID returns an application addressed by its own ID, with its job attached
+// GET /applications/:id
+func (h *ApplicationHandler) GetByID(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid application ID")
+		return
+	}
+
+	app, err := h.appRepo.FindByID(c.Request.Context(), appID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get application")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get application")
+		return
+	}
+	if app == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), app.JobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get application job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get application")
+		return
+	}
+	app.Job = job
+
+	c.JSON(http.StatusOK, app)
+}
+
+// GetHistoryByID returns the status change timeline for an application by ID
+// GET /applications/:id/history
+func (h *ApplicationHandler) GetHistoryByID(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid application ID")
+		return
+	}
+
+	// Ownership check before reading history (GetHistory isn't user-scoped)
+	app, err := h.appRepo.FindByID(c.Request.Context(), appID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find application")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to find application")
+		return
+	}
+	if app == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
+		return
+	}
+
+	history, err := h.appRepo.GetHistory(c.Request.Context(), app.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get application history")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get history")
+		return
+	}
+
+	if history == nil {
+		history = []model.StatusHistory{}
+	}
+
+	respondList(c, history, nil)
+}
+
+// List returns the user's applications with a job summary, most recently
+// updated first. ?status= narrows to one stage. Send Accept: text/csv for a
+// spreadsheet export of the same list.
+// GET /applications
+func (h *ApplicationHandler) List(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && !model.ValidStatus(status) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid status")
+		return
+	}
+
+	apps, err := h.appRepo.ListByUser(c.Request.Context(), userID, status)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list applications")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list applications")
+		return
+	}
+
+	if wantsCSV(c) {
+		records := make([][]string, len(apps))
+		for i := range apps {
+			records[i] = applicationCSVRecord(&apps[i])
+		}
+		writeCSV(c, "hireiq-applications.csv", applicationCSVHeader, records)
+		return
+	}
+
+	if apps == nil {
+		apps = []model.Application{}
+	}
+
+	respondList(c, apps, nil)
+}
+
+// ListFollowUps returns every follow-up across the pipeline, split into
+// overdue (dated before today, UTC) and upcoming, each sorted by date
+// GET /applications/followups
+func (h *ApplicationHandler) ListFollowUps(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	apps, err := h.appRepo.ListFollowUps(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list follow-ups")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list follow-ups")
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	overdue := []model.Application{}
+	upcoming := []model.Application{}
+	for _, a := range apps {
+		if a.FollowUpDate.Before(today) {
+			overdue = append(overdue, a)
+		} else {
+			upcoming = append(upcoming, a)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"overdue":  overdue,
+		"upcoming": upcoming,
+		"total":    len(apps),
+	})
+}
+
+// GetFunnel returns pipeline counts by status and outcome plus response,
+// interview, and offer rates across the user's applications
+// GET /applications/funnel
+func (h *ApplicationHandler) GetFunnel(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	progress, err := h.appRepo.ListProgress(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load application funnel")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get funnel")
+		return
+	}
+
+	c.JSON(http.StatusOK, service.BuildFunnel(progress))
+}
+
+// maxHistoryBatch caps application IDs per POST /applications/history/batch
+const maxHistoryBatch = 200
+
+// GetHistoryBatch returns the status history of several applications at once
+// for the pipeline board's timeline overlay, keyed by application ID. IDs
+// that aren't the user's applications are omitted.
+// POST /applications/history/batch
+func (h *ApplicationHandler) GetHistoryBatch(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req struct {
+		ApplicationIDs []string `json:"applicationIds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+	if len(req.ApplicationIDs) == 0 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "applicationIds is required")
+		return
+	}
+	if len(req.ApplicationIDs) > maxHistoryBatch {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("At most %d application IDs per request", maxHistoryBatch))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.ApplicationIDs))
+	for _, idStr := range req.ApplicationIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid application ID: "+idStr)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	history, err := h.appRepo.GetHistoryBatch(c.Request.Context(), userID, ids)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get status history batch")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get history")
+		return
+	}
+
+	out := make(map[string][]model.StatusHistory, len(history))
+	for id, rows := range history {
+		out[id.String()] = rows
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": out})
 }
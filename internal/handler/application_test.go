@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
+)
+
+// Dropping a Kanban card onto the column it's already in sends its current
+// status again; that mustn't add a history row, but should still repair a
+// job whose status drifted from its application.
+func TestUpdateStatusNoOp(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	user := createTestUser(t, pool)
+
+	jobRepo := repository.NewJobRepo(pool, repository.NewReadPool(pool, nil))
+	appRepo := repository.NewApplicationRepo(pool)
+
+	job, err := jobRepo.Create(ctx, &model.Job{
+		UserID: user.ID, Source: "manual", Title: "Engineer", Company: "Acme",
+		Tags: []string{}, RequiredSkills: []string{}, PreferredSkills: []string{},
+		Status: model.StatusApplied,
+	})
+	if err != nil {
+		t.Fatalf("creating job: %v", err)
+	}
+	app, _, err := appRepo.Create(ctx, &model.Application{UserID: user.ID, JobID: job.ID, Status: model.StatusApplied})
+	if err != nil {
+		t.Fatalf("creating application: %v", err)
+	}
+	if err := jobRepo.UpdateStatus(ctx, job.ID, user.ID, model.StatusSaved); err != nil {
+		t.Fatalf("drifting job status: %v", err)
+	}
+
+	before, err := appRepo.GetHistory(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("getting history: %v", err)
+	}
+
+	h := NewApplicationHandler(appRepo, jobRepo, service.NewStatusEventBus(), 24*time.Hour)
+	r := gin.New()
+	r.PUT("/jobs/:id/application/status", asUser(user.ID), h.UpdateStatus)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/jobs/"+job.ID.String()+"/application/status",
+		strings.NewReader(`{"status": "applied"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", w.Code, w.Body)
+	}
+
+	after, err := appRepo.GetHistory(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("getting history: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("history rows = %d after no-op change, want %d", len(after), len(before))
+	}
+
+	synced, err := jobRepo.FindByID(ctx, job.ID, user.ID)
+	if err != nil {
+		t.Fatalf("finding job: %v", err)
+	}
+	if synced.Status != model.StatusApplied {
+		t.Errorf("job status = %q, want %q repaired from the application", synced.Status, model.StatusApplied)
+	}
+}
@@ -2,15 +2,20 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/middleware"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/requestid"
 	"github.com/yourusername/hireiq-api/internal/service"
 )
 
@@ -27,7 +32,7 @@ func NewAuthHandler(userRepo *repository.UserRepo) *AuthHandler {
 func (h *AuthHandler) GoogleSignIn(c *gin.Context) {
 	firebaseUID := middleware.GetFirebaseUID(c)
 	if firebaseUID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -38,7 +43,7 @@ func (h *AuthHandler) GoogleSignIn(c *gin.Context) {
 	user, err := h.userRepo.FindByFirebaseUID(c.Request.Context(), firebaseUID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to look up user")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Internal error")
 		return
 	}
 
@@ -52,7 +57,7 @@ func (h *AuthHandler) GoogleSignIn(c *gin.Context) {
 		user, err = h.userRepo.Create(c.Request.Context(), firebaseUID, emailStr, req.Name)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to create user")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create account"})
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create account")
 			return
 		}
 		log.Info().Str("uid", firebaseUID).Msg("New user created")
@@ -63,25 +68,27 @@ func (h *AuthHandler) GoogleSignIn(c *gin.Context) {
 
 // ProfileHandler handles profile CRUD
 type ProfileHandler struct {
-	userRepo    *repository.UserRepo
-	feedService *service.FeedService
+	userRepo       *repository.UserRepo
+	feedService    feedRescorer
+	skillSuggester *service.SkillSuggester
+	stripeService  *service.StripeService
 }
 
-func NewProfileHandler(userRepo *repository.UserRepo, feedService *service.FeedService) *ProfileHandler {
-	return &ProfileHandler{userRepo: userRepo, feedService: feedService}
+func NewProfileHandler(userRepo *repository.UserRepo, feedService *service.FeedService, skillSuggester *service.SkillSuggester, stripeService *service.StripeService) *ProfileHandler {
+	return &ProfileHandler{userRepo: userRepo, feedService: asRescorer(feedService), skillSuggester: skillSuggester, stripeService: stripeService}
 }
 
 // GetProfile handles GET /profile
 func (h *ProfileHandler) GetProfile(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	user, err := h.userRepo.FindByID(c.Request.Context(), userID)
 	if err != nil || user == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "User not found")
 		return
 	}
 
@@ -92,49 +99,115 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
-	var updates model.User
-	if err := c.ShouldBindJSON(&updates); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var req struct {
+		model.User
+		// The updatedAt the client last saw; if the profile changed since,
+		// the update is rejected instead of overwriting the other edit
+		IfUnmodifiedSince *time.Time `json:"ifUnmodifiedSince"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
+	updates := req.User
 
-	updated, err := h.userRepo.Update(c.Request.Context(), userID, &updates)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to update profile")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+	// Empty keeps the stored currency
+	updates.SalaryCurrency = strings.ToUpper(strings.TrimSpace(updates.SalaryCurrency))
+	if updates.SalaryCurrency != "" && !model.ValidCurrency(updates.SalaryCurrency) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Unknown salary currency: "+updates.SalaryCurrency+". Use an ISO 4217 code such as USD, EUR or GBP")
 		return
 	}
 
-	// Re-score existing feed jobs in the background so match scores
-	// reflect the updated profile (target roles, skills, etc.)
-	if h.feedService != nil {
-		go func() {
-			bgCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			rescored, err := h.feedService.RescoreUserFeed(bgCtx, userID)
-			if err != nil {
-				log.Error().Err(err).Str("userId", userID.String()).Msg("Background feed rescore failed")
+	if updates.RemotiveCategories != nil {
+		cats := model.NormalizeStringList(updates.RemotiveCategories)
+		for i, cat := range cats {
+			cats[i] = strings.ToLower(cat)
+			if !service.ValidRemotiveCategory(cats[i]) {
+				respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Unknown Remotive category: "+cat+". Must be one of: "+strings.Join(service.RemotiveCategorySlugs, ", "))
 				return
 			}
-			log.Info().
-				Str("userId", userID.String()).
-				Int("rescored", rescored).
-				Msg("Background feed rescore complete after profile update")
-		}()
+		}
+		updates.RemotiveCategories = cats
+	}
+
+	before, err := h.userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load profile before update")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update profile")
+		return
+	}
+
+	updated, err := h.userRepo.Update(c.Request.Context(), userID, &updates, req.IfUnmodifiedSince)
+	if errors.Is(err, repository.ErrStaleUpdate) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   apierror.New(apierror.Conflict, "Your profile was changed elsewhere since you loaded it. Review the latest version and try again."),
+			"current": before,
+		})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to update profile")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update profile")
+		return
+	}
+
+	// Re-score existing feed jobs so match scores reflect the updated
+	// profile, unless only fields scoring ignores (bio, education...) changed
+	if before == nil || before.ScoringFingerprint() != updated.ScoringFingerprint() {
+		h.rescoreFeedInBackground(c.Request.Context(), userID, "profile update")
 	}
 
 	c.JSON(http.StatusOK, updated)
 }
 
+// DeleteProfile permanently deletes the account and all of its data (jobs,
+// applications, notes, contacts, feed, billing records). Any Stripe
+// subscription is canceled first; if that fails nothing is deleted, so the
+// user is never billed for an account that no longer exists. Requires
+// ?confirm=true.
+// DELETE /profile
+func (h *ProfileHandler) DeleteProfile(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	if c.Query("confirm") != "true" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Account deletion is permanent — repeat the request with ?confirm=true")
+		return
+	}
+
+	if err := h.stripeService.CancelSubscription(c.Request.Context(), userID); err != nil {
+		log.Error().Err(err).Str("userId", userID.String()).Msg("Failed to cancel subscription for account deletion")
+		respondError(c, http.StatusBadGateway, apierror.UpstreamFailed, "Could not cancel your subscription, so your account was not deleted. Please try again.")
+		return
+	}
+
+	deleted, err := h.userRepo.DeleteCascade(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("userId", userID.String()).Msg("Failed to delete account")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to delete account")
+		return
+	}
+	if !deleted {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "User not found")
+		return
+	}
+
+	log.Info().Str("userId", userID.String()).Msg("Account deleted")
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
 // UpdateSkills handles PUT /profile/skills
 func (h *ProfileHandler) UpdateSkills(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -142,17 +215,63 @@ func (h *ProfileHandler) UpdateSkills(c *gin.Context) {
 		Skills []string `json:"skills"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
-	if err := h.userRepo.UpdateSkills(c.Request.Context(), userID, req.Skills); err != nil {
+	skills, err := h.userRepo.UpdateSkills(c.Request.Context(), userID, req.Skills)
+	if err != nil {
 		log.Error().Err(err).Msg("Failed to update skills")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update skills"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update skills")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"skills": req.Skills})
+	// Skills are a major scoring input
+	h.rescoreFeedInBackground(c.Request.Context(), userID, "skills update")
+
+	c.JSON(http.StatusOK, gin.H{"skills": skills})
+}
+
+// feedRescorer is the part of FeedService that handlers use to re-score a
+// user's feed after a profile change
+type feedRescorer interface {
+	RescoreUserFeed(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// asRescorer keeps a nil *FeedService nil as an interface, so
+// rescoreFeedInBackground's nil check still works
+func asRescorer(feedService *service.FeedService) feedRescorer {
+	if feedService == nil {
+		return nil
+	}
+	return feedService
+}
+
+// rescoreFeedInBackground re-scores the user's existing feed jobs in a
+// detached goroutine so the response isn't held up. The goroutine keeps the
+// request's ID for logging.
+func (h *ProfileHandler) rescoreFeedInBackground(ctx context.Context, userID uuid.UUID, reason string) {
+	rescoreFeedInBackground(ctx, h.feedService, userID, reason)
+}
+
+func rescoreFeedInBackground(ctx context.Context, feedService feedRescorer, userID uuid.UUID, reason string) {
+	if feedService == nil {
+		return
+	}
+	go func() {
+		bgCtx, cancel := context.WithTimeout(requestid.Detach(ctx), 30*time.Second)
+		defer cancel()
+		rescored, err := feedService.RescoreUserFeed(bgCtx, userID)
+		if err != nil {
+			requestid.Logger(bgCtx).Error().Err(err).Str("userId", userID.String()).Msg("Background feed rescore failed")
+			return
+		}
+		requestid.Logger(bgCtx).Info().
+			Str("userId", userID.String()).
+			Int("rescored", rescored).
+			Str("reason", reason).
+			Msg("Background feed rescore complete")
+	}()
 }
 
 // GetRoleSuggestions returns the curated list of target role suggestions
@@ -161,6 +280,20 @@ func (h *ProfileHandler) GetRoleSuggestions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"roles": service.RoleSuggestions})
 }
 
+// GetSkillSuggestions returns canonical skill names matching the typed prefix
+// (?q=, ?limit= up to 50), most requested by feed jobs first
+// GET /profile/skills/suggestions
+func (h *ProfileHandler) GetSkillSuggestions(c *gin.Context) {
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 50 {
+		limit = l
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"skills": h.skillSuggester.Suggest(c.Request.Context(), c.Query("q"), limit),
+	})
+}
+
 // getUserID extracts and parses the user UUID from context
 func getUserID(c *gin.Context) (uuid.UUID, error) {
 	idStr := middleware.GetUserID(c)
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// recordingRescorer reports each RescoreUserFeed call on a channel
+type recordingRescorer struct {
+	calls chan uuid.UUID
+}
+
+func (r *recordingRescorer) RescoreUserFeed(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.calls <- userID
+	return 0, nil
+}
+
+func TestUpdateSkillsRescoresFeed(t *testing.T) {
+	pool := testPool(t)
+	user := createTestUser(t, pool)
+
+	rescorer := &recordingRescorer{calls: make(chan uuid.UUID, 1)}
+	h := &ProfileHandler{userRepo: repository.NewUserRepo(pool), feedService: rescorer}
+	r := gin.New()
+	r.PUT("/profile/skills", asUser(user.ID), h.UpdateSkills)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/profile/skills", strings.NewReader(`{"skills": ["golang", "React"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", w.Code, w.Body)
+	}
+
+	select {
+	case got := <-rescorer.calls:
+		if got != user.ID {
+			t.Errorf("rescored user %s, want %s", got, user.ID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("skills update didn't trigger a feed rescore")
+	}
+}
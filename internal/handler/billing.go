@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
 	"github.com/yourusername/hireiq-api/internal/service"
@@ -27,14 +28,14 @@ func NewBillingHandler(stripeService *service.StripeService, subRepo *repository
 func (h *BillingHandler) GetSubscription(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	sub, err := h.subRepo.FindByUserID(c.Request.Context(), userID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get subscription")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get subscription"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get subscription")
 		return
 	}
 
@@ -55,7 +56,7 @@ func (h *BillingHandler) GetSubscription(c *gin.Context) {
 func (h *BillingHandler) CreateCheckout(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -64,26 +65,26 @@ func (h *BillingHandler) CreateCheckout(c *gin.Context) {
 		Interval string `json:"interval" binding:"required"` // "month" or "year"
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "plan and interval are required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "plan and interval are required")
 		return
 	}
 
 	// Validate plan
 	if req.Plan != model.PlanPro && req.Plan != model.PlanProPlus {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid plan. Must be 'pro' or 'pro_plus'"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid plan. Must be 'pro' or 'pro_plus'")
 		return
 	}
 
 	// Validate interval
 	if req.Interval != "month" && req.Interval != "year" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interval. Must be 'month' or 'year'"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid interval. Must be 'month' or 'year'")
 		return
 	}
 
 	url, err := h.stripeService.CreateCheckoutSession(c.Request.Context(), userID, req.Plan, req.Interval)
 	if err != nil {
 		log.Error().Err(err).Str("plan", req.Plan).Msg("Failed to create checkout session")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create checkout session"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create checkout session")
 		return
 	}
 
@@ -95,14 +96,14 @@ func (h *BillingHandler) CreateCheckout(c *gin.Context) {
 func (h *BillingHandler) CreatePortal(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	url, err := h.stripeService.CreatePortalSession(c.Request.Context(), userID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create portal session")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create portal session"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create portal session")
 		return
 	}
 
@@ -115,15 +116,43 @@ func (h *BillingHandler) HandleWebhook(c *gin.Context) {
 	event, err := h.stripeService.VerifyWebhook(c.Request.Body, c.GetHeader("Stripe-Signature"))
 	if err != nil {
 		log.Warn().Err(err).Msg("Invalid webhook signature")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid signature"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid signature")
 		return
 	}
 
 	if err := h.stripeService.HandleWebhookEvent(c.Request.Context(), event); err != nil {
 		log.Error().Err(err).Str("type", string(event.Type)).Msg("Failed to process webhook event")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process event"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to process event")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"received": true})
 }
+
+// TestWebhook handles POST /billing/webhook/test (development only)
+// Runs a raw Stripe event JSON through HandleWebhookEvent without signature
+// verification and returns the result. Only registered when ENV=development.
+func (h *BillingHandler) TestWebhook(c *gin.Context) {
+	event, err := h.stripeService.ParseTestEvent(c.Request.Body)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
+		return
+	}
+
+	if err := h.stripeService.HandleWebhookEvent(c.Request.Context(), event); err != nil {
+		log.Error().Err(err).Str("type", string(event.Type)).Msg("Test webhook event failed")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"processed": false,
+			"type":      event.Type,
+			"id":        event.ID,
+			"error":     apierror.New(apierror.Internal, err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"processed": true,
+		"type":      event.Type,
+		"id":        event.ID,
+	})
+}
@@ -1,22 +1,43 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
+	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/service"
 )
 
 type CompanyHandler struct {
 	yahoo  *service.YahooFinanceClient
 	claude *service.ClaudeClient
+
+	// AI estimates by normalized company name, kept as long as Yahoo data
+	// and capped at maxAIIntelCache entries
+	aiMu    sync.RWMutex
+	aiCache map[string]*service.CompanyIntel
 }
 
+// maxAIIntelCache caps how many companies' AI estimates are kept in memory
+const maxAIIntelCache = 1000
+
 func NewCompanyHandler(yahoo *service.YahooFinanceClient, claude *service.ClaudeClient) *CompanyHandler {
-	return &CompanyHandler{yahoo: yahoo, claude: claude}
+	return &CompanyHandler{
+		yahoo:   yahoo,
+		claude:  claude,
+		aiCache: make(map[string]*service.CompanyIntel),
+	}
 }
 
 // GetIntel handles GET /company/intel?company=Apple&ticker=AAPL
@@ -26,10 +47,13 @@ func NewCompanyHandler(yahoo *service.YahooFinanceClient, claude *service.Claude
 //  2. If only company name is provided, search Yahoo for ticker first
 //  3. If Yahoo Finance fails or company is private, fall back to Claude AI estimation
 //  4. Results are cached in-memory for 6 hours
+//
+// Responses carry Cache-Control for the rest of that window and an ETag; a
+// matching If-None-Match gets 304 Not Modified.
 func (h *CompanyHandler) GetIntel(c *gin.Context) {
 	_, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -37,22 +61,195 @@ func (h *CompanyHandler) GetIntel(c *gin.Context) {
 	ticker := strings.TrimSpace(c.Query("ticker"))
 
 	if company == "" && ticker == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "company or ticker query param is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "company or ticker query param is required")
 		return
 	}
 
 	// Input length limits to prevent abuse of external API calls
 	if len(company) > 256 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Company name too long"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Company name too long")
 		return
 	}
 	if len(ticker) > 10 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Ticker symbol too long"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Ticker symbol too long")
+		return
+	}
+
+	budget := &intelQuota{quota: middleware.GetAIQuota(c)}
+	intel, err := h.resolveIntel(c.Request.Context(), company, ticker, budget)
+	if errors.Is(err, errTickerNotFound) {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Could not fetch company data. The ticker may be invalid.")
+		return
+	}
+	if err != nil {
+		respondAIError(c, err, "Failed to resolve company intel", "Could not retrieve company information. Please try again.")
+		return
+	}
+	// Yahoo Finance and cached estimates don't count against the AI quota
+	budget.settle(c.Request.Context())
+
+	if notModified(c, intel) {
+		return
+	}
+	c.JSON(http.StatusOK, intel)
+}
+
+// maxIntelBatch caps companies per POST /company/intel/batch
+const maxIntelBatch = 10
+
+// intelBatchWorkers bounds concurrent lookups within one batch
+const intelBatchWorkers = 4
+
+// GetIntelBatch resolves several companies at once for the network dashboard.
+// Each goes through the same Yahoo / AI flow as GetIntel; companies that
+// can't be resolved are listed under errors instead of failing the batch,
+// including those turned away because the AI queue was full.
+// Every uncached AI estimate counts against the company intel quota, so once
+// it runs out the remaining companies fail with a quota error.
+// POST /company/intel/batch
+func (h *CompanyHandler) GetIntelBatch(c *gin.Context) {
+	_, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req struct {
+		Companies []string `json:"companies" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "companies is required")
+		return
+	}
+
+	// Trim and drop blanks and duplicates, keeping the caller's spelling
+	var companies []string
+	seen := make(map[string]bool)
+	for _, name := range req.Companies {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if len(name) > 256 {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Company name too long")
+			return
+		}
+		seen[name] = true
+		companies = append(companies, name)
+	}
+	if len(companies) == 0 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "companies is required")
+		return
+	}
+	if len(companies) > maxIntelBatch {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("At most %d companies per batch", maxIntelBatch))
 		return
 	}
 
 	ctx := c.Request.Context()
+	budget := &intelQuota{quota: middleware.GetAIQuota(c)}
+	results := make(map[string]*service.CompanyIntel, len(companies))
+	failures := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, intelBatchWorkers)
+
+	for _, name := range companies {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			intel, err := h.resolveIntel(ctx, name, "", budget)
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, errIntelQuotaExceeded) {
+				failures[name] = "Daily AI limit reached"
+				return
+			}
+			if errors.Is(err, service.ErrAIBusy) {
+				failures[name] = "AI features are busy right now. Please try again in a few seconds."
+				return
+			}
+			if err != nil {
+				failures[name] = "Could not retrieve company information"
+				return
+			}
+			results[name] = intel
+		}(name)
+	}
+	wg.Wait()
+	budget.settle(ctx)
+
+	c.JSON(http.StatusOK, gin.H{"intel": results, "errors": failures})
+}
+
+// errTickerNotFound means only a ticker was given and Yahoo Finance couldn't
+// resolve it, so there's no company name to fall back on
+var errTickerNotFound = errors.New("ticker not found")
+
+// errIntelQuotaExceeded means a batch ran out of AI quota before this company
+var errIntelQuotaExceeded = errors.New("ai quota exceeded")
 
+// intelQuota meters a request's AI estimates. The call RequireAIQuota
+// reserved for the request covers the first; each further one (in a batch)
+// reserves another from the same daily quota. A nil intelQuota allows
+// everything.
+type intelQuota struct {
+	quota *middleware.AIQuota
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (q *intelQuota) reserve(ctx context.Context) bool {
+	if q == nil {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.calls > 0 && !q.quota.Reserve(ctx) {
+		return false
+	}
+	q.calls++
+	return true
+}
+
+// refund gives back a reserved call whose estimate failed
+func (q *intelQuota) refund(ctx context.Context) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.calls--
+	if q.calls > 0 {
+		q.quota.Refund(ctx)
+	}
+}
+
+// settle gives back the call RequireAIQuota reserved when no estimate used
+// it, e.g. every company came from Yahoo Finance or the cache. Call it only
+// on success; RequireAIQuota already refunds failed requests.
+func (q *intelQuota) settle(ctx context.Context) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.calls == 0 {
+		q.quota.Refund(ctx)
+	}
+}
+
+// resolveIntel runs the lookup flow for one company:
+//  1. If ticker is empty, search Yahoo for one by company name
+//  2. With a ticker, fetch from Yahoo Finance (cached 6 hours by the client)
+//  3. Otherwise, or if Yahoo fails, estimate via Claude (cached here as long)
+//
+// budget, when set, must allow each Claude call before it's made.
+func (h *CompanyHandler) resolveIntel(ctx context.Context, company, ticker string, budget *intelQuota) (*service.CompanyIntel, error) {
 	// ── Step 1: Try Yahoo Finance (public companies) ────────
 
 	// If no ticker provided, search for one
@@ -75,8 +272,7 @@ func (h *CompanyHandler) GetIntel(c *gin.Context) {
 			if company != "" && intel.Company == "" {
 				intel.Company = company
 			}
-			c.JSON(http.StatusOK, intel)
-			return
+			return intel, nil
 		}
 	}
 
@@ -84,26 +280,87 @@ func (h *CompanyHandler) GetIntel(c *gin.Context) {
 
 	if company == "" {
 		// We only had a ticker and Yahoo failed — not much we can do
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Could not fetch company data. The ticker may be invalid.",
-		})
-		return
+		return nil, errTickerNotFound
+	}
+
+	key := model.NormalizeCompanyName(company)
+	h.aiMu.RLock()
+	cached, ok := h.aiCache[key]
+	h.aiMu.RUnlock()
+	if ok && time.Since(cached.FetchedAt) < service.CompanyIntelCacheTTL {
+		return cached, nil
+	}
+
+	if !budget.reserve(ctx) {
+		return nil, errIntelQuotaExceeded
 	}
 
 	log.Info().Str("company", company).Msg("Fetching company intel via AI estimation")
 
 	aiIntel, aiErr := h.claude.EstimateCompanyIntel(ctx, company)
 	if aiErr != nil {
+		budget.refund(ctx)
 		log.Error().Str("company", company).Err(aiErr).Msg("AI company intel estimation failed")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Could not retrieve company information. Please try again.",
-		})
-		return
+		return nil, aiErr
 	}
 
 	// Convert AI result to unified CompanyIntel format
 	result := convertAIToCompanyIntel(company, aiIntel)
-	c.JSON(http.StatusOK, result)
+	h.cacheAIIntel(key, result)
+	return result, nil
+}
+
+// cacheAIIntel stores an AI estimate. When the cache is full, expired
+// estimates are dropped first; if that frees nothing an arbitrary one goes.
+func (h *CompanyHandler) cacheAIIntel(key string, intel *service.CompanyIntel) {
+	h.aiMu.Lock()
+	defer h.aiMu.Unlock()
+	if _, ok := h.aiCache[key]; !ok && len(h.aiCache) >= maxAIIntelCache {
+		for k, cached := range h.aiCache {
+			if time.Since(cached.FetchedAt) >= service.CompanyIntelCacheTTL {
+				delete(h.aiCache, k)
+			}
+		}
+		for k := range h.aiCache {
+			if len(h.aiCache) < maxAIIntelCache {
+				break
+			}
+			delete(h.aiCache, k)
+		}
+	}
+	h.aiCache[key] = intel
+}
+
+// notModified sets caching headers for cached intel and writes 304 if the
+// client's copy is current. The ETag changes whenever the server refetches.
+func notModified(c *gin.Context, intel *service.CompanyIntel) bool {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", intel.Ticker, intel.FetchedAt.UnixNano())))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	maxAge := int((service.CompanyIntelCacheTTL - time.Since(intel.FetchedAt)).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	c.Header("ETag", etag)
+
+	if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. Per RFC
+// 9110 the comparison is weak, so a W/ prefix is ignored.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
 }
 
 // convertAIToCompanyIntel maps the AI-estimated data to the same response shape
@@ -113,7 +370,7 @@ func convertAIToCompanyIntel(company string, ai *service.CompanyIntelAI) *servic
 		Company:  ai.Company,
 		IsPublic: ai.IsPublic,
 		Source:   "ai_estimated",
-		FetchedAt: time.Now(),
+		FetchedAt: time.Now().UTC(),
 		Profile: service.CompanyProfile{
 			Industry:          ai.Profile.Industry,
 			Sector:            ai.Profile.Sector,
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
 	"github.com/yourusername/hireiq-api/internal/service"
@@ -28,7 +29,7 @@ func NewCompareHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo
 func (h *CompareHandler) Compare(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -36,12 +37,12 @@ func (h *CompareHandler) Compare(c *gin.Context) {
 		JobIDs []string `json:"jobIds" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "jobIds is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "jobIds is required")
 		return
 	}
 
 	if len(req.JobIDs) < 2 || len(req.JobIDs) > 4 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Between 2 and 4 job IDs are required for comparison"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Between 2 and 4 job IDs are required for comparison")
 		return
 	}
 
@@ -50,18 +51,18 @@ func (h *CompareHandler) Compare(c *gin.Context) {
 	for _, idStr := range req.JobIDs {
 		jobID, err := uuid.Parse(idStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid job ID: %s", idStr)})
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("Invalid job ID: %s", idStr))
 			return
 		}
 
 		job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
 		if err != nil {
 			log.Error().Err(err).Str("jobId", idStr).Msg("Failed to fetch job for comparison")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job"})
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to fetch job")
 			return
 		}
 		if job == nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Job not found: %s", idStr)})
+			respondError(c, http.StatusNotFound, apierror.NotFound, fmt.Sprintf("Job not found: %s", idStr))
 			return
 		}
 		jobs = append(jobs, job)
@@ -87,8 +88,7 @@ func (h *CompareHandler) Compare(c *gin.Context) {
 	// Call Claude
 	result, err := h.claude.CompareJobs(c.Request.Context(), jobDescriptions, profileStr)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to compare jobs")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI comparison failed. Please try again."})
+		respondAIError(c, err, "Failed to compare jobs", "AI comparison failed. Please try again.")
 		return
 	}
 
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// competitionHistoryDays is how many daily snapshots GET /jobs/:id/competition returns
+const competitionHistoryDays = 30
+
+type CompetitionHandler struct {
+	jobRepo      *repository.JobRepo
+	snapshotRepo *repository.CompetitiveSnapshotRepo
+}
+
+func NewCompetitionHandler(jobRepo *repository.JobRepo, snapshotRepo *repository.CompetitiveSnapshotRepo) *CompetitionHandler {
+	return &CompetitionHandler{jobRepo: jobRepo, snapshotRepo: snapshotRepo}
+}
+
+// Get records today's competitive snapshot for a job and returns it with the
+// recent daily history. Jobs not found in anyone's feed (e.g. added by hand)
+// have no competition data: current is null and tracked is false.
+// GET /jobs/:id/competition
+func (h *CompetitionHandler) Get(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for competition")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get competition")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	current, err := h.snapshotRepo.Record(c.Request.Context(), job)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to record competitive snapshot")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get competition")
+		return
+	}
+
+	history, err := h.snapshotRepo.List(c.Request.Context(), job.ID, competitionHistoryDays)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list competitive snapshots")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get competition")
+		return
+	}
+	if history == nil {
+		history = []model.CompetitiveSnapshot{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobId":   job.ID,
+		"tracked": current != nil,
+		"current": current,
+		"history": history,
+	})
+}
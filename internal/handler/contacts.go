@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
 )
@@ -25,7 +26,7 @@ func NewContactHandler(contactRepo *repository.ContactRepo) *ContactHandler {
 func (h *ContactHandler) List(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -33,7 +34,7 @@ func (h *ContactHandler) List(c *gin.Context) {
 	contacts, err := h.contactRepo.List(c.Request.Context(), userID, search)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list contacts")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list contacts"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list contacts")
 		return
 	}
 
@@ -41,20 +42,20 @@ func (h *ContactHandler) List(c *gin.Context) {
 		contacts = []model.Contact{}
 	}
 
-	c.JSON(http.StatusOK, contacts)
+	respondList(c, contacts, nil)
 }
 
 // Create handles POST /contacts
 func (h *ContactHandler) Create(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	var contact model.Contact
 	if err := c.ShouldBindJSON(&contact); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
@@ -63,7 +64,7 @@ func (h *ContactHandler) Create(c *gin.Context) {
 	created, err := h.contactRepo.Create(c.Request.Context(), &contact)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create contact")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create contact"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create contact")
 		return
 	}
 
@@ -74,19 +75,19 @@ func (h *ContactHandler) Create(c *gin.Context) {
 func (h *ContactHandler) Update(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	contactID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid contact ID")
 		return
 	}
 
 	var contact model.Contact
 	if err := c.ShouldBindJSON(&contact); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
@@ -96,7 +97,7 @@ func (h *ContactHandler) Update(c *gin.Context) {
 	updated, err := h.contactRepo.Update(c.Request.Context(), &contact)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to update contact")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contact"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update contact")
 		return
 	}
 
@@ -107,18 +108,18 @@ func (h *ContactHandler) Update(c *gin.Context) {
 func (h *ContactHandler) Delete(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	contactID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid contact ID")
 		return
 	}
 
 	if err := h.contactRepo.Delete(c.Request.Context(), contactID, userID); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Contact not found")
 		return
 	}
 
@@ -130,26 +131,26 @@ func (h *ContactHandler) Delete(c *gin.Context) {
 func (h *ContactHandler) ImportLinkedIn(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "No file uploaded")
 		return
 	}
 	defer file.Close()
 
 	// Validate file extension
 	if !strings.HasSuffix(strings.ToLower(header.Filename), ".csv") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Only CSV files are supported"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Only CSV files are supported")
 		return
 	}
 
 	// Limit to 5MB
 	if header.Size > 5*1024*1024 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File too large. Maximum size is 5MB."})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "File too large. Maximum size is 5MB.")
 		return
 	}
 
@@ -159,7 +160,7 @@ func (h *ContactHandler) ImportLinkedIn(c *gin.Context) {
 	// Read header row
 	headers, err := reader.Read()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV headers"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Failed to read CSV headers")
 		return
 	}
 
@@ -176,11 +177,11 @@ func (h *ContactHandler) ImportLinkedIn(c *gin.Context) {
 
 	// Validate required columns
 	if _, ok := colMap["First Name"]; !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid LinkedIn CSV format. Missing 'First Name' column."})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid LinkedIn CSV format. Missing 'First Name' column.")
 		return
 	}
 	if _, ok := colMap["Last Name"]; !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid LinkedIn CSV format. Missing 'Last Name' column."})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid LinkedIn CSV format. Missing 'Last Name' column.")
 		return
 	}
 
@@ -222,14 +223,14 @@ func (h *ContactHandler) ImportLinkedIn(c *gin.Context) {
 	}
 
 	if len(contacts) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No valid contacts found in CSV"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "No valid contacts found in CSV")
 		return
 	}
 
 	imported, skipped, err := h.contactRepo.BulkCreate(c.Request.Context(), userID, contacts)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to bulk import contacts")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import contacts"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to import contacts")
 		return
 	}
 
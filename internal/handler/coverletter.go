@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
+)
+
+type CoverLetterHandler struct {
+	claude   *service.ClaudeClient
+	jobRepo  *repository.JobRepo
+	userRepo *repository.UserRepo
+}
+
+func NewCoverLetterHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo, userRepo *repository.UserRepo) *CoverLetterHandler {
+	return &CoverLetterHandler{claude: claude, jobRepo: jobRepo, userRepo: userRepo}
+}
+
+// Generate handles POST /ai/cover-letter
+// Writes a cover letter for a saved job from the user's resume text
+func (h *CoverLetterHandler) Generate(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req struct {
+		JobID      string `json:"jobId" binding:"required"`
+		ResumeText string `json:"resumeText" binding:"required"`
+		Tone       string `json:"tone"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "jobId and resumeText are required")
+		return
+	}
+
+	if req.Tone == "" {
+		req.Tone = service.ToneProfessional
+	}
+	if !service.ValidTone(req.Tone) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "tone must be professional, enthusiastic, or concise")
+		return
+	}
+
+	if len(req.ResumeText) < 50 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Resume text is too short")
+		return
+	}
+	// Cap at 30K chars
+	if len(req.ResumeText) > 30000 {
+		req.ResumeText = req.ResumeText[:30000]
+	}
+
+	jobID, err := uuid.Parse(req.JobID)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch job for cover letter")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to fetch job")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	jobContext := formatJobForComparison("Target Job", job)
+
+	// Candidate name for the sign-off
+	if user, err := h.userRepo.FindByID(c.Request.Context(), userID); err == nil && user != nil && user.Name != "" {
+		jobContext += "\n\nCandidate name: " + user.Name
+	}
+
+	log.Info().Str("jobId", jobID.String()).Str("tone", req.Tone).Msg("Generating cover letter")
+
+	result, err := h.claude.GenerateCoverLetter(c.Request.Context(), req.ResumeText, jobContext, req.Tone)
+	if err != nil {
+		respondAIError(c, err, "Failed to generate cover letter", "AI generation failed. Please try again.")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// wantsCSV reports whether the client asked for CSV (Accept: text/csv)
+// instead of the JSON default
+func wantsCSV(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), "text/csv")
+}
+
+// writeCSV streams records as a CSV attachment named filename, header first
+func writeCSV(c *gin.Context, filename string, header []string, records [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write(header)
+	for _, rec := range records {
+		for i, v := range rec {
+			rec[i] = csvSafe(v)
+		}
+		w.Write(rec)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Warn().Err(err).Str("file", filename).Msg("Failed to write CSV response")
+	}
+}
+
+// csvSafe keeps spreadsheet apps from evaluating user-entered text that
+// looks like a formula (e.g. a job title of "=HYPERLINK(...)")
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+var jobCSVHeader = []string{
+	"id", "title", "company", "location", "salary_range", "job_type", "status",
+	"applied_externally", "match_score", "bookmarked", "tags", "required_skills",
+	"apply_url", "hiring_email", "source", "created_at", "updated_at", "archived_at",
+}
+
+func jobCSVRecord(j *model.Job) []string {
+	return []string{
+		j.ID.String(), j.Title, j.Company, j.Location, j.SalaryRange, j.JobType, j.Status,
+		strconv.FormatBool(j.AppliedExternally), strconv.Itoa(j.MatchScore), strconv.FormatBool(j.Bookmarked),
+		strings.Join(j.Tags, "; "), strings.Join(j.RequiredSkills, "; "),
+		j.ApplyURL, j.HiringEmail, j.Source, csvTime(&j.CreatedAt), csvTime(&j.UpdatedAt), csvTime(j.ArchivedAt),
+	}
+}
+
+var applicationCSVHeader = []string{
+	"id", "job_id", "title", "company", "location", "salary_range", "status", "outcome",
+	"applied_at", "next_step", "follow_up_date", "follow_up_type", "created_at", "updated_at",
+}
+
+func applicationCSVRecord(a *model.Application) []string {
+	var job model.Job
+	if a.Job != nil {
+		job = *a.Job
+	}
+	return []string{
+		a.ID.String(), a.JobID.String(), job.Title, job.Company, job.Location, job.SalaryRange,
+		a.Status, a.Outcome, csvTime(a.AppliedAt), a.NextStep, csvTime(a.FollowUpDate),
+		a.FollowUpType, csvTime(&a.CreatedAt), csvTime(&a.UpdatedAt),
+	}
+}
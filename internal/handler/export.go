@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+type ExportHandler struct {
+	userRepo     *repository.UserRepo
+	jobRepo      *repository.JobRepo
+	appRepo      *repository.ApplicationRepo
+	noteRepo     *repository.NoteRepo
+	contactRepo  *repository.ContactRepo
+	questionRepo *repository.InterviewQuestionRepo
+}
+
+func NewExportHandler(userRepo *repository.UserRepo, jobRepo *repository.JobRepo, appRepo *repository.ApplicationRepo, noteRepo *repository.NoteRepo, contactRepo *repository.ContactRepo, questionRepo *repository.InterviewQuestionRepo) *ExportHandler {
+	return &ExportHandler{userRepo: userRepo, jobRepo: jobRepo, appRepo: appRepo, noteRepo: noteRepo, contactRepo: contactRepo, questionRepo: questionRepo}
+}
+
+// ExportJob returns a job with its application, status history, notes, and
+// linked contacts as one JSON document for the user to keep. Archived jobs
+// can be exported too.
+// GET /jobs/:id/export
+func (h *ExportHandler) ExportJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	job, err := h.jobRepo.FindByID(ctx, jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export job")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	export := model.JobExport{
+		ExportedAt: time.Now().UTC(),
+		Job:        job,
+		History:    []model.StatusHistory{},
+	}
+
+	app, err := h.appRepo.FindByJobID(ctx, userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find application for export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export job")
+		return
+	}
+	if app != nil {
+		export.Application = app
+		history, err := h.appRepo.GetHistory(ctx, app.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get history for export")
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export job")
+			return
+		}
+		if history != nil {
+			export.History = history
+		}
+	}
+
+	export.Notes, err = h.noteRepo.ListByJob(ctx, userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list notes for export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export job")
+		return
+	}
+	if export.Notes == nil {
+		export.Notes = []model.Note{}
+	}
+
+	export.Contacts, err = h.contactRepo.ListByJobID(ctx, userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list contacts for export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export job")
+		return
+	}
+	if export.Contacts == nil {
+		export.Contacts = []model.Contact{}
+	}
+
+	if c.Query("download") == "true" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="hireiq-job-%s.json"`, jobID))
+	}
+	c.JSON(http.StatusOK, export)
+}
+
+// ExportAccount returns everything stored for the user — profile, jobs
+// (including archived), applications with their status history, notes,
+// contacts, and recorded interview questions — as one JSON file download
+// GET /profile/export
+func (h *ExportHandler) ExportAccount(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load profile for account export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+		return
+	}
+	if user == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "User not found")
+		return
+	}
+
+	export := model.AccountExport{
+		ExportedAt: time.Now().UTC(),
+		Profile:    user,
+		Jobs:       []model.Job{},
+		History:    []model.StatusHistory{},
+	}
+
+	for _, archived := range []bool{false, true} {
+		jobs, err := h.jobRepo.List(ctx, userID, repository.JobFilter{Archived: archived, Sort: repository.SortNewest})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list jobs for account export")
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+			return
+		}
+		export.Jobs = append(export.Jobs, jobs...)
+	}
+
+	export.Applications, err = h.appRepo.ListByUser(ctx, userID, "")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list applications for account export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+		return
+	}
+	if export.Applications == nil {
+		export.Applications = []model.Application{}
+	}
+
+	ids := make([]uuid.UUID, len(export.Applications))
+	for i := range export.Applications {
+		// The job summary joined in for list views duplicates export.Jobs
+		export.Applications[i].Job = nil
+		ids[i] = export.Applications[i].ID
+	}
+	if len(ids) > 0 {
+		history, err := h.appRepo.GetHistoryBatch(ctx, userID, ids)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get history for account export")
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+			return
+		}
+		for _, id := range ids {
+			export.History = append(export.History, history[id]...)
+		}
+	}
+
+	export.Notes, err = h.noteRepo.ListByUser(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list notes for account export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+		return
+	}
+	if export.Notes == nil {
+		export.Notes = []model.Note{}
+	}
+
+	export.Contacts, err = h.contactRepo.List(ctx, userID, "")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list contacts for account export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+		return
+	}
+	if export.Contacts == nil {
+		export.Contacts = []model.Contact{}
+	}
+
+	export.InterviewQuestions, err = h.questionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list interview questions for account export")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to export account")
+		return
+	}
+	if export.InterviewQuestions == nil {
+		export.InterviewQuestions = []model.InterviewQuestion{}
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="hireiq-export.json"`)
+	c.JSON(http.StatusOK, export)
+}
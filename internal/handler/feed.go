@@ -1,8 +1,9 @@
 package handler
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,9 +12,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/requestid"
 	"github.com/yourusername/hireiq-api/internal/service"
+	"github.com/yourusername/hireiq-api/internal/skills"
 )
 
 type FeedHandler struct {
@@ -21,6 +25,10 @@ type FeedHandler struct {
 	feedRepo    *repository.FeedRepo
 	claude      *service.ClaudeClient
 	userRepo    *repository.UserRepo
+	jobRepo     *repository.JobRepo
+	brand       *service.BrandService
+	events      *service.StatusEventBus
+	minScore    int // default relevance floor for GET /feed
 }
 
 func NewFeedHandler(
@@ -28,33 +36,95 @@ func NewFeedHandler(
 	feedRepo *repository.FeedRepo,
 	claude *service.ClaudeClient,
 	userRepo *repository.UserRepo,
+	jobRepo *repository.JobRepo,
+	brand *service.BrandService,
+	events *service.StatusEventBus,
+	minScore int,
 ) *FeedHandler {
 	return &FeedHandler{
 		feedService: feedService,
 		feedRepo:    feedRepo,
 		claude:      claude,
 		userRepo:    userRepo,
+		jobRepo:     jobRepo,
+		brand:       brand,
+		events:      events,
+		minScore:    minScore,
 	}
 }
 
-// GetFeed returns the user's job feed, sorted by match score
+// GetFeed returns a page of the user's job feed, sorted by match score, with
+// the total number of undismissed jobs for "showing 100 of 340".
+// ?limit (max 200) and ?offset page through the feed. ?postedWithin=7d limits
+// to recent postings; undated jobs are excluded unless ?includeUndated=true.
+// Jobs scoring below the configured relevance floor are hidden unless
+// ?minScore overrides it (?minScore=0 shows everything). ?sort is match
+// (default), newest, salary, or company.
 // GET /feed
 func (h *FeedHandler) GetFeed(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
 		return
 	}
 
-	limit := 100
+	filter := repository.FeedFilter{
+		Limit:          100,
+		IncludeUndated: c.Query("includeUndated") == "true",
+		MinScore:       h.minScore,
+		Sort:           c.Query("sort"),
+	}
+	if filter.Sort != "" && !repository.ValidSort(filter.Sort) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid sort — use match, newest, salary, or company")
+		return
+	}
 	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 200 {
-		limit = l
+		filter.Limit = l
+	}
+	if c.Query("offset") != "" {
+		o, err := strconv.Atoi(c.Query("offset"))
+		if err != nil || o < 0 {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid offset")
+			return
+		}
+		filter.Offset = o
+	}
+	if c.Query("minScore") != "" {
+		m, err := strconv.Atoi(c.Query("minScore"))
+		if err != nil || m < 0 || m > 100 {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid minScore — use 0 to 100")
+			return
+		}
+		filter.MinScore = m
+	}
+	if pw := c.Query("postedWithin"); pw != "" {
+		d, err := parsePostedWithin(pw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid postedWithin — use a number of days or hours like 7d, 30d, or 24h")
+			return
+		}
+		filter.PostedWithin = d
+	}
+	if a := c.Query("alert"); a != "" {
+		alertID, err := uuid.Parse(a)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid alert ID")
+			return
+		}
+		filter.AlertID = &alertID
 	}
 
-	jobs, err := h.feedRepo.GetUserFeed(c.Request.Context(), userID, limit)
+	jobs, err := h.feedRepo.GetUserFeed(c.Request.Context(), userID, filter)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get user feed")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get feed"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get feed")
+		return
+	}
+
+	total, err := h.feedRepo.CountUserFeed(c.Request.Context(), userID, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count user feed")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get feed")
 		return
 	}
 
@@ -62,98 +132,637 @@ func (h *FeedHandler) GetFeed(c *gin.Context) {
 		jobs = []model.FeedJob{}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"jobs":  jobs,
-		"count": len(jobs),
+	respondListLegacy(c, jobs, gin.H{
+		"total":    total,
+		"offset":   filter.Offset,
+		"minScore": filter.MinScore,
+	}, gin.H{
+		"jobs":     jobs,
+		"count":    len(jobs),
+		"total":    total,
+		"offset":   filter.Offset,
+		"minScore": filter.MinScore,
 	})
 }
 
+// parsePostedWithin parses a recency window like "7d", "2w", or "24h"
+func parsePostedWithin(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 || n > 365 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	switch s[len(s)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q", s)
+}
+
 // RefreshFeed triggers a feed refresh for the current user.
-// The refresh runs in the background so the client gets an immediate response.
+// The refresh runs in the background so the client gets an immediate response;
+// poll GET /feed/refresh/status for progress.
 // POST /feed/refresh
 func (h *FeedHandler) RefreshFeed(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
 		return
 	}
 
 	force := c.Query("force") == "true"
 
-	// Run refresh in the background with a detached context so it isn't
-	// cancelled when the HTTP response is sent back to the client.
-	go func() {
-		bgCtx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
-		defer cancel()
-
-		fetched, newJobs, err := h.feedService.RefreshUserFeed(bgCtx, userID, force)
-		if err != nil {
-			log.Error().Err(err).Str("userId", userID.String()).Msg("Background feed refresh failed")
-			return
-		}
-		log.Info().
-			Str("userId", userID.String()).
-			Int("fetched", fetched).
-			Int("new", newJobs).
-			Msg("Background feed refresh complete")
-	}()
+	if !h.feedService.StartBackgroundRefresh(c.Request.Context(), userID, force) {
+		c.JSON(http.StatusOK, gin.H{
+			"fetched": 0,
+			"new":     0,
+			"status":  service.RefreshStateRunning,
+			"message": "Feed refresh already in progress",
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"fetched": 0,
 		"new":     0,
+		"status":  service.RefreshStateRunning,
 		"message": "Feed refresh started",
 	})
 }
 
+// GetRefreshStatus reports whether the user's feed refresh is running or done
+// GET /feed/refresh/status
+func (h *FeedHandler) GetRefreshStatus(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	status, err := h.feedService.GetRefreshStatus(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get feed refresh status")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get refresh status")
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// PreviewQueries returns the search queries a refresh would run for the
+// current profile, without running them
+// GET /feed/queries
+func (h *FeedHandler) PreviewQueries(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	preview, err := h.feedService.PreviewQueries(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to preview feed queries")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to preview feed queries")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// SkillDemand lists the skills the user's feed jobs ask for most often,
+// flagging the ones already on their profile
+// GET /feed/skill-demand
+func (h *FeedHandler) SkillDemand(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	ctx := c.Request.Context()
+	demand, err := h.feedRepo.SkillDemand(ctx, userID, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get skill demand")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get skill demand")
+		return
+	}
+	if demand == nil {
+		demand = []model.SkillDemand{}
+	}
+
+	user, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load profile for skill demand")
+	}
+	if user != nil {
+		have := make(map[string]bool, len(user.Skills))
+		for _, sk := range user.Skills {
+			have[skills.CanonicalKey(sk)] = true
+		}
+		for i := range demand {
+			demand[i].Have = have[skills.CanonicalKey(demand[i].Skill)]
+		}
+	}
+
+	respondListLegacy(c, demand, nil, gin.H{"skills": demand})
+}
+
+// SalaryInsights returns salary percentiles across the user's feed, per
+// currency, alongside their own target range for benchmarking
+// GET /feed/salary-insights
+func (h *FeedHandler) SalaryInsights(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		log.Error().Err(err).Msg("Failed to load profile for salary insights")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get salary insights")
+		return
+	}
+
+	dists, err := h.feedRepo.SalaryDistribution(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get salary distribution")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get salary insights")
+		return
+	}
+
+	// The user's own currency leads; other currencies follow by job count
+	sorted := make([]model.SalaryDistribution, 0, len(dists))
+	for _, d := range dists {
+		if d.Currency == user.SalaryCurrency {
+			sorted = append(sorted, d)
+		}
+	}
+	for _, d := range dists {
+		if d.Currency != user.SalaryCurrency {
+			sorted = append(sorted, d)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"target": gin.H{
+			"min":      user.SalaryMin,
+			"max":      user.SalaryMax,
+			"currency": user.SalaryCurrency,
+		},
+		"distributions": sorted,
+	})
+}
+
 // DismissFeedJob hides a feed job from the user's feed
 // POST /feed/:id/dismiss
 func (h *FeedHandler) DismissFeedJob(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
 		return
 	}
 
 	feedJobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
 	if err := h.feedRepo.DismissFeedJob(c.Request.Context(), userID, feedJobID); err != nil {
 		log.Error().Err(err).Msg("Failed to dismiss feed job")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to dismiss"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to dismiss")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Job dismissed"})
 }
 
+// UndismissFeedJob restores an accidentally dismissed feed job
+// POST /feed/:id/undismiss
+func (h *FeedHandler) UndismissFeedJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	feedJobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	err = h.feedRepo.UndismissFeedJob(c.Request.Context(), userID, feedJobID)
+	if errors.Is(err, repository.ErrFeedJobNotFound) {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Feed job not found")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to undismiss feed job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to restore job")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job restored to your feed"})
+}
+
+// maxSnooze is the furthest ahead a feed job can be snoozed
+const maxSnooze = 365 * 24 * time.Hour
+
+// SnoozeFeedJob hides a feed job until a later date, after which it comes
+// back on its own. Softer than dismiss for jobs worth another look.
+// POST /feed/:id/snooze
+func (h *FeedHandler) SnoozeFeedJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	feedJobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	var req struct {
+		Until *time.Time `json:"until"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Until == nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "until (an RFC3339 timestamp) is required")
+		return
+	}
+	if !req.Until.After(time.Now()) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "until must be in the future")
+		return
+	}
+	if req.Until.After(time.Now().Add(maxSnooze)) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Can snooze for at most a year")
+		return
+	}
+
+	err = h.feedRepo.SnoozeFeedJob(c.Request.Context(), userID, feedJobID, *req.Until)
+	if errors.Is(err, repository.ErrFeedJobNotFound) {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Feed job not found")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to snooze feed job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to snooze job")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"snoozedUntil": req.Until.UTC()})
+}
+
+// maxBulkDismiss caps how many feed jobs can be dismissed in one request
+const maxBulkDismiss = 200
+
+// BulkDismissFeedJobs hides several feed jobs at once, e.g. to clear out
+// irrelevant results after a refresh
+// POST /feed/dismiss/bulk
+func (h *FeedHandler) BulkDismissFeedJobs(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		FeedJobIDs []string `json:"feedJobIds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	if len(req.FeedJobIDs) == 0 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "feedJobIds is required")
+		return
+	}
+	if len(req.FeedJobIDs) > maxBulkDismiss {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("Can dismiss at most %d jobs at once", maxBulkDismiss))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.FeedJobIDs))
+	for _, idStr := range req.FeedJobIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID: "+idStr)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	dismissed, err := h.feedRepo.BulkDismiss(c.Request.Context(), userID, ids)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to bulk dismiss feed jobs")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to dismiss jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dismissed": dismissed})
+}
+
 // SaveFeedJob copies a feed job to the user's CRM
 // POST /feed/:id/save
 func (h *FeedHandler) SaveFeedJob(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
 		return
 	}
 
 	feedJobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
-	job, err := h.feedRepo.SaveFeedJobToCRM(c.Request.Context(), userID, feedJobID)
+	job, alreadySaved, err := h.feedRepo.SaveFeedJobToCRM(c.Request.Context(), userID, feedJobID)
+	if errors.Is(err, repository.ErrFeedJobNotFound) {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Feed job not found")
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to save feed job to CRM")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save job"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to save job")
+		return
+	}
+
+	if alreadySaved {
+		c.JSON(http.StatusOK, gin.H{
+			"message":      "Job is already in your tracker",
+			"job":          job,
+			"alreadySaved": true,
+		})
+		return
+	}
+
+	enrichSavedJob(c.Request.Context(), h.brand, h.jobRepo, job)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Job saved to your tracker",
+		"job":          job,
+		"alreadySaved": false,
+	})
+}
+
+// ApplyFeedJob saves a feed job to the tracker and marks it applied in one
+// step: the job gets an "applied" application with its initial history row,
+// all in one transaction. Body is optional: {"appliedAt": RFC3339}, default
+// now. Returns 201 with the job and application, or 200 with status
+// already_applied if the job was already tracked as an application.
+// POST /feed/:id/apply
+func (h *FeedHandler) ApplyFeedJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	feedJobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	var req struct {
+		AppliedAt *string `json:"appliedAt"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
+	appliedAt, err := parseAppliedAt(req.AppliedAt)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "appliedAt must be an RFC3339 timestamp")
+		return
+	}
+
+	results, err := h.feedRepo.ApplyFeedJobs(c.Request.Context(), userID, []uuid.UUID{feedJobID}, appliedAt)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to mark feed job applied")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to mark job applied")
+		return
+	}
+
+	result := results[0]
+	switch result.Status {
+	case model.FeedApplyNotFound:
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Feed job not found")
+	case model.FeedApplyAlreadyApplied:
+		c.JSON(http.StatusOK, result)
+	default:
+		h.events.Publish(c.Request.Context(), model.StatusChangeEvent{
+			UserID:        userID,
+			ApplicationID: result.Application.ID,
+			JobID:         result.Job.ID,
+			ToStatus:      result.Application.Status,
+			ChangedAt:     result.Application.CreatedAt,
+		})
+		enrichSavedJob(c.Request.Context(), h.brand, h.jobRepo, result.Job)
+		c.JSON(http.StatusCreated, result)
+	}
+}
+
+// parseAppliedAt parses an optional RFC3339 appliedAt, defaulting to now
+func parseAppliedAt(s *string) (time.Time, error) {
+	if s == nil {
+		return time.Now().UTC(), nil
+	}
+	return time.Parse(time.RFC3339, *s)
+}
+
+// maxBulkSave caps how many feed jobs can be saved in one request
+const maxBulkSave = 50
+
+// BulkSaveFeedJobs saves several feed jobs to the CRM in one transaction
+// POST /feed/save/bulk
+func (h *FeedHandler) BulkSaveFeedJobs(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		FeedJobIDs []string `json:"feedJobIds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	if len(req.FeedJobIDs) == 0 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "feedJobIds is required")
+		return
+	}
+	if len(req.FeedJobIDs) > maxBulkSave {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("Can save at most %d jobs at once", maxBulkSave))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.FeedJobIDs))
+	for _, idStr := range req.FeedJobIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID: "+idStr)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	results, err := h.feedRepo.SaveFeedJobsToCRM(c.Request.Context(), userID, ids)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to bulk save feed jobs to CRM")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to save jobs")
+		return
+	}
+
+	jobs := []*model.Job{}
+	saved, alreadySaved := 0, 0
+	for _, r := range results {
+		switch r.Status {
+		case model.FeedSaveSaved:
+			saved++
+			jobs = append(jobs, r.Job)
+		case model.FeedSaveAlreadySaved:
+			alreadySaved++
+		}
+	}
+
+	// Logo lookups for a whole batch would hold up the response; fill them in
+	// afterwards so they show up on the next load
+	if h.brand.Enabled() && len(jobs) > 0 {
+		pending := make([]model.Job, len(jobs))
+		for i, j := range jobs {
+			pending[i] = *j
+		}
+		bgCtx := requestid.Detach(c.Request.Context())
+		go func() {
+			for i := range pending {
+				enrichSavedJob(bgCtx, h.brand, h.jobRepo, &pending[i])
+			}
+		}()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"saved":        saved,
+		"alreadySaved": alreadySaved,
+		"jobs":         jobs,
+		"results":      results,
+	})
+}
+
+// BulkApplyFeedJobs marks several feed jobs applied in one transaction: each
+// is saved to the tracker (or the existing saved job reused) with an
+// "applied" application. Jobs already tracked as an application are left
+// unchanged and reported as already_applied.
+// POST /feed/apply/bulk
+func (h *FeedHandler) BulkApplyFeedJobs(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		FeedJobIDs []string `json:"feedJobIds"`
+		AppliedAt  *string  `json:"appliedAt"` // RFC3339; defaults to now
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	if len(req.FeedJobIDs) == 0 {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "feedJobIds is required")
+		return
+	}
+	if len(req.FeedJobIDs) > maxBulkSave {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("Can mark at most %d jobs applied at once", maxBulkSave))
+		return
+	}
+
+	appliedAt, err := parseAppliedAt(req.AppliedAt)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "appliedAt must be an RFC3339 timestamp")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.FeedJobIDs))
+	for _, idStr := range req.FeedJobIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID: "+idStr)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	results, err := h.feedRepo.ApplyFeedJobs(c.Request.Context(), userID, ids, appliedAt)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to bulk mark feed jobs applied")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to mark jobs applied")
+		return
+	}
+
+	applied, alreadyApplied, notFound := 0, 0, 0
+	var pending []model.Job
+	for _, r := range results {
+		switch r.Status {
+		case model.FeedApplyApplied:
+			applied++
+			if r.Job.CompanyLogo == "" {
+				pending = append(pending, *r.Job)
+			}
+			h.events.Publish(c.Request.Context(), model.StatusChangeEvent{
+				UserID:        userID,
+				ApplicationID: r.Application.ID,
+				JobID:         r.Job.ID,
+				ToStatus:      r.Application.Status,
+				ChangedAt:     r.Application.CreatedAt,
+			})
+		case model.FeedApplyAlreadyApplied:
+			alreadyApplied++
+		case model.FeedApplyNotFound:
+			notFound++
+		}
+	}
+
+	// As with bulk save, logos are looked up after responding
+	if h.brand.Enabled() && len(pending) > 0 {
+		bgCtx := requestid.Detach(c.Request.Context())
+		go func() {
+			for i := range pending {
+				enrichSavedJob(bgCtx, h.brand, h.jobRepo, &pending[i])
+			}
+		}()
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Job saved to your tracker",
-		"job":     job,
+		"applied":        applied,
+		"alreadyApplied": alreadyApplied,
+		"notFound":       notFound,
+		"results":        results,
 	})
 }
 
@@ -162,7 +771,7 @@ func (h *FeedHandler) SaveFeedJob(c *gin.Context) {
 func (h *FeedHandler) CompareFeedJobs(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Unauthorized")
 		return
 	}
 
@@ -170,12 +779,12 @@ func (h *FeedHandler) CompareFeedJobs(c *gin.Context) {
 		FeedJobIDs []string `json:"feedJobIds" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "feedJobIds is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "feedJobIds is required")
 		return
 	}
 
 	if len(req.FeedJobIDs) < 2 || len(req.FeedJobIDs) > 4 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Between 2 and 4 feed job IDs are required for comparison"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Between 2 and 4 feed job IDs are required for comparison")
 		return
 	}
 
@@ -184,7 +793,7 @@ func (h *FeedHandler) CompareFeedJobs(c *gin.Context) {
 	for _, idStr := range req.FeedJobIDs {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid feed job ID: %s", idStr)})
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("Invalid feed job ID: %s", idStr))
 			return
 		}
 		ids = append(ids, id)
@@ -194,11 +803,11 @@ func (h *FeedHandler) CompareFeedJobs(c *gin.Context) {
 	feedJobs, err := h.feedRepo.GetFeedJobsByIDs(c.Request.Context(), userID, ids)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to fetch feed jobs for comparison")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to fetch jobs")
 		return
 	}
 	if len(feedJobs) != len(req.FeedJobIDs) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "One or more feed jobs not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "One or more feed jobs not found")
 		return
 	}
 
@@ -211,7 +820,7 @@ func (h *FeedHandler) CompareFeedJobs(c *gin.Context) {
 	for _, idStr := range req.FeedJobIDs {
 		fj, ok := jobMap[idStr]
 		if !ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Feed job not found: %s", idStr)})
+			respondError(c, http.StatusNotFound, apierror.NotFound, fmt.Sprintf("Feed job not found: %s", idStr))
 			return
 		}
 		ordered = append(ordered, fj)
@@ -235,8 +844,7 @@ func (h *FeedHandler) CompareFeedJobs(c *gin.Context) {
 	// Call Claude
 	result, err := h.claude.CompareJobs(c.Request.Context(), jobDescriptions, profileStr)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to compare feed jobs")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI comparison failed. Please try again."})
+		respondAIError(c, err, "Failed to compare feed jobs", "AI comparison failed. Please try again.")
 		return
 	}
 
@@ -257,7 +865,8 @@ func formatFeedJobForComparison(label string, fj *model.FeedJob) string {
 
 	salaryStr := fj.SalaryText
 	if salaryStr == "" && fj.SalaryMin > 0 {
-		salaryStr = fmt.Sprintf("$%dk - $%dk", fj.SalaryMin/1000, fj.SalaryMax/1000)
+		cur := model.CurrencyPrefix(fj.SalaryCurrency)
+		salaryStr = fmt.Sprintf("%s%dk - %s%dk", cur, fj.SalaryMin/1000, cur, fj.SalaryMax/1000)
 	}
 	if salaryStr != "" {
 		parts = append(parts, fmt.Sprintf("Salary: %s", salaryStr))
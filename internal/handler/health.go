@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/config"
+)
+
+type HealthHandler struct {
+	pool     *pgxpool.Pool
+	readPool *pgxpool.Pool
+	cfg      *config.Config
+}
+
+func NewHealthHandler(pool, readPool *pgxpool.Pool, cfg *config.Config) *HealthHandler {
+	return &HealthHandler{pool: pool, readPool: readPool, cfg: cfg}
+}
+
+// Check handles GET /health and GET /readyz
+// Pings the database with a short timeout and reports per-dependency status.
+// Returns 503 if the database is unreachable so load balancers stop routing here.
+// A down read replica only reports "degraded": reads fall back to the primary,
+// so the instance can still serve traffic.
+// The endpoint is unauthenticated, so ping errors are logged, never returned.
+func (h *HealthHandler) Check(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	defer cancel()
+
+	checks := gin.H{}
+	status := http.StatusOK
+	degraded := false
+
+	start := time.Now()
+	if err := h.pool.Ping(ctx); err != nil {
+		log.Error().Err(err).Msg("Health check: database ping failed")
+		checks["database"] = gin.H{"status": "down", "error": "Database unreachable"}
+		status = http.StatusServiceUnavailable
+	} else {
+		checks["database"] = gin.H{"status": "up", "latencyMs": time.Since(start).Milliseconds()}
+	}
+
+	if h.readPool != h.pool {
+		start = time.Now()
+		if err := h.readPool.Ping(ctx); err != nil {
+			log.Error().Err(err).Msg("Health check: read replica ping failed")
+			checks["databaseReplica"] = gin.H{"status": "down", "error": "Read replica unreachable"}
+			degraded = true
+		} else {
+			checks["databaseReplica"] = gin.H{"status": "up", "latencyMs": time.Since(start).Milliseconds()}
+		}
+	}
+
+	// Config-only checks — these don't call out, they just flag missing keys
+	checks["claude"] = configuredStatus(h.cfg.ClaudeAPIKey)
+	checks["stripe"] = configuredStatus(h.cfg.StripeSecretKey)
+
+	resp := gin.H{
+		"status":  "ok",
+		"service": "hireiq-api",
+		"time":    time.Now().UTC(),
+		"checks":  checks,
+	}
+	if status != http.StatusOK {
+		resp["status"] = "unavailable"
+		resp["error"] = apierror.New(apierror.Unavailable, "A required dependency is down")
+	} else if degraded {
+		resp["status"] = "degraded"
+	}
+	c.JSON(status, resp)
+}
+
+func configuredStatus(key string) gin.H {
+	if key == "" {
+		return gin.H{"status": "not_configured"}
+	}
+	return gin.H{"status": "configured"}
+}
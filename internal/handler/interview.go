@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
+)
+
+type InterviewHandler struct {
+	claude   *service.ClaudeClient
+	jobRepo  *repository.JobRepo
+	userRepo *repository.UserRepo
+}
+
+func NewInterviewHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo, userRepo *repository.UserRepo) *InterviewHandler {
+	return &InterviewHandler{claude: claude, jobRepo: jobRepo, userRepo: userRepo}
+}
+
+// Prep handles POST /ai/interview-prep
+// Generates likely technical, behavioral, and company questions for a saved job
+func (h *InterviewHandler) Prep(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req struct {
+		JobID string `json:"jobId" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "jobId is required")
+		return
+	}
+
+	jobID, err := uuid.Parse(req.JobID)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch job for interview prep")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to fetch job")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	// Profile lets talking points reference the candidate's own experience
+	var profileStr string
+	user, err := h.userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch user profile for interview prep")
+	} else if user != nil {
+		profileStr = formatUserProfile(user)
+	}
+
+	log.Info().Str("jobId", jobID.String()).Msg("Generating interview prep")
+
+	result, err := h.claude.GenerateInterviewQuestions(c.Request.Context(), formatJobForComparison("Target Job", job), profileStr)
+	if err != nil {
+		respondAIError(c, err, "Failed to generate interview questions", "AI generation failed. Please try again.")
+		return
+	}
+
+	if result.Technical == nil {
+		result.Technical = []service.InterviewQuestion{}
+	}
+	if result.Behavioral == nil {
+		result.Behavioral = []service.InterviewQuestion{}
+	}
+	if result.Company == nil {
+		result.Company = []service.InterviewQuestion{}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
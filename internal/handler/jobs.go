@@ -1,29 +1,42 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
 )
 
 type JobHandler struct {
-	jobRepo *repository.JobRepo
-	appRepo *repository.ApplicationRepo
+	jobRepo     *repository.JobRepo
+	appRepo     *repository.ApplicationRepo
+	contactRepo *repository.ContactRepo
+	brand       *service.BrandService
+	events      *service.StatusEventBus
 }
 
-func NewJobHandler(jobRepo *repository.JobRepo, appRepo *repository.ApplicationRepo) *JobHandler {
-	return &JobHandler{jobRepo: jobRepo, appRepo: appRepo}
+func NewJobHandler(jobRepo *repository.JobRepo, appRepo *repository.ApplicationRepo, contactRepo *repository.ContactRepo, brand *service.BrandService, events *service.StatusEventBus) *JobHandler {
+	return &JobHandler{jobRepo: jobRepo, appRepo: appRepo, contactRepo: contactRepo, brand: brand, events: events}
 }
 
 // ListJobs handles GET /jobs
 func (h *JobHandler) ListJobs(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -31,12 +44,32 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 		Search:         c.Query("search"),
 		LocationType:   c.Query("location"),
 		BookmarkedOnly: c.Query("bookmarked") == "true",
+		Archived:       c.Query("archived") == "true",
+		Sort:           c.Query("sort"),
+		Tracking:       c.Query("tracking"),
+	}
+	if filter.Sort != "" && !repository.ValidSort(filter.Sort) {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid sort — use match, newest, salary, or company")
+		return
+	}
+	if filter.Tracking != "" && filter.Tracking != repository.TrackingWatching && filter.Tracking != repository.TrackingApplied {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid tracking — use watching or applied")
+		return
 	}
 
 	jobs, err := h.jobRepo.List(c.Request.Context(), userID, filter)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list jobs")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list jobs")
+		return
+	}
+
+	if wantsCSV(c) {
+		records := make([][]string, len(jobs))
+		for i := range jobs {
+			records[i] = jobCSVRecord(&jobs[i])
+		}
+		writeCSV(c, "hireiq-jobs.csv", jobCSVHeader, records)
 		return
 	}
 
@@ -44,31 +77,55 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 		jobs = []model.Job{}
 	}
 
-	c.JSON(http.StatusOK, jobs)
+	respondList(c, jobs, nil)
+}
+
+// CountJobs handles GET /jobs/counts
+// Splits active jobs into ones the user is watching and ones they've applied
+// to (status past "saved", or marked applied externally).
+func (h *JobHandler) CountJobs(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	watching, applied, err := h.jobRepo.CountByTracking(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count jobs")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to count jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"watching": watching,
+		"applied":  applied,
+		"total":    watching + applied,
+	})
 }
 
 // GetJob handles GET /jobs/:id
 func (h *JobHandler) GetJob(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
 	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get job")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get job")
 		return
 	}
 	if job == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
 		return
 	}
 
@@ -76,104 +133,350 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 }
 
 // CreateJob handles POST /jobs
+// With ?createContact=true, a job carrying a hiring email also creates a
+// "Recruiter" contact at the job's company (skipped if that email already exists).
 func (h *JobHandler) CreateJob(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	var job model.Job
 	if err := c.ShouldBindJSON(&job); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
 	job.UserID = userID
 
+	// Best-effort logo/color for jobs whose source didn't supply one
+	brandCtx, cancel := context.WithTimeout(c.Request.Context(), brandLookupTimeout)
+	h.brand.Enrich(brandCtx, &job)
+	cancel()
+
 	created, err := h.jobRepo.Create(c.Request.Context(), &job)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create job")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save job"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to save job")
 		return
 	}
 
+	// The contact is a side effect, so API tokens also need contacts:write
+	if c.Query("createContact") == "true" && created.HiringEmail != "" &&
+		middleware.HasScope(c, model.ScopeContactsWrite) {
+		h.createRecruiterContact(c.Request.Context(), created)
+	}
+
 	c.JSON(http.StatusCreated, created)
 }
 
+// brandLookupTimeout bounds how long a save waits on logo enrichment
+const brandLookupTimeout = 3 * time.Second
+
+// enrichSavedJob fills in the logo and color of an already-saved job and
+// persists them. Failures are logged; the job is returned as saved.
+func enrichSavedJob(ctx context.Context, brand *service.BrandService, jobRepo *repository.JobRepo, job *model.Job) {
+	if job == nil || job.CompanyLogo != "" {
+		return
+	}
+
+	brandCtx, cancel := context.WithTimeout(ctx, brandLookupTimeout)
+	defer cancel()
+	brand.Enrich(brandCtx, job)
+	if job.CompanyLogo == "" {
+		return
+	}
+
+	if err := jobRepo.UpdateBranding(ctx, job.ID, job.UserID, job.CompanyLogo, job.CompanyColor); err != nil {
+		log.Warn().Err(err).Str("jobId", job.ID.String()).Msg("Failed to save job branding")
+	}
+}
+
+// createRecruiterContact turns a job's hiring email into a networking contact.
+// Failures are logged, not returned — the job itself was saved successfully.
+func (h *JobHandler) createRecruiterContact(ctx context.Context, job *model.Job) {
+	if h.contactRepo == nil {
+		return
+	}
+
+	email := strings.TrimSpace(job.HiringEmail)
+	existing, err := h.contactRepo.FindByEmail(ctx, job.UserID, email)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check for existing recruiter contact")
+		return
+	}
+	if existing != nil {
+		return
+	}
+
+	jobID := job.ID
+	_, err = h.contactRepo.Create(ctx, &model.Contact{
+		UserID:     job.UserID,
+		Name:       nameFromEmail(email, job.Company),
+		Company:    job.Company,
+		Role:       "Recruiter",
+		Connection: "3rd",
+		Email:      email,
+		Tip:        fmt.Sprintf("Hiring contact from the %s posting", job.Title),
+		JobID:      &jobID,
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("jobId", job.ID.String()).Msg("Failed to create recruiter contact")
+		return
+	}
+	log.Info().Str("jobId", job.ID.String()).Msg("Recruiter contact created from job posting")
+}
+
+// nameFromEmail derives a display name from an email local part
+// ("jane.doe@acme.com" -> "Jane Doe"). Generic inboxes and local parts with
+// no name in them fall back to the company, or the email itself without one.
+func nameFromEmail(email, company string) string {
+	local, _, _ := strings.Cut(email, "@")
+	words := strings.FieldsFunc(local, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == '+'
+	})
+	switch {
+	case len(words) == 0, len(words) == 1 && genericInboxes[strings.ToLower(words[0])]:
+		if company = strings.TrimSpace(company); company != "" {
+			return "Recruiter at " + company
+		}
+		return email
+	}
+
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// genericInboxes are shared mailbox names that say nothing about who reads them
+var genericInboxes = map[string]bool{
+	"jobs": true, "careers": true, "recruiting": true, "recruiter": true,
+	"hiring": true, "talent": true, "hr": true,
+}
+
 // UpdateJob handles PUT /jobs/:id
 func (h *JobHandler) UpdateJob(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
-	var job model.Job
-	if err := c.ShouldBindJSON(&job); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var req struct {
+		model.Job
+		// The updatedAt the client last saw; if the job changed since, the
+		// update is rejected instead of overwriting the other edit
+		IfUnmodifiedSince *time.Time `json:"ifUnmodifiedSince"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
+	job := req.Job
 	job.ID = jobID
 	job.UserID = userID
 
-	updated, err := h.jobRepo.Update(c.Request.Context(), &job)
+	updated, err := h.jobRepo.Update(c.Request.Context(), &job, req.IfUnmodifiedSince)
+	if errors.Is(err, repository.ErrStaleUpdate) {
+		current, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load job after stale update")
+		}
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   apierror.New(apierror.Conflict, "This job was changed elsewhere since you loaded it. Review the latest version and try again."),
+			"current": current,
+		})
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to update job")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update job")
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// validJobStatuses are the Kanban columns a job can sit in
+var validJobStatuses = map[string]bool{
+	"saved": true, "applied": true, "screening": true,
+	"interview": true, "offer": true, "rejected": true,
+}
+
+// PatchJob handles PATCH /jobs/:id
+// Updates only the fields present in the body; anything omitted (or null) is
+// left as it is, unlike PUT which replaces the whole job.
+func (h *JobHandler) PatchJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	var req struct {
+		model.JobPatch
+		IfUnmodifiedSince *time.Time `json:"ifUnmodifiedSince"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	if req.Status != nil && !validJobStatuses[*req.Status] {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid status. Must be: saved, applied, screening, interview, offer, rejected")
+		return
+	}
+
+	updated, err := h.jobRepo.PartialUpdate(c.Request.Context(), jobID, userID, &req.JobPatch, req.IfUnmodifiedSince)
+	if errors.Is(err, repository.ErrStaleUpdate) {
+		current, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load job after stale update")
+		}
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   apierror.New(apierror.Conflict, "This job was changed elsewhere since you loaded it. Review the latest version and try again."),
+			"current": current,
+		})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to patch job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update job")
+		return
+	}
+	if updated == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
 		return
 	}
 
+	if req.Status != nil {
+		h.syncApplicationStatus(c.Request.Context(), userID, jobID, *req.Status, "Updated via job edit")
+	}
+
 	c.JSON(http.StatusOK, updated)
 }
 
 // DeleteJob handles DELETE /jobs/:id
+// Archives the job by default so it can be restored; ?purge=true deletes it
+// permanently along with its application history and notes.
 func (h *JobHandler) DeleteJob(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	if c.Query("purge") == "true" {
+		if err := h.jobRepo.Delete(c.Request.Context(), jobID, userID); err != nil {
+			respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"deleted": true})
 		return
 	}
 
-	if err := h.jobRepo.Delete(c.Request.Context(), jobID, userID); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+	if err := h.jobRepo.Archive(c.Request.Context(), jobID, userID); err != nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"deleted": true})
+	c.JSON(http.StatusOK, gin.H{"archived": true})
+}
+
+// UnarchiveJob handles POST /jobs/:id/unarchive
+// Restores a job archived by DELETE /jobs/:id
+func (h *JobHandler) UnarchiveJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	if err := h.jobRepo.Unarchive(c.Request.Context(), jobID, userID); err != nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Archived job not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"archived": false})
+}
+
+// DuplicateJob copies a job as a new saved job (not bookmarked) so the user
+// can track a variant, e.g. another role at the same company. The
+// application and notes aren't copied.
+// POST /jobs/:id/duplicate
+func (h *JobHandler) DuplicateJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	dup, err := h.jobRepo.Duplicate(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to duplicate job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to duplicate job")
+		return
+	}
+	if dup == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dup)
 }
 
 // ToggleBookmark handles POST /jobs/:id/bookmark
 func (h *JobHandler) ToggleBookmark(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
 	bookmarked, err := h.jobRepo.ToggleBookmark(c.Request.Context(), jobID, userID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to toggle bookmark")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to toggle bookmark"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to toggle bookmark")
 		return
 	}
 
@@ -184,13 +487,13 @@ func (h *JobHandler) ToggleBookmark(c *gin.Context) {
 func (h *JobHandler) UpdateJobStatus(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	jobID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
 		return
 	}
 
@@ -198,35 +501,57 @@ func (h *JobHandler) UpdateJobStatus(c *gin.Context) {
 		Status string `json:"status" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Status is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Status is required")
 		return
 	}
 
-	// Validate status value
-	validStatuses := map[string]bool{
-		"saved": true, "applied": true, "screening": true,
-		"interview": true, "offer": true, "rejected": true,
-	}
-	if !validStatuses[req.Status] {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be: saved, applied, screening, interview, offer, rejected"})
+	if !validJobStatuses[req.Status] {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid status. Must be: saved, applied, screening, interview, offer, rejected")
 		return
 	}
 
 	if err := h.jobRepo.UpdateStatus(c.Request.Context(), jobID, userID, req.Status); err != nil {
 		log.Error().Err(err).Msg("Failed to update job status")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update status"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update status")
 		return
 	}
 
-	// Sync application record if one exists (keeps pipeline tracker in sync with Kanban)
-	if h.appRepo != nil {
-		app, err := h.appRepo.FindByJobID(c.Request.Context(), userID, jobID)
-		if err == nil && app != nil && app.Status != req.Status {
-			if _, syncErr := h.appRepo.UpdateStatus(c.Request.Context(), app.ID, userID, req.Status, "Updated via Kanban board"); syncErr != nil {
-				log.Warn().Err(syncErr).Msg("Failed to sync application status from Kanban")
-			}
-		}
-	}
+	h.syncApplicationStatus(c.Request.Context(), userID, jobID, req.Status, "Updated via Kanban board")
 
 	c.JSON(http.StatusOK, gin.H{"status": req.Status})
 }
+
+// syncApplicationStatus moves the job's application, if it has one, to the
+// job's new status so the pipeline tracker stays in sync with the Kanban
+// board. The change is recorded in the application's status history and
+// published to status change subscribers, like any other application move.
+func (h *JobHandler) syncApplicationStatus(ctx context.Context, userID, jobID uuid.UUID, status, note string) {
+	if h.appRepo == nil {
+		return
+	}
+	app, err := h.appRepo.FindByJobID(ctx, userID, jobID)
+	if err != nil || app == nil || app.Status == status {
+		return
+	}
+
+	outcome, _ := resolveOutcome(status, "")
+	updated, changed, err := h.appRepo.UpdateStatus(ctx, app.ID, userID, status, outcome, note)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to sync application status from Kanban")
+		return
+	}
+	if !changed {
+		return
+	}
+
+	h.events.Publish(ctx, model.StatusChangeEvent{
+		UserID:        userID,
+		ApplicationID: app.ID,
+		JobID:         jobID,
+		FromStatus:    app.Status,
+		ToStatus:      updated.Status,
+		Outcome:       updated.Outcome,
+		Note:          note,
+		ChangedAt:     updated.UpdatedAt,
+	})
+}
@@ -0,0 +1,22 @@
+package handler
+
+import "testing"
+
+func TestNameFromEmail(t *testing.T) {
+	tests := []struct {
+		email, company, want string
+	}{
+		{"jane.doe@acme.com", "Acme", "Jane Doe"},
+		{"émile_roy@acme.com", "Acme", "Émile Roy"},
+		{"careers@acme.com", "Acme", "Recruiter at Acme"},
+		{"Jobs+eng@acme.com", "Acme", "Jobs Eng"},
+		{"._-@acme.com", "Acme", "Recruiter at Acme"},
+		{"hr@acme.com", " ", "hr@acme.com"},
+		{"@acme.com", "", "@acme.com"},
+	}
+	for _, tt := range tests {
+		if got := nameFromEmail(tt.email, tt.company); got != tt.want {
+			t.Errorf("nameFromEmail(%q, %q) = %q, want %q", tt.email, tt.company, got, tt.want)
+		}
+	}
+}
@@ -4,32 +4,36 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
 )
 
 type NetworkHandler struct {
-	jobRepo     *repository.JobRepo
-	contactRepo *repository.ContactRepo
+	jobRepo      *repository.JobRepo
+	contactRepo  *repository.ContactRepo
+	questionRepo *repository.InterviewQuestionRepo
 }
 
-func NewNetworkHandler(jobRepo *repository.JobRepo, contactRepo *repository.ContactRepo) *NetworkHandler {
-	return &NetworkHandler{jobRepo: jobRepo, contactRepo: contactRepo}
+func NewNetworkHandler(jobRepo *repository.JobRepo, contactRepo *repository.ContactRepo, questionRepo *repository.InterviewQuestionRepo) *NetworkHandler {
+	return &NetworkHandler{jobRepo: jobRepo, contactRepo: contactRepo, questionRepo: questionRepo}
 }
 
 // ListCompanies handles GET /network/companies
 func (h *NetworkHandler) ListCompanies(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	companies, err := h.jobRepo.ListCompanies(c.Request.Context(), userID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list companies")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list companies"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list companies")
 		return
 	}
 
@@ -37,34 +41,41 @@ func (h *NetworkHandler) ListCompanies(c *gin.Context) {
 		companies = []model.CompanySummary{}
 	}
 
-	c.JSON(http.StatusOK, companies)
+	respondList(c, companies, nil)
 }
 
 // GetCompanyDetail handles GET /network/companies/:company/detail
 func (h *NetworkHandler) GetCompanyDetail(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	company := c.Param("company")
 	if company == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Company name is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Company name is required")
 		return
 	}
 
-	jobs, err := h.jobRepo.ListByCompany(c.Request.Context(), userID, company)
+	jobs, err := h.jobRepo.ListByCompanyNormalized(c.Request.Context(), userID, company)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list company jobs")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list company jobs"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list company jobs")
 		return
 	}
 
-	contacts, err := h.contactRepo.ListByCompany(c.Request.Context(), userID, company)
+	contacts, err := h.contactRepo.ListByCompanyNormalized(c.Request.Context(), userID, company)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list company contacts")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list company contacts"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list company contacts")
+		return
+	}
+
+	questions, err := h.questionRepo.ListByCompanyNormalized(c.Request.Context(), userID, company)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list company interview questions")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list company interview questions")
 		return
 	}
 
@@ -74,10 +85,110 @@ func (h *NetworkHandler) GetCompanyDetail(c *gin.Context) {
 	if contacts == nil {
 		contacts = []model.Contact{}
 	}
+	aggregated := service.AggregateQuestions(questions)
+	if aggregated == nil {
+		aggregated = []model.CompanyQuestion{}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"company":  company,
-		"jobs":     jobs,
+		"company":   company,
+		"jobs":      jobs,
+		"contacts":  contacts,
+		"questions": aggregated,
+	})
+}
+
+// GetCompanyQuestions handles GET /network/companies/:company/questions
+// Returns the questions the user recorded from interviews at the company,
+// merged so repeats show how often each came up.
+func (h *NetworkHandler) GetCompanyQuestions(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	company := c.Param("company")
+	if company == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Company name is required")
+		return
+	}
+
+	questions, err := h.questionRepo.ListByCompanyNormalized(c.Request.Context(), userID, company)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list company interview questions")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list company interview questions")
+		return
+	}
+
+	aggregated := service.AggregateQuestions(questions)
+	if aggregated == nil {
+		aggregated = []model.CompanyQuestion{}
+	}
+
+	respondListLegacy(c, aggregated, gin.H{"company": company, "total": len(questions)}, gin.H{
+		"company":   company,
+		"total":     len(questions),
+		"questions": aggregated,
+	})
+}
+
+// GetJobContacts handles GET /jobs/:id/contacts
+// Returns contacts linked to the job plus anyone at the job's company
+// (normalized name match), for "You know 2 people here".
+func (h *NetworkHandler) GetJobContacts(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for contacts")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get job contacts")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	linked, err := h.contactRepo.ListByJobID(c.Request.Context(), userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list job-linked contacts")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get job contacts")
+		return
+	}
+
+	atCompany, err := h.contactRepo.ListByCompanyNormalized(c.Request.Context(), userID, job.Company)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list company contacts")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get job contacts")
+		return
+	}
+
+	// Linked contacts first, then company matches, without duplicates
+	contacts := []model.Contact{}
+	seen := make(map[uuid.UUID]bool)
+	for _, list := range [][]model.Contact{linked, atCompany} {
+		for _, contact := range list {
+			if !seen[contact.ID] {
+				seen[contact.ID] = true
+				contacts = append(contacts, contact)
+			}
+		}
+	}
+
+	respondListLegacy(c, contacts, gin.H{"company": job.Company}, gin.H{
+		"company":  job.Company,
+		"count":    len(contacts),
 		"contacts": contacts,
 	})
 }
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// maxNoteLength caps a single note's content, in characters
+const maxNoteLength = 10000
+
+type NoteHandler struct {
+	noteRepo *repository.NoteRepo
+	jobRepo  *repository.JobRepo
+}
+
+func NewNoteHandler(noteRepo *repository.NoteRepo, jobRepo *repository.JobRepo) *NoteHandler {
+	return &NoteHandler{noteRepo: noteRepo, jobRepo: jobRepo}
+}
+
+// List returns a job's notes, newest first
+// GET /jobs/:id/notes
+func (h *NoteHandler) List(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	notes, err := h.noteRepo.ListByJob(c.Request.Context(), userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list notes")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list notes")
+		return
+	}
+
+	if notes == nil {
+		notes = []model.Note{}
+	}
+	respondList(c, notes, nil)
+}
+
+// Create adds a note to a job
+// POST /jobs/:id/notes
+func (h *NoteHandler) Create(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	var req struct {
+		Content string `json:"content"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "content is required")
+		return
+	}
+	if utf8.RuneCountInString(content) > maxNoteLength {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("Note is too long (max %d characters)", maxNoteLength))
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for note")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create note")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	note, err := h.noteRepo.Create(c.Request.Context(), userID, jobID, content)
+	if errors.Is(err, repository.ErrNoteLimitReached) {
+		respondError(c, http.StatusConflict, apierror.Conflict, "This job has reached the maximum number of notes")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create note")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create note")
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// Delete removes a note
+// DELETE /jobs/:id/notes/:noteId
+func (h *NoteHandler) Delete(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	noteID, err := uuid.Parse(c.Param("noteId"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid note ID")
+		return
+	}
+
+	err = h.noteRepo.Delete(c.Request.Context(), noteID, userID)
+	if errors.Is(err, repository.ErrNoteNotFound) {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Note not found")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete note")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to delete note")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted"})
+}
@@ -1,38 +1,50 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
 	"github.com/yourusername/hireiq-api/internal/service"
 )
 
 type ParseHandler struct {
-	claude *service.ClaudeClient
+	claude  *service.ClaudeClient
+	fetcher *service.URLFetcher
+	jobRepo *repository.JobRepo
 }
 
-func NewParseHandler(claude *service.ClaudeClient) *ParseHandler {
-	return &ParseHandler{claude: claude}
+func NewParseHandler(claude *service.ClaudeClient, fetcher *service.URLFetcher, jobRepo *repository.JobRepo) *ParseHandler {
+	return &ParseHandler{claude: claude, fetcher: fetcher, jobRepo: jobRepo}
 }
 
 // ParseJobPosting handles POST /jobs/parse
-// Accepts either raw text or a URL, parses it with Claude, returns structured job data
+// Accepts either raw text or a URL, parses it with Claude, returns structured job data.
+// With {multi: true} the input is treated as a careers/listing page and the
+// response is {jobs: [...]} instead of a single job.
 func (h *ParseHandler) ParseJobPosting(c *gin.Context) {
 	var req struct {
-		Text string `json:"text"` // Raw pasted text
-		URL  string `json:"url"`  // Or a URL to fetch first
+		Text  string `json:"text"`  // Raw pasted text
+		URL   string `json:"url"`   // Or a URL to fetch first
+		Multi bool   `json:"multi"` // Extract every posting on the page
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
 		return
 	}
 
 	// Need either text or URL
 	if strings.TrimSpace(req.Text) == "" && strings.TrimSpace(req.URL) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either 'text' or 'url'"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Provide either 'text' or 'url'")
 		return
 	}
 
@@ -42,14 +54,12 @@ func (h *ParseHandler) ParseJobPosting(c *gin.Context) {
 	if req.URL != "" {
 		log.Info().Str("url", req.URL).Msg("Fetching job posting URL")
 
-		fetched, err := service.FetchURLContent(c.Request.Context(), req.URL)
+		fetched, err := h.fetcher.FetchURLContent(c.Request.Context(), req.URL)
 		if err != nil {
 			log.Warn().Err(err).Str("url", req.URL).Msg("Failed to fetch URL")
 			// If URL fetch fails but we also have text, fall back to text
 			if content == "" {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "Could not fetch URL. Try pasting the job description text instead.",
-				})
+				respondError(c, http.StatusBadRequest, apierror.UpstreamFailed, "Could not fetch URL. Try pasting the job description text instead.")
 				return
 			}
 			// Otherwise use the text they pasted
@@ -68,14 +78,16 @@ func (h *ParseHandler) ParseJobPosting(c *gin.Context) {
 		content = content[:50000]
 	}
 
+	if req.Multi {
+		h.parseListings(c, content, req.URL)
+		return
+	}
+
 	log.Info().Int("contentLength", len(content)).Msg("Parsing job posting with Claude")
 
 	parsed, err := h.claude.ParseJobPosting(c.Request.Context(), content)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to parse job posting")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to parse job posting. Please try again or enter details manually.",
-		})
+		respondAIError(c, err, "Failed to parse job posting", "Failed to parse job posting. Please try again or enter details manually.")
 		return
 	}
 
@@ -92,27 +104,353 @@ func (h *ParseHandler) ParseJobPosting(c *gin.Context) {
 	c.JSON(http.StatusOK, parsed)
 }
 
-// inferSource guesses the job source from the URL domain
-func inferSource(url string) string {
-	lower := strings.ToLower(url)
+// parseListings extracts all postings from a listing page and responds with {jobs: [...]}
+func (h *ParseHandler) parseListings(c *gin.Context, content, sourceURL string) {
+	log.Info().Int("contentLength", len(content)).Msg("Parsing job listings with Claude")
+
+	jobs, err := h.claude.ParseJobListings(c.Request.Context(), content)
+	if err != nil {
+		respondAIError(c, err, "Failed to parse job listings", "Failed to parse job listings. Please try again or paste a single posting.")
+		return
+	}
+
+	if jobs == nil {
+		jobs = []service.ParsedJob{}
+	}
+
+	// Same URL fallbacks as single-job mode, applied per posting
+	if sourceURL != "" {
+		for i := range jobs {
+			if jobs[i].Source == "" {
+				jobs[i].Source = inferSource(sourceURL)
+			}
+			if jobs[i].ApplyURL == "" {
+				jobs[i].ApplyURL = sourceURL
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+// maxBatchParseChars caps the combined text of a batch parse, the same budget
+// as a single posting
+const maxBatchParseChars = 50000
+
+// Per-item outcomes of a batch parse
+const (
+	batchParseParsed     = "parsed"
+	batchParseIncomplete = "incomplete" // parsed, but no title or company found
+	batchParseFailed     = "failed"     // not a job posting, or missing from the result
+)
+
+type batchParseResult struct {
+	Index  int                `json:"index"`
+	Status string             `json:"status"`
+	Job    *service.ParsedJob `json:"job,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// ParseBatch handles POST /jobs/parse/batch
+// Parses several postings from one pasted block ({text}) or a list of
+// already-separated postings ({postings: [...]}) in a single Claude call.
+// Each result carries its own status so a partially readable paste still
+// returns the postings that parsed.
+func (h *ParseHandler) ParseBatch(c *gin.Context) {
+	var req struct {
+		Text     string   `json:"text"`
+		Postings []string `json:"postings"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	var postings []string
+	total := 0
+	for _, p := range req.Postings {
+		if p = strings.TrimSpace(p); p != "" {
+			postings = append(postings, p)
+			total += len(p)
+		}
+	}
+	text := strings.TrimSpace(req.Text)
+	total += len(text)
+
 	switch {
-	case strings.Contains(lower, "linkedin.com"):
-		return "linkedin"
-	case strings.Contains(lower, "greenhouse.io"):
-		return "greenhouse"
-	case strings.Contains(lower, "lever.co"):
-		return "lever"
-	case strings.Contains(lower, "indeed.com"):
-		return "indeed"
-	case strings.Contains(lower, "glassdoor.com"):
-		return "glassdoor"
-	case strings.Contains(lower, "wellfound.com") || strings.Contains(lower, "angel.co"):
-		return "angellist"
-	case strings.Contains(lower, "workday.com"):
-		return "workday"
-	case strings.Contains(lower, "ashbyhq.com"):
-		return "ashby"
-	default:
-		return "other"
+	case text == "" && len(postings) == 0:
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Provide either 'text' or 'postings'")
+		return
+	case text != "" && len(postings) > 0:
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Provide either 'text' or 'postings', not both")
+		return
+	case len(postings) > service.MaxBatchPostings:
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("At most %d postings per batch", service.MaxBatchPostings))
+		return
+	case total > maxBatchParseChars:
+		respondError(c, http.StatusRequestEntityTooLarge, apierror.InvalidInput, fmt.Sprintf("Batch text is too long (max %d characters)", maxBatchParseChars))
+		return
+	}
+
+	log.Info().Int("contentLength", total).Int("postings", len(postings)).Msg("Parsing job posting batch with Claude")
+
+	items, err := h.claude.ParsePostingBatch(c.Request.Context(), text, postings)
+	if err != nil {
+		respondAIError(c, err, "Failed to parse job posting batch", "Failed to parse job postings. Please try again or paste them one at a time.")
+		return
+	}
+
+	results := batchParseResults(items, len(postings))
+	truncated := len(results) > service.MaxBatchPostings
+	if truncated {
+		results = results[:service.MaxBatchPostings]
+	}
+
+	parsed := 0
+	for _, r := range results {
+		if r.Status != batchParseFailed {
+			parsed++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":   results,
+		"parsed":    parsed,
+		"failed":    len(results) - parsed,
+		"truncated": truncated,
+	})
+}
+
+// batchParseResults turns Claude's items into per-posting results. When the
+// postings were sent separately (expected > 0), every one gets a result in
+// input order, and any Claude skipped is reported as failed.
+func batchParseResults(items []service.ParsedBatchItem, expected int) []batchParseResult {
+	toResult := func(index int, item service.ParsedBatchItem) batchParseResult {
+		r := batchParseResult{Index: index}
+		switch {
+		case strings.TrimSpace(item.Error) != "":
+			r.Status, r.Error = batchParseFailed, item.Error
+		case strings.TrimSpace(item.Title) == "" || strings.TrimSpace(item.Company) == "":
+			job := item.ParsedJob
+			r.Status, r.Job = batchParseIncomplete, &job
+		default:
+			job := item.ParsedJob
+			r.Status, r.Job = batchParseParsed, &job
+		}
+		return r
+	}
+
+	if expected == 0 {
+		results := make([]batchParseResult, len(items))
+		for i, item := range items {
+			results[i] = toResult(i+1, item)
+		}
+		return results
+	}
+
+	byIndex := make(map[int]service.ParsedBatchItem, len(items))
+	for _, item := range items {
+		if _, dup := byIndex[item.Index]; !dup {
+			byIndex[item.Index] = item
+		}
+	}
+	results := make([]batchParseResult, expected)
+	for i := range results {
+		item, ok := byIndex[i+1]
+		if !ok {
+			results[i] = batchParseResult{Index: i + 1, Status: batchParseFailed, Error: "Posting could not be parsed"}
+			continue
+		}
+		results[i] = toResult(i+1, item)
+	}
+	return results
+}
+
+// jobSource is a job board or ATS recognized by its URL domain
+type jobSource struct {
+	Name    string   // stored as Job.Source
+	Label   string   // display name
+	Domains []string // matched against the URL host and its parent domains
+}
+
+var jobSources = []jobSource{
+	{"linkedin", "LinkedIn", []string{"linkedin.com"}},
+	{"greenhouse", "Greenhouse", []string{"greenhouse.io"}},
+	{"lever", "Lever", []string{"lever.co"}},
+	{"indeed", "Indeed", []string{"indeed.com"}},
+	{"glassdoor", "Glassdoor", []string{"glassdoor.com"}},
+	{"angellist", "Wellfound", []string{"wellfound.com", "angel.co"}},
+	{"workday", "Workday", []string{"workday.com", "myworkdayjobs.com"}},
+	{"ashby", "Ashby", []string{"ashbyhq.com"}},
+	{"smartrecruiters", "SmartRecruiters", []string{"smartrecruiters.com"}},
+	{"workable", "Workable", []string{"workable.com"}},
+	{"icims", "iCIMS", []string{"icims.com"}},
+	{"jobvite", "Jobvite", []string{"jobvite.com"}},
+	{"bamboohr", "BambooHR", []string{"bamboohr.com"}},
+	{"recruitee", "Recruitee", []string{"recruitee.com"}},
+	{"breezy", "Breezy HR", []string{"breezy.hr"}},
+	{"jazzhr", "JazzHR", []string{"applytojob.com"}},
+	{"teamtailor", "Teamtailor", []string{"teamtailor.com"}},
+	{"personio", "Personio", []string{"jobs.personio.de", "jobs.personio.com"}},
+	{"rippling", "Rippling", []string{"ats.rippling.com"}},
+	{"taleo", "Taleo", []string{"taleo.net"}},
+	{"successfactors", "SAP SuccessFactors", []string{"successfactors.com", "successfactors.eu"}},
+	{"ziprecruiter", "ZipRecruiter", []string{"ziprecruiter.com"}},
+	{"dice", "Dice", []string{"dice.com"}},
+	{"builtin", "Built In", []string{"builtin.com"}},
+	{"remotive", "Remotive", []string{"remotive.com", "remotive.io"}},
+	{"weworkremotely", "We Work Remotely", []string{"weworkremotely.com"}},
+}
+
+var otherJobSource = jobSource{Name: "other", Label: "Other"}
+
+// detectSource finds the job board or ATS a URL belongs to. A URL pasted
+// without a scheme ("boards.greenhouse.io/...") is accepted.
+func detectSource(rawURL string) jobSource {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return otherJobSource
+	}
+	host := strings.ToLower(u.Hostname())
+
+	for _, src := range jobSources {
+		for _, d := range src.Domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				return src
+			}
+		}
+	}
+	return otherJobSource
+}
+
+// inferSource guesses the job source from the URL domain
+func inferSource(rawURL string) string {
+	return detectSource(rawURL).Name
+}
+
+// DetectSource handles GET /jobs/detect-source?url=
+// Identifies the job board or ATS behind a URL without fetching it, so the
+// client can label the posting before the slower AI parse.
+func (h *ParseHandler) DetectSource(c *gin.Context) {
+	rawURL := strings.TrimSpace(c.Query("url"))
+	if rawURL == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "url is required")
+		return
+	}
+
+	src := detectSource(rawURL)
+	// Every site goes through the same generic extraction (JSON-LD, embedded
+	// app state, visible text); none has a site-specific extractor yet
+	c.JSON(http.StatusOK, gin.H{
+		"source":               src.Name,
+		"label":                src.Label,
+		"known":                src.Name != otherJobSource.Name,
+		"specializedExtractor": false,
+	})
+}
+
+// RefreshJob handles POST /jobs/:id/refresh
+// Re-fetches a saved job's apply URL, re-parses it, and updates the posting
+// details (description, salary, skills, type, location). The user's own
+// tracking state — status, bookmark, notes, title/company edits — is kept.
+func (h *ParseHandler) RefreshJob(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for refresh")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to refresh job")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+	if strings.TrimSpace(job.ApplyURL) == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "This job has no URL to refresh from")
+		return
+	}
+
+	fetched, err := h.fetcher.FetchURLContent(c.Request.Context(), job.ApplyURL)
+	if err != nil {
+		log.Warn().Err(err).Str("url", job.ApplyURL).Msg("Failed to fetch job URL for refresh")
+		respondError(c, http.StatusBadGateway, apierror.UpstreamFailed, "Could not fetch the job posting. It may have been taken down.")
+		return
+	}
+
+	content := "Source URL: " + job.ApplyURL + "\n\n" + fetched
+	if len(content) > 50000 {
+		content = content[:50000]
+	}
+
+	parsed, err := h.claude.ParseJobPosting(c.Request.Context(), content)
+	if err != nil {
+		respondAIError(c, err, "Failed to re-parse job posting", "Failed to parse job posting. Please try again.")
+		return
+	}
+
+	changed := mergeParsedJob(job, parsed)
+	if len(changed) == 0 {
+		c.JSON(http.StatusOK, gin.H{"job": job, "changed": changed})
+		return
+	}
+
+	// The fetch and parse take a while; don't overwrite edits made meanwhile
+	updated, err := h.jobRepo.Update(c.Request.Context(), job, &job.UpdatedAt)
+	if errors.Is(err, repository.ErrStaleUpdate) {
+		respondError(c, http.StatusConflict, apierror.Conflict, "This job was edited while it was being refreshed. Please try again.")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save refreshed job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to refresh job")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": updated, "changed": changed})
+}
+
+// mergeParsedJob copies freshly parsed posting details onto a saved job and
+// returns the names of fields that changed. Empty parsed values never blank
+// out existing data, and the hiring email is only filled in, not replaced.
+func mergeParsedJob(job *model.Job, parsed *service.ParsedJob) []string {
+	changed := []string{}
+	setString := func(name string, dst *string, val string) {
+		val = strings.TrimSpace(val)
+		if val != "" && val != *dst {
+			*dst = val
+			changed = append(changed, name)
+		}
+	}
+	setList := func(name string, dst *[]string, val []string) {
+		if len(val) > 0 && strings.Join(val, "\x00") != strings.Join(*dst, "\x00") {
+			*dst = val
+			changed = append(changed, name)
+		}
+	}
+
+	setString("description", &job.Description, parsed.Description)
+	setString("salaryRange", &job.SalaryRange, parsed.SalaryRange)
+	setString("jobType", &job.JobType, parsed.JobType)
+	setString("location", &job.Location, parsed.Location)
+	setList("requiredSkills", &job.RequiredSkills, parsed.RequiredSkills)
+	setList("preferredSkills", &job.PreferredSkills, parsed.PreferredSkills)
+	if job.HiringEmail == "" {
+		setString("hiringEmail", &job.HiringEmail, parsed.HiringEmail)
 	}
+	return changed
 }
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+const (
+	// maxQuestionLength caps a recorded interview question, in characters
+	maxQuestionLength = 2000
+	// maxQuestionNotesLength caps the notes on an interview question
+	maxQuestionNotesLength = 10000
+)
+
+// QuestionHandler manages the interview questions a user records, the raw
+// material for each company's question bank
+type QuestionHandler struct {
+	questionRepo *repository.InterviewQuestionRepo
+	appRepo      *repository.ApplicationRepo
+	jobRepo      *repository.JobRepo
+}
+
+func NewQuestionHandler(questionRepo *repository.InterviewQuestionRepo, appRepo *repository.ApplicationRepo, jobRepo *repository.JobRepo) *QuestionHandler {
+	return &QuestionHandler{questionRepo: questionRepo, appRepo: appRepo, jobRepo: jobRepo}
+}
+
+type questionRequest struct {
+	ApplicationID *uuid.UUID `json:"applicationId"` // create only; sets the company
+	Company       string     `json:"company"`       // create only; required without applicationId
+	Question      string     `json:"question"`
+	Category      string     `json:"category"`
+	Stage         string     `json:"stage"`
+	Notes         string     `json:"notes"`
+}
+
+// cleanQuestionRequest trims the editable fields and checks them
+func cleanQuestionRequest(req *questionRequest) error {
+	req.Question = strings.TrimSpace(req.Question)
+	req.Category = strings.TrimSpace(req.Category)
+	req.Stage = strings.TrimSpace(req.Stage)
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	if req.Question == "" {
+		return errors.New("question is required")
+	}
+	if utf8.RuneCountInString(req.Question) > maxQuestionLength {
+		return fmt.Errorf("Question is too long (max %d characters)", maxQuestionLength)
+	}
+	if utf8.RuneCountInString(req.Notes) > maxQuestionNotesLength {
+		return fmt.Errorf("Notes are too long (max %d characters)", maxQuestionNotesLength)
+	}
+	if !model.ValidQuestionCategory(req.Category) {
+		return fmt.Errorf("Invalid category: %s", req.Category)
+	}
+	return nil
+}
+
+// List handles GET /interview-questions
+// Optional ?company= narrows to one company (normalized name match).
+func (h *QuestionHandler) List(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var questions []model.InterviewQuestion
+	if company := strings.TrimSpace(c.Query("company")); company != "" {
+		questions, err = h.questionRepo.ListByCompanyNormalized(c.Request.Context(), userID, company)
+	} else {
+		questions, err = h.questionRepo.ListByUser(c.Request.Context(), userID)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list interview questions")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list interview questions")
+		return
+	}
+
+	if questions == nil {
+		questions = []model.InterviewQuestion{}
+	}
+
+	respondList(c, questions, nil)
+}
+
+// Create handles POST /interview-questions
+// With an applicationId the question is filed under that application's
+// company; otherwise company is required.
+func (h *QuestionHandler) Create(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req questionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+	if err := cleanQuestionRequest(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
+		return
+	}
+
+	company := strings.TrimSpace(req.Company)
+	if req.ApplicationID != nil {
+		app, err := h.appRepo.FindByID(c.Request.Context(), *req.ApplicationID, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to find application for interview question")
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to save interview question")
+			return
+		}
+		if app == nil {
+			respondError(c, http.StatusNotFound, apierror.NotFound, "Application not found")
+			return
+		}
+
+		job, err := h.jobRepo.FindByID(c.Request.Context(), app.JobID, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to find job for interview question")
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to save interview question")
+			return
+		}
+		if job == nil {
+			respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+			return
+		}
+		company = job.Company
+	}
+	if company == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "company or applicationId is required")
+		return
+	}
+
+	created, err := h.questionRepo.Create(c.Request.Context(), &model.InterviewQuestion{
+		UserID:        userID,
+		ApplicationID: req.ApplicationID,
+		Company:       company,
+		Question:      req.Question,
+		Category:      req.Category,
+		Stage:         req.Stage,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create interview question")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to save interview question")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// Update handles PUT /interview-questions/:id
+// Replaces the question, category, stage, and notes. The company and
+// application a question was filed under don't change.
+func (h *QuestionHandler) Update(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid question ID")
+		return
+	}
+
+	var req questionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+	if err := cleanQuestionRequest(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, err.Error())
+		return
+	}
+
+	updated, err := h.questionRepo.Update(c.Request.Context(), &model.InterviewQuestion{
+		ID:       questionID,
+		UserID:   userID,
+		Question: req.Question,
+		Category: req.Category,
+		Stage:    req.Stage,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to update interview question")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update interview question")
+		return
+	}
+	if updated == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Question not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete handles DELETE /interview-questions/:id
+func (h *QuestionHandler) Delete(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid question ID")
+		return
+	}
+
+	deleted, err := h.questionRepo.Delete(c.Request.Context(), questionID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete interview question")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to delete interview question")
+		return
+	}
+	if !deleted {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Question not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
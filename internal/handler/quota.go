@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+type QuotaHandler struct {
+	usageRepo *repository.AIUsageRepo
+	subRepo   *repository.SubscriptionRepo
+}
+
+func NewQuotaHandler(usageRepo *repository.AIUsageRepo, subRepo *repository.SubscriptionRepo) *QuotaHandler {
+	return &QuotaHandler{usageRepo: usageRepo, subRepo: subRepo}
+}
+
+// GetQuota handles GET /ai/quota
+// Returns today's AI usage per category against the user's plan limits
+func (h *QuotaHandler) GetQuota(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	sub, err := h.subRepo.FindByUserID(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get subscription for AI quota")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get AI quota")
+		return
+	}
+	plan := model.EffectivePlan(sub)
+
+	usage, err := h.usageRepo.GetDailyUsage(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get AI usage")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get AI quota")
+		return
+	}
+
+	resetsAt := repository.NextQuotaReset(time.Now())
+	quotas := make(map[string]model.AIQuota, len(model.AICategories))
+	for _, category := range model.AICategories {
+		quotas[category] = model.AIQuota{
+			Used:     usage[category],
+			Limit:    model.AIDailyLimit(plan, category),
+			ResetsAt: resetsAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"plan":       plan,
+		"categories": quotas,
+	})
+}
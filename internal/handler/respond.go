@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
+	"github.com/yourusername/hireiq-api/internal/service"
+)
+
+// aiBusyRetryAfter is the Retry-After hint (seconds) when the AI queue is full
+const aiBusyRetryAfter = "10"
+
+// respondError writes {"error": {"code": code, "message": message}}. Codes
+// are the stable apierror constants; messages are for display.
+func respondError(c *gin.Context, status int, code, message string) {
+	apierror.Respond(c, status, code, message)
+}
+
+// respondAIError reports a failed Claude call. A full AI queue is a 503 with
+// Retry-After, since the request never ran; anything else is logged with
+// logMsg and returned as a 500 with message.
+func respondAIError(c *gin.Context, err error, logMsg, message string) {
+	if errors.Is(err, service.ErrAIBusy) {
+		log.Warn().Str("path", c.FullPath()).Msg("AI queue full; rejecting request")
+		c.Header("Retry-After", aiBusyRetryAfter)
+		respondError(c, http.StatusServiceUnavailable, apierror.Unavailable, "AI features are busy right now. Please try again in a few seconds.")
+		return
+	}
+	log.Error().Err(err).Msg(logMsg)
+	respondError(c, http.StatusInternalServerError, apierror.AIFailed, message)
+}
+
+// respondList writes a 200 list response for an endpoint whose legacy shape
+// is the bare array. Enveloped clients get {"data": items, "meta": meta}
+// with meta.count set to len(items).
+func respondList[T any](c *gin.Context, items []T, meta gin.H) {
+	respondListLegacy(c, items, meta, items)
+}
+
+// respondListLegacy is respondList for endpoints whose legacy shape is an
+// object (e.g. {"jobs": [...], "count": n}); non-enveloped clients get
+// legacy unchanged.
+func respondListLegacy[T any](c *gin.Context, items []T, meta gin.H, legacy any) {
+	if !middleware.WantsEnvelope(c) {
+		c.JSON(http.StatusOK, legacy)
+		return
+	}
+
+	if meta == nil {
+		meta = gin.H{}
+	}
+	meta["count"] = len(items)
+	c.JSON(http.StatusOK, gin.H{"data": items, "meta": meta})
+}
@@ -1,27 +1,35 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ledongthuc/pdf"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
+	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
 	"github.com/yourusername/hireiq-api/internal/service"
+	"github.com/yourusername/hireiq-api/internal/skills"
 )
 
 type ResumeHandler struct {
-	claude  *service.ClaudeClient
-	jobRepo *repository.JobRepo
+	claude      *service.ClaudeClient
+	jobRepo     *repository.JobRepo
+	userRepo    *repository.UserRepo
+	feedService feedRescorer
 }
 
-func NewResumeHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo) *ResumeHandler {
-	return &ResumeHandler{claude: claude, jobRepo: jobRepo}
+func NewResumeHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo, userRepo *repository.UserRepo, feedService *service.FeedService) *ResumeHandler {
+	return &ResumeHandler{claude: claude, jobRepo: jobRepo, userRepo: userRepo, feedService: asRescorer(feedService)}
 }
 
 // Upload handles POST /resume/upload
@@ -29,26 +37,26 @@ func NewResumeHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo)
 func (h *ResumeHandler) Upload(c *gin.Context) {
 	_, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "No file uploaded")
 		return
 	}
 	defer file.Close()
 
 	// Validate file type
 	if !strings.HasSuffix(strings.ToLower(header.Filename), ".pdf") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Only PDF files are supported"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Only PDF files are supported")
 		return
 	}
 
 	// Limit to 10MB
 	if header.Size > 10*1024*1024 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File too large. Maximum size is 10MB."})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "File too large. Maximum size is 10MB.")
 		return
 	}
 
@@ -56,13 +64,13 @@ func (h *ResumeHandler) Upload(c *gin.Context) {
 	fileBytes, err := io.ReadAll(file)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to read uploaded file")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to read file")
 		return
 	}
 
 	// Validate PDF magic bytes (header must start with %PDF)
 	if len(fileBytes) < 4 || string(fileBytes[:4]) != "%PDF" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid PDF file"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid PDF file")
 		return
 	}
 
@@ -70,17 +78,13 @@ func (h *ResumeHandler) Upload(c *gin.Context) {
 	text, err := extractPDFText(fileBytes)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to extract text from PDF")
-		c.JSON(http.StatusUnprocessableEntity, gin.H{
-			"error": "Could not extract text from this PDF. It may be image-based or corrupted.",
-		})
+		respondError(c, http.StatusUnprocessableEntity, apierror.InvalidInput, "Could not extract text from this PDF. It may be image-based or corrupted.")
 		return
 	}
 
 	text = strings.TrimSpace(text)
 	if len(text) < 50 {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{
-			"error": "Very little text was extracted. This PDF may be image-based (scanned). Try a text-based PDF.",
-		})
+		respondError(c, http.StatusUnprocessableEntity, apierror.InvalidInput, "Very little text was extracted. This PDF may be image-based (scanned). Try a text-based PDF.")
 		return
 	}
 
@@ -101,7 +105,7 @@ func (h *ResumeHandler) Upload(c *gin.Context) {
 func (h *ResumeHandler) Critique(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -110,12 +114,12 @@ func (h *ResumeHandler) Critique(c *gin.Context) {
 		JobID      string `json:"jobId"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "resumeText is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "resumeText is required")
 		return
 	}
 
 	if len(req.ResumeText) < 50 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Resume text is too short"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Resume text is too short")
 		return
 	}
 
@@ -146,8 +150,7 @@ func (h *ResumeHandler) Critique(c *gin.Context) {
 
 	result, err := h.claude.CritiqueResume(c.Request.Context(), req.ResumeText, jobContext)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to critique resume")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI analysis failed. Please try again."})
+		respondAIError(c, err, "Failed to critique resume", "AI analysis failed. Please try again.")
 		return
 	}
 
@@ -159,7 +162,7 @@ func (h *ResumeHandler) Critique(c *gin.Context) {
 func (h *ResumeHandler) Fix(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
@@ -173,7 +176,7 @@ func (h *ResumeHandler) Fix(c *gin.Context) {
 		JobID string `json:"jobId"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "resumeText and issue are required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "resumeText and issue are required")
 		return
 	}
 
@@ -198,8 +201,7 @@ func (h *ResumeHandler) Fix(c *gin.Context) {
 		jobContext,
 	)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get fix suggestions")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI fix suggestions failed. Please try again."})
+		respondAIError(c, err, "Failed to get fix suggestions", "AI fix suggestions failed. Please try again.")
 		return
 	}
 
@@ -207,24 +209,36 @@ func (h *ResumeHandler) Fix(c *gin.Context) {
 }
 
 // ParseToProfile handles POST /resume/parse-profile
-// Sends resume text to Claude and returns structured profile data
+// Sends resume text to Claude and returns structured profile data. With
+// apply: true the parsed data is merged into the profile instead (see
+// mergeParsedProfile) and the updated user is returned; that needs a
+// signed-in session, like PUT /profile.
 func (h *ResumeHandler) ParseToProfile(c *gin.Context) {
-	_, err := getUserID(c)
+	userID, err := getUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 		return
 	}
 
 	var req struct {
 		ResumeText string `json:"resumeText" binding:"required"`
+		Apply      bool   `json:"apply"`
+		// With apply, the updatedAt the client last saw; if the profile
+		// changed since, nothing is merged
+		IfUnmodifiedSince *time.Time `json:"ifUnmodifiedSince"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "resumeText is required"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "resumeText is required")
 		return
 	}
 
 	if len(req.ResumeText) < 50 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Resume text is too short"})
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Resume text is too short")
+		return
+	}
+
+	if req.Apply && middleware.IsAPITokenRequest(c) {
+		respondError(c, http.StatusForbidden, apierror.Forbidden, "Applying to the profile requires a signed-in session, not an API token")
 		return
 	}
 
@@ -233,16 +247,142 @@ func (h *ResumeHandler) ParseToProfile(c *gin.Context) {
 		req.ResumeText = req.ResumeText[:30000]
 	}
 
-	log.Info().Int("resumeLen", len(req.ResumeText)).Msg("Parsing resume to profile")
+	log.Info().Int("resumeLen", len(req.ResumeText)).Bool("apply", req.Apply).Msg("Parsing resume to profile")
 
-	result, err := h.claude.ParseResumeToProfile(c.Request.Context(), req.ResumeText)
+	ctx := c.Request.Context()
+	result, err := h.claude.ParseResumeToProfile(ctx, req.ResumeText)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to parse resume to profile")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI profile parsing failed. Please try again."})
+		respondAIError(c, err, "Failed to parse resume to profile", "AI profile parsing failed. Please try again.")
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	if !req.Apply {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	user, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		log.Error().Err(err).Msg("Failed to load profile for resume merge")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update profile")
+		return
+	}
+
+	// The merge is based on the profile just read, so it only saves if that
+	// is still current (and matches what the client saw, when given)
+	expected := user.UpdatedAt
+	if req.IfUnmodifiedSince != nil {
+		expected = *req.IfUnmodifiedSince
+	}
+	mergeParsedProfile(user, result)
+
+	updated, err := h.userRepo.UpdateWithSkills(ctx, userID, user, &expected)
+	if errors.Is(err, repository.ErrStaleUpdate) {
+		current, _ := h.userRepo.FindByID(ctx, userID)
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   apierror.New(apierror.Conflict, "Your profile was changed elsewhere since you loaded it. Review the latest version and try again."),
+			"current": current,
+		})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save merged profile")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to update profile")
+		return
+	}
+
+	rescoreFeedInBackground(ctx, h.feedService, userID, "resume merge")
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// mergeParsedProfile folds parsed resume data into the user without losing
+// anything already there: empty text fields are filled in, and list entries
+// (experience, education, skills, ...) are appended unless an equivalent one
+// exists.
+func mergeParsedProfile(user *model.User, parsed *service.ParsedProfile) {
+	fill := func(dst *string, src string) {
+		if strings.TrimSpace(*dst) == "" {
+			*dst = strings.TrimSpace(src)
+		}
+	}
+	fill(&user.Name, parsed.Name)
+	fill(&user.Bio, parsed.Bio)
+	fill(&user.Location, parsed.Location)
+
+	// Entries match ignoring case and spacing
+	key := func(parts ...string) string {
+		for i, p := range parts {
+			parts[i] = strings.Join(strings.Fields(strings.ToLower(p)), " ")
+		}
+		return strings.Join(parts, "|")
+	}
+
+	haveSkill := make(map[string]bool)
+	for _, sk := range user.Skills {
+		haveSkill[skills.CanonicalKey(sk)] = true
+	}
+	for _, sk := range parsed.Skills {
+		if k := skills.CanonicalKey(sk); k != "" && !haveSkill[k] {
+			haveSkill[k] = true
+			user.Skills = append(user.Skills, skills.Canonical(sk))
+		}
+	}
+
+	haveExp := make(map[string]bool)
+	for _, e := range user.Experience {
+		haveExp[key(e.Title, e.Company, e.StartDate)] = true
+	}
+	for _, e := range parsed.Experience {
+		if k := key(e.Title, e.Company, e.StartDate); !haveExp[k] {
+			haveExp[k] = true
+			user.Experience = append(user.Experience, model.Experience(e))
+		}
+	}
+
+	haveEdu := make(map[string]bool)
+	for _, e := range user.Education {
+		haveEdu[key(e.School, e.Degree, e.Field)] = true
+	}
+	for _, e := range parsed.Education {
+		if k := key(e.School, e.Degree, e.Field); !haveEdu[k] {
+			haveEdu[k] = true
+			user.Education = append(user.Education, model.Education(e))
+		}
+	}
+
+	haveCert := make(map[string]bool)
+	for _, ce := range user.Certifications {
+		haveCert[key(ce.Name, ce.Issuer)] = true
+	}
+	for _, ce := range parsed.Certifications {
+		if k := key(ce.Name, ce.Issuer); !haveCert[k] {
+			haveCert[k] = true
+			user.Certifications = append(user.Certifications, model.Certification(ce))
+		}
+	}
+
+	haveLang := make(map[string]bool)
+	for _, l := range user.Languages {
+		haveLang[key(l.Language)] = true
+	}
+	for _, l := range parsed.Languages {
+		if k := key(l.Language); !haveLang[k] {
+			haveLang[k] = true
+			user.Languages = append(user.Languages, model.Language(l))
+		}
+	}
+
+	haveVol := make(map[string]bool)
+	for _, v := range user.Volunteer {
+		haveVol[key(v.Organization, v.Role)] = true
+	}
+	for _, v := range parsed.Volunteer {
+		if k := key(v.Organization, v.Role); !haveVol[k] {
+			haveVol[k] = true
+			user.Volunteer = append(user.Volunteer, model.Volunteer(v))
+		}
+	}
 }
 
 // ── Helpers ──────────────────────────────────────────
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
+)
+
+const (
+	defaultShareDays = 7
+	maxShareDays     = 30
+)
+
+type ShareHandler struct {
+	jobRepo     *repository.JobRepo
+	signer      *service.ShareSigner
+	frontendURL string
+}
+
+func NewShareHandler(jobRepo *repository.JobRepo, signer *service.ShareSigner, frontendURL string) *ShareHandler {
+	return &ShareHandler{jobRepo: jobRepo, signer: signer, frontendURL: frontendURL}
+}
+
+// CreateShare handles POST /jobs/:id/share
+// Mints a signed, expiring link that shows the job read-only without login
+func (h *ShareHandler) CreateShare(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	if !h.signer.Enabled() {
+		respondError(c, http.StatusServiceUnavailable, apierror.Unavailable, "Job sharing is not configured")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	var req struct {
+		ExpiresInDays int `json:"expiresInDays"`
+	}
+	// Body is optional
+	_ = c.ShouldBindJSON(&req)
+	days := req.ExpiresInDays
+	if days <= 0 {
+		days = defaultShareDays
+	}
+	if days > maxShareDays {
+		days = maxShareDays
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for share")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to share job")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	expiresAt := time.Now().Add(time.Duration(days) * 24 * time.Hour).UTC().Truncate(time.Second)
+	token := h.signer.Sign(job.ID, userID, expiresAt)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token":     token,
+		"url":       strings.TrimRight(h.frontendURL, "/") + "/shared/jobs/" + token,
+		"expiresAt": expiresAt,
+	})
+}
+
+// GetSharedJob handles GET /shared/jobs/:token (unauthenticated)
+// Verifies the signature and expiry, then returns a sanitized job view
+func (h *ShareHandler) GetSharedJob(c *gin.Context) {
+	if !h.signer.Enabled() {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Shared job not found")
+		return
+	}
+
+	jobID, userID, err := h.signer.Verify(c.Param("token"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "This share link is invalid or has expired")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load shared job")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to load shared job")
+		return
+	}
+	// Archiving a job (DELETE /jobs/:id) also retires its share links
+	if job == nil || job.ArchivedAt != nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Shared job not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewSharedJob(job))
+}
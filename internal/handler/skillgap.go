@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/service"
+)
+
+type SkillGapHandler struct {
+	claude   *service.ClaudeClient
+	jobRepo  *repository.JobRepo
+	userRepo *repository.UserRepo
+}
+
+func NewSkillGapHandler(claude *service.ClaudeClient, jobRepo *repository.JobRepo, userRepo *repository.UserRepo) *SkillGapHandler {
+	return &SkillGapHandler{claude: claude, jobRepo: jobRepo, userRepo: userRepo}
+}
+
+// Analyze handles POST /jobs/:id/skill-gap
+// Compares the user's skills to the job's required/preferred skills. With
+// ?suggest=true (Pro), also asks Claude for learning resources for the gaps;
+// the route applies the plan, AI rate limit, and quota gates to that mode.
+func (h *SkillGapHandler) Analyze(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for skill gap")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to analyze skill gap")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	user, err := h.userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil || user == nil {
+		log.Error().Err(err).Msg("Failed to load profile for skill gap")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to analyze skill gap")
+		return
+	}
+
+	gap := service.AnalyzeSkillGap(user.Skills, job.RequiredSkills, job.PreferredSkills)
+	resp := gin.H{
+		"jobId":            job.ID,
+		"matched":          gap.Matched,
+		"missingRequired":  gap.MissingRequired,
+		"missingPreferred": gap.MissingPreferred,
+		"coverage":         gap.Coverage,
+	}
+
+	if c.Query("suggest") != "true" {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	missing := append(append([]string{}, gap.MissingRequired...), gap.MissingPreferred...)
+	if len(missing) == 0 {
+		// No Claude call, so don't count it
+		middleware.GetAIQuota(c).Refund(c.Request.Context())
+		resp["suggestions"] = []service.LearningSuggestion{}
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	suggestions, err := h.claude.SuggestLearningResources(c.Request.Context(), job.Title, job.Company, missing)
+	if err != nil {
+		respondAIError(c, err, "Failed to get learning suggestions", "Failed to get learning suggestions")
+		return
+	}
+	if suggestions == nil {
+		suggestions = []service.LearningSuggestion{}
+	}
+	resp["suggestions"] = suggestions
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+type TimelineHandler struct {
+	jobRepo  *repository.JobRepo
+	appRepo  *repository.ApplicationRepo
+	noteRepo *repository.NoteRepo
+}
+
+func NewTimelineHandler(jobRepo *repository.JobRepo, appRepo *repository.ApplicationRepo, noteRepo *repository.NoteRepo) *TimelineHandler {
+	return &TimelineHandler{jobRepo: jobRepo, appRepo: appRepo, noteRepo: noteRepo}
+}
+
+// Get returns a job's status changes and notes merged into one list, oldest
+// first. A job that was never tracked as an application has only notes.
+// GET /jobs/:id/timeline
+func (h *TimelineHandler) Get(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid job ID")
+		return
+	}
+
+	ctx := c.Request.Context()
+	job, err := h.jobRepo.FindByID(ctx, jobID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find job for timeline")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get timeline")
+		return
+	}
+	if job == nil {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Job not found")
+		return
+	}
+
+	app, err := h.appRepo.FindByJobID(ctx, userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find application for timeline")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get timeline")
+		return
+	}
+
+	var history []model.StatusHistory
+	if app != nil {
+		history, err = h.appRepo.GetHistory(ctx, app.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get history for timeline")
+			respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get timeline")
+			return
+		}
+	}
+
+	notes, err := h.noteRepo.ListByJob(ctx, userID, jobID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list notes for timeline")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to get timeline")
+		return
+	}
+
+	respondList(c, buildTimeline(history, notes), nil)
+}
+
+// buildTimeline interleaves status changes and notes by time, oldest first.
+// On a tie the status change comes first, so a note written right after
+// moving a job reads as commentary on the move.
+func buildTimeline(history []model.StatusHistory, notes []model.Note) []model.TimelineEntry {
+	entries := make([]model.TimelineEntry, 0, len(history)+len(notes))
+	for i := range history {
+		entries = append(entries, model.TimelineEntry{
+			Type:   model.TimelineStatusChange,
+			At:     history[i].ChangedAt,
+			Status: &history[i],
+		})
+	}
+	for i := range notes {
+		entries = append(entries, model.TimelineEntry{
+			Type: model.TimelineNote,
+			At:   notes[i].CreatedAt,
+			Note: &notes[i],
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+	return entries
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/middleware"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+type TokenHandler struct {
+	tokenRepo *repository.APITokenRepo
+}
+
+func NewTokenHandler(tokenRepo *repository.APITokenRepo) *TokenHandler {
+	return &TokenHandler{tokenRepo: tokenRepo}
+}
+
+// maxTokenDays caps expiresInDays; longer-lived tokens can omit it to never
+// expire
+const maxTokenDays = 365
+
+type createTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expiresInDays"` // 0 = never expires
+}
+
+// List handles GET /tokens
+func (h *TokenHandler) List(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	tokens, err := h.tokenRepo.ListByUser(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list API tokens")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to list tokens")
+		return
+	}
+
+	if tokens == nil {
+		tokens = []model.APIToken{}
+	}
+
+	respondList(c, tokens, nil)
+}
+
+// Create handles POST /tokens
+// Mints a new personal access token. The plaintext token is only returned here.
+func (h *TokenHandler) Create(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	var req createTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid request body")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "name is required")
+		return
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > maxTokenDays {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, fmt.Sprintf("expiresInDays must be between 1 and %d, or 0 to never expire", maxTokenDays))
+		return
+	}
+
+	// Default to read-only when no scopes are requested
+	scopes := []string{}
+	seen := map[string]bool{}
+	for _, s := range req.Scopes {
+		s = strings.TrimSpace(s)
+		if !model.ValidScope(s) {
+			respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid scope: "+s)
+			return
+		}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		scopes = []string{model.ScopeRead}
+	}
+
+	raw, prefix, hash, err := middleware.GenerateAPIToken()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate API token")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create token")
+		return
+	}
+
+	token := &model.APIToken{
+		UserID:      userID,
+		Name:        req.Name,
+		TokenPrefix: prefix,
+		TokenHash:   hash,
+		Scopes:      scopes,
+	}
+	if req.ExpiresInDays > 0 {
+		exp := time.Now().UTC().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour)
+		token.ExpiresAt = &exp
+	}
+
+	created, err := h.tokenRepo.Create(c.Request.Context(), token)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create API token")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to create token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token":    raw,
+		"apiToken": created,
+	})
+}
+
+// Revoke handles DELETE /tokens/:id
+func (h *TokenHandler) Revoke(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+		return
+	}
+
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, apierror.InvalidInput, "Invalid token ID")
+		return
+	}
+
+	revoked, err := h.tokenRepo.Revoke(c.Request.Context(), tokenID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to revoke API token")
+		respondError(c, http.StatusInternalServerError, apierror.Internal, "Failed to revoke token")
+		return
+	}
+	if !revoked {
+		respondError(c, http.StatusNotFound, apierror.NotFound, "Token not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": true})
+}
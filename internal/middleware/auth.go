@@ -4,11 +4,15 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/repository"
 	"google.golang.org/api/option"
 )
 
@@ -17,15 +21,41 @@ const (
 	ContextKeyFirebaseUID = "firebase_uid"
 	// ContextKeyUserID is the key for the internal user UUID in the Gin context
 	ContextKeyUserID = "user_id"
+	// ContextKeyEmailVerified is true when the Firebase token's email is verified
+	ContextKeyEmailVerified = "email_verified"
+
+	// accountStatusTTL is how long a Firebase user's disabled flag is cached,
+	// bounding how long a disabled account keeps working
+	accountStatusTTL = 5 * time.Minute
+	// accountStatusFailureTTL is how long a failed lookup lets the user
+	// through before Firebase is asked again, so an outage (or missing admin
+	// credentials) doesn't cost a Firebase call and a warning per request
+	accountStatusFailureTTL = 30 * time.Second
 )
 
-// AuthMiddleware validates Firebase ID tokens and injects the UID into context
+// AuthMiddleware validates Firebase ID tokens (or hiq_ personal API tokens)
+// and injects the UID into context
 type AuthMiddleware struct {
-	client *auth.Client
+	client    *auth.Client
+	tokenRepo *repository.APITokenRepo
+	tokens    *tokenCache // nil when caching is off
+
+	// checkDisabled looks up each Firebase user so disabled or deleted
+	// accounts are rejected even while their ID tokens are still valid
+	checkDisabled bool
+	mu            sync.Mutex
+	accounts      map[string]accountStatus // Firebase UID -> cached status
 }
 
-// NewAuthMiddleware creates a new Firebase auth middleware
-func NewAuthMiddleware(projectID string) (*AuthMiddleware, error) {
+type accountStatus struct {
+	disabled  bool
+	expiresAt time.Time
+}
+
+// NewAuthMiddleware creates a new Firebase auth middleware.
+// tokenRepo may be nil to disable personal API tokens. tokenCacheSize caps
+// how many verified ID tokens are remembered; 0 verifies every request.
+func NewAuthMiddleware(projectID string, tokenRepo *repository.APITokenRepo, checkDisabled bool, tokenCacheSize int) (*AuthMiddleware, error) {
 	ctx := context.Background()
 
 	var app *firebase.App
@@ -48,7 +78,25 @@ func NewAuthMiddleware(projectID string) (*AuthMiddleware, error) {
 		return nil, err
 	}
 
-	return &AuthMiddleware{client: client}, nil
+	am := &AuthMiddleware{
+		client:        client,
+		tokenRepo:     tokenRepo,
+		tokens:        newTokenCache(tokenCacheSize),
+		checkDisabled: checkDisabled,
+		accounts:      make(map[string]accountStatus),
+	}
+
+	// Periodically drop expired account statuses, off the request path
+	if checkDisabled {
+		go func() {
+			for {
+				time.Sleep(accountStatusTTL)
+				am.evictAccountStatuses(time.Now())
+			}
+		}()
+	}
+
+	return am, nil
 }
 
 // Authenticate is the Gin middleware handler
@@ -56,42 +104,116 @@ func (am *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Missing Authorization header",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Missing Authorization header")
 			return
 		}
 
 		// Expect "Bearer <token>"
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid Authorization header format",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Invalid Authorization header format")
+			return
+		}
+
+		// Personal API tokens are distinguished by prefix
+		if strings.HasPrefix(parts[1], APITokenPrefix) {
+			am.authenticateAPIToken(c, parts[1])
 			return
 		}
 
-		token, err := am.client.VerifyIDToken(c.Request.Context(), parts[1])
-		if err != nil {
-			log.Warn().Err(err).Msg("Failed to verify Firebase token")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid or expired token",
-			})
+		token, ok := am.tokens.get(parts[1])
+		if !ok {
+			verified, err := am.client.VerifyIDToken(c.Request.Context(), parts[1])
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to verify Firebase token")
+				apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Invalid or expired token")
+				return
+			}
+			token = verifiedToken{uid: verified.UID, expiresAt: time.Unix(verified.Expires, 0)}
+			token.email, _ = verified.Claims["email"].(string)
+			token.emailVerified, _ = verified.Claims["email_verified"].(bool)
+			am.tokens.put(parts[1], token)
+		}
+
+		if am.accountDisabled(c.Request.Context(), token.uid) {
+			apierror.Abort(c, http.StatusForbidden, apierror.AccountDisabled, "This account has been disabled")
 			return
 		}
 
 		// Inject Firebase UID into context
-		c.Set(ContextKeyFirebaseUID, token.UID)
+		c.Set(ContextKeyFirebaseUID, token.uid)
 
 		// Extract email if available
-		if email, ok := token.Claims["email"].(string); ok {
-			c.Set("email", email)
+		if token.email != "" {
+			c.Set("email", token.email)
 		}
+		c.Set(ContextKeyEmailVerified, token.emailVerified)
 
 		c.Next()
 	}
 }
 
+// accountDisabled reports whether the Firebase user is disabled or no longer
+// exists. Lookups are cached for accountStatusTTL. If Firebase can't be
+// reached the request is let through rather than locking everyone out, and
+// that answer is cached for accountStatusFailureTTL.
+func (am *AuthMiddleware) accountDisabled(ctx context.Context, uid string) bool {
+	if !am.checkDisabled || uid == "" {
+		return false
+	}
+
+	am.mu.Lock()
+	status, ok := am.accounts[uid]
+	am.mu.Unlock()
+	if ok && time.Now().Before(status.expiresAt) {
+		return status.disabled
+	}
+
+	user, err := am.client.GetUser(ctx, uid)
+	var disabled bool
+	ttl := accountStatusTTL
+	switch {
+	case auth.IsUserNotFound(err):
+		disabled = true
+	case err != nil:
+		log.Warn().Err(err).Str("uid", uid).Msg("Failed to check Firebase account status")
+		ttl = accountStatusFailureTTL
+	default:
+		disabled = user.Disabled
+	}
+
+	am.mu.Lock()
+	am.accounts[uid] = accountStatus{disabled: disabled, expiresAt: time.Now().Add(ttl)}
+	am.mu.Unlock()
+
+	return disabled
+}
+
+// evictAccountStatuses drops cached account statuses that have expired
+func (am *AuthMiddleware) evictAccountStatuses(now time.Time) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	for uid, status := range am.accounts {
+		if now.After(status.expiresAt) {
+			delete(am.accounts, uid)
+		}
+	}
+}
+
+// RequireVerifiedEmail rejects Firebase sessions whose email isn't verified
+// with 403 email_unverified. API token requests pass: creating a token is
+// itself behind this check when it's enabled. A no-op when enabled is false.
+func RequireVerifiedEmail(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled || IsAPITokenRequest(c) || c.GetBool(ContextKeyEmailVerified) {
+			c.Next()
+			return
+		}
+		apierror.Abort(c, http.StatusForbidden, apierror.EmailUnverified, "Verify your email address to use this feature")
+	}
+}
+
 // GetFirebaseUID extracts the Firebase UID from the Gin context
 func GetFirebaseUID(c *gin.Context) string {
 	uid, _ := c.Get(ContextKeyFirebaseUID)
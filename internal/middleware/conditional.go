@@ -0,0 +1,16 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// WhenQuery applies mw only to requests whose query parameter key equals
+// value, for endpoints with an optional mode that needs extra gates (e.g. an
+// AI-backed ?suggest=true). Other requests pass straight through.
+func WhenQuery(key, value string, mw gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query(key) != value {
+			c.Next()
+			return
+		}
+		mw(c)
+	}
+}
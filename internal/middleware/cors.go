@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"strings"
+)
+
+// SplitOrigins separates exact CORS origins from wildcard subdomain patterns
+// like "https://*.preview.hireiq.app"
+func SplitOrigins(origins []string) (exact, patterns []string) {
+	for _, o := range origins {
+		if strings.Contains(o, "://*.") {
+			patterns = append(patterns, o)
+		} else {
+			exact = append(exact, o)
+		}
+	}
+	return exact, patterns
+}
+
+// WildcardOrigins returns a CORS origin check for subdomain patterns. The "*"
+// stands for exactly one DNS label, so "https://*.hireiq.app" allows
+// "https://pr-42.hireiq.app" but not "https://a.b.hireiq.app", the bare
+// "https://hireiq.app", or another scheme.
+func WildcardOrigins(patterns []string) func(origin string) bool {
+	type rule struct{ prefix, suffix string }
+	rules := make([]rule, 0, len(patterns))
+	for _, p := range patterns {
+		prefix, suffix, _ := strings.Cut(strings.ToLower(p), "*")
+		rules = append(rules, rule{prefix, suffix})
+	}
+
+	return func(origin string) bool {
+		origin = strings.ToLower(origin)
+		for _, r := range rules {
+			if len(origin) <= len(r.prefix)+len(r.suffix) ||
+				!strings.HasPrefix(origin, r.prefix) || !strings.HasSuffix(origin, r.suffix) {
+				continue
+			}
+			label := origin[len(r.prefix) : len(origin)-len(r.suffix)]
+			if isDNSLabel(label) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isDNSLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if !(b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media types a client can send in Accept to pick a list response shape
+// regardless of the server default
+const (
+	EnvelopeMediaType = "application/vnd.hireiq.envelope+json"
+	LegacyMediaType   = "application/vnd.hireiq.legacy+json"
+)
+
+const ContextKeyEnvelope = "response_envelope"
+
+// ResponseEnvelope records whether list endpoints should wrap their response
+// as {"data": [...], "meta": {...}}. enabled is the server default
+// (RESPONSE_ENVELOPE); an Accept header naming EnvelopeMediaType or
+// LegacyMediaType overrides it per request, so existing clients keep the
+// legacy shape while new ones opt in.
+func ResponseEnvelope(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accept := c.GetHeader("Accept")
+		switch {
+		case strings.Contains(accept, EnvelopeMediaType):
+			c.Set(ContextKeyEnvelope, true)
+		case strings.Contains(accept, LegacyMediaType):
+			c.Set(ContextKeyEnvelope, false)
+		default:
+			c.Set(ContextKeyEnvelope, enabled)
+		}
+		c.Next()
+	}
+}
+
+// WantsEnvelope reports whether ResponseEnvelope chose the enveloped shape
+// for this request
+func WantsEnvelope(c *gin.Context) bool {
+	return c.GetBool(ContextKeyEnvelope)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+)
+
+// maintenanceRetryAfter is the Retry-After hint (seconds) sent with 503s
+const maintenanceRetryAfter = "120"
+
+// MaintenanceMode is a process-wide read-only switch. While enabled, reads
+// keep working and every mutating request gets a 503, so operators can run
+// migrations without taking the API fully down.
+type MaintenanceMode struct {
+	enabled atomic.Bool
+}
+
+func NewMaintenanceMode(enabled bool) *MaintenanceMode {
+	m := &MaintenanceMode{}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether maintenance mode is on
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Set turns maintenance mode on or off
+func (m *MaintenanceMode) Set(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Middleware rejects POST/PUT/PATCH/DELETE with 503 while maintenance mode is
+// on. Admin routes are exempt so the switch can be turned back off.
+func (m *MaintenanceMode) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.Enabled() || strings.HasPrefix(c.Request.URL.Path, "/admin/") {
+			c.Next()
+			return
+		}
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", maintenanceRetryAfter)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error":       apierror.New(apierror.Unavailable, "HireIQ is undergoing maintenance and is read-only for a few minutes. Please try again shortly."),
+			"maintenance": true,
+		})
+	}
+}
+
+// RequireAdminToken guards operator endpoints with a shared secret sent as
+// "Authorization: Bearer <token>". An empty token rejects every request.
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Invalid admin token")
+			return
+		}
+		c.Next()
+	}
+}
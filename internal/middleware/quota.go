@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// contextKeyAIQuota holds the request's *AIQuota once RequireAIQuota has
+// reserved its call
+const contextKeyAIQuota = "ai_quota"
+
+// AIQuota lets a handler that fans out to several AI calls charge the ones
+// beyond the call RequireAIQuota already reserved against the same daily quota
+type AIQuota struct {
+	repo     *repository.AIUsageRepo
+	userID   uuid.UUID
+	category string
+	limit    int
+}
+
+// Reserve counts one more call, returning false once the quota is used up.
+// A nil AIQuota (route without RequireAIQuota) allows every call, and so does
+// a counter failure, matching RequireAIQuota.
+func (q *AIQuota) Reserve(ctx context.Context) bool {
+	if q == nil {
+		return true
+	}
+	ok, err := q.repo.Reserve(ctx, q.userID, q.category, q.limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to reserve AI quota")
+		return true
+	}
+	return ok
+}
+
+// Refund gives back a call taken with Reserve whose AI call failed
+func (q *AIQuota) Refund(ctx context.Context) {
+	if q == nil {
+		return
+	}
+	if err := q.repo.Refund(context.WithoutCancel(ctx), q.userID, q.category); err != nil {
+		log.Error().Err(err).Msg("Failed to refund AI usage")
+	}
+}
+
+// GetAIQuota returns the quota RequireAIQuota enforced for this request, or
+// nil if the route has none
+func GetAIQuota(c *gin.Context) *AIQuota {
+	q, _ := c.Get(contextKeyAIQuota)
+	quota, _ := q.(*AIQuota)
+	return quota
+}
+
+// RequireAIQuota returns middleware that enforces the plan's daily AI quota
+// for a category. A call is reserved atomically before the handler runs and
+// refunded if the handler fails, so only successful calls count and
+// concurrent requests can't push usage past the limit. Returns 429 with the
+// quota once it's used up. Place after RequirePlan so the plan is known.
+func RequireAIQuota(category string, usageRepo *repository.AIUsageRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := uuid.Parse(GetUserID(c))
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
+			return
+		}
+
+		limit := model.AIDailyLimit(GetPlan(c), category)
+		reserved, err := usageRepo.Reserve(c.Request.Context(), userID, category, limit)
+		if err != nil {
+			// Don't block AI features on a counter failure
+			log.Error().Err(err).Msg("Failed to reserve AI quota")
+			c.Next()
+			return
+		}
+
+		if !reserved {
+			// Report the stored count; after a downgrade it can exceed the limit
+			used, err := usageRepo.GetUsed(c.Request.Context(), userID, category)
+			if err != nil {
+				used = limit
+			}
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":    apierror.New(apierror.AIQuotaExceeded, "You've used today's AI limit for this feature. It resets at midnight UTC."),
+				"category": category,
+				"quota": model.AIQuota{
+					Used:     used,
+					Limit:    limit,
+					ResetsAt: repository.NextQuotaReset(time.Now()),
+				},
+			})
+			return
+		}
+
+		c.Set(contextKeyAIQuota, &AIQuota{repo: usageRepo, userID: userID, category: category, limit: limit})
+		c.Next()
+
+		// Only successful calls count against the quota
+		if c.Writer.Status() >= http.StatusBadRequest {
+			if err := usageRepo.Refund(context.WithoutCancel(c.Request.Context()), userID, category); err != nil {
+				log.Error().Err(err).Msg("Failed to refund AI usage")
+			}
+		}
+	}
+}
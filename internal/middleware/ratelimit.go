@@ -1,58 +1,102 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
 	"golang.org/x/time/rate"
 )
 
-// RateLimiter implements per-user rate limiting
+// RateLimiter implements per-user rate limiting. Each limiter is an
+// independent named bucket, so routes can carry a stricter limiter on top of
+// the global one (e.g. the AI endpoints).
 type RateLimiter struct {
-	limiters map[string]*rate.Limiter
-	mu       sync.RWMutex
-	rps      rate.Limit
-	burst    int
+	name        string
+	limiters    map[string]*limiterEntry
+	mu          sync.Mutex
+	rps         rate.Limit
+	burst       int
+	scaleByPlan bool
 }
 
-// NewRateLimiter creates a rate limiter with the given requests per second
+// limiterEntry pairs a bucket with when it was last used, for idle eviction
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+const (
+	// limiterIdleTTL is how long a bucket can go unused before it's evicted.
+	// An idle bucket has long since refilled, so dropping it changes nothing.
+	limiterIdleTTL       = 10 * time.Minute
+	limiterSweepInterval = 5 * time.Minute
+)
+
+// NewRateLimiter creates the global rate limiter with the given requests per second
 func NewRateLimiter(rps int) *RateLimiter {
+	return newRateLimiter("default", rate.Limit(rps), rps*2, false)
+}
+
+// NewBucketRateLimiter creates a named bucket allowing perMinute requests per
+// minute per user. With scaleByPlan the allowance is multiplied by plan level
+// + 1 (free 1x, pro 2x, pro_plus 3x), read from the plan RequirePlan puts in
+// context — so place it after RequirePlan on the route.
+func NewBucketRateLimiter(name string, perMinute int, scaleByPlan bool) *RateLimiter {
+	return newRateLimiter(name, rate.Limit(float64(perMinute)/60), perMinute, scaleByPlan)
+}
+
+func newRateLimiter(name string, rps rate.Limit, burst int, scaleByPlan bool) *RateLimiter {
 	rl := &RateLimiter{
-		limiters: make(map[string]*rate.Limiter),
-		rps:      rate.Limit(rps),
-		burst:    rps * 2,
+		name:        name,
+		limiters:    make(map[string]*limiterEntry),
+		rps:         rps,
+		burst:       burst,
+		scaleByPlan: scaleByPlan,
 	}
 
-	// Clean up old limiters every 5 minutes
+	// Periodically evict idle limiters. Active buckets are kept so a throttled
+	// user doesn't get a fresh allowance on a fixed schedule.
 	go func() {
 		for {
-			time.Sleep(5 * time.Minute)
-			rl.mu.Lock()
-			rl.limiters = make(map[string]*rate.Limiter)
-			rl.mu.Unlock()
+			time.Sleep(limiterSweepInterval)
+			rl.evictIdle(time.Now())
 		}
 	}()
 
 	return rl
 }
 
-func (rl *RateLimiter) getLimiter(key string) *rate.Limiter {
-	rl.mu.RLock()
-	limiter, exists := rl.limiters[key]
-	rl.mu.RUnlock()
+// evictIdle drops limiters that haven't been used within limiterIdleTTL
+func (rl *RateLimiter) evictIdle(now time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
 
-	if exists {
-		return limiter
+	for key, entry := range rl.limiters {
+		if now.Sub(entry.lastSeen) > limiterIdleTTL {
+			delete(rl.limiters, key)
+		}
 	}
+}
 
+func (rl *RateLimiter) getLimiter(key string, multiplier int) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	limiter = rate.NewLimiter(rl.rps, rl.burst)
-	rl.limiters[key] = limiter
-	return limiter
+	entry, exists := rl.limiters[key]
+	if !exists {
+		entry = &limiterEntry{
+			limiter: rate.NewLimiter(rl.rps*rate.Limit(multiplier), rl.burst*multiplier),
+		}
+		rl.limiters[key] = entry
+	}
+	entry.lastSeen = time.Now()
+	return entry.limiter
 }
 
 // Limit is the Gin middleware handler
@@ -64,9 +108,21 @@ func (rl *RateLimiter) Limit() gin.HandlerFunc {
 			key = c.ClientIP()
 		}
 
-		if !rl.getLimiter(key).Allow() {
+		// Plan is part of the key so an upgrade gets a fresh, larger bucket
+		multiplier := 1
+		if rl.scaleByPlan {
+			plan := GetPlan(c)
+			multiplier = model.PlanLevel(plan) + 1
+			key = plan + ":" + key
+		}
+
+		limiter := rl.getLimiter(key, multiplier)
+		if !limiter.Allow() {
+			retryAfter := int(math.Ceil(1 / float64(limiter.Limit())))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "Rate limit exceeded. Please try again shortly.",
+				"error":  apierror.New(apierror.RateLimited, "Rate limit exceeded. Please try again shortly."),
+				"bucket": rl.name,
 			})
 			return
 		}
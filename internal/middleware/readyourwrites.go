@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// ReadYourWrites keeps a user's reads on the primary database after any
+// request that may write (anything but GET, HEAD and OPTIONS), so the list
+// they load next includes the change even while the read replica lags.
+// Place after the user ID is resolved.
+func ReadYourWrites(reads *repository.ReadPool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		userID, err := uuid.Parse(GetUserID(c))
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		reads.NoteWrite(userID)
+		c.Next()
+		// Again once the write has committed, in case the handler ran long
+		reads.NoteWrite(userID)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/hireiq-api/internal/requestid"
+)
+
+// ContextKeyRequestID holds the request's correlation ID in the gin context
+const ContextKeyRequestID = "requestId"
+
+// maxRequestIDLen bounds client-supplied IDs so they can't bloat log lines
+const maxRequestIDLen = 128
+
+// RequestID reuses the caller's X-Request-ID (if it's short and printable) or
+// generates one, stores it in the gin and request contexts, and echoes it in
+// the response header.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(ContextKeyRequestID, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the request's correlation ID, or "" outside RequestID
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(ContextKeyRequestID)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/hireiq-api/internal/apierror"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// RequireScope returns middleware that checks an API token was granted the
+// given scope. Returns 403 with the missing scope if not.
+//
+// Firebase sessions are interactive and always have full access, so the check
+// only applies to hiq_ token requests. Any write scope implies read.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasScope(c, scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":         apierror.New(apierror.InsufficientScope, "This API token lacks the scope this endpoint requires"),
+				"requiredScope": scope,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSession rejects API token requests outright. Used for account-level
+// routes (profile, billing, token management) that scripts shouldn't touch.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsAPITokenRequest(c) {
+			apierror.Abort(c, http.StatusForbidden, apierror.Forbidden, "This endpoint requires a signed-in session, not an API token")
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasScope reports whether the request may act with the given scope.
+// Always true for Firebase sessions.
+func HasScope(c *gin.Context, scope string) bool {
+	if !IsAPITokenRequest(c) {
+		return true
+	}
+	return hasScope(GetScopes(c), scope)
+}
+
+// GetScopes returns the scopes granted to the request's API token
+func GetScopes(c *gin.Context) []string {
+	v, _ := c.Get(ContextKeyScopes)
+	if s, ok := v.([]string); ok {
+		return s
+	}
+	return nil
+}
+
+func hasScope(granted []string, required string) bool {
+	for _, s := range granted {
+		if s == required {
+			return true
+		}
+		if required == model.ScopeRead && model.ValidScope(s) {
+			return true
+		}
+	}
+	return false
+}
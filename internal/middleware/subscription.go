@@ -6,10 +6,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/apierror"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
 )
 
+// ContextKeyPlan holds the user's effective plan once RequirePlan has run
+const ContextKeyPlan = "plan"
+
 // RequirePlan returns middleware that checks whether the user's subscription
 // meets the minimum plan level. Returns 402 if the user's plan is insufficient.
 //
@@ -20,32 +24,31 @@ func RequirePlan(minPlan string, subRepo *repository.SubscriptionRepo) gin.Handl
 	return func(c *gin.Context) {
 		userIDStr := GetUserID(c)
 		if userIDStr == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID"})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthenticated, "Invalid user ID")
 			return
 		}
 
 		sub, err := subRepo.FindByUserID(c.Request.Context(), userID)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to check subscription")
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check subscription"})
+			apierror.Abort(c, http.StatusInternalServerError, apierror.Internal, "Failed to check subscription")
 			return
 		}
 
 		// Determine user's current plan
-		userPlan := model.PlanFree
-		if sub != nil && (sub.Status == model.SubStatusActive || sub.Status == model.SubStatusTrialing) {
-			userPlan = sub.Plan
-		}
+		userPlan := model.EffectivePlan(sub)
+
+		c.Set(ContextKeyPlan, userPlan)
 
 		if model.PlanLevel(userPlan) < minLevel {
 			c.AbortWithStatusJSON(http.StatusPaymentRequired, gin.H{
-				"error":        "upgrade_required",
+				"error":        apierror.New(apierror.UpgradeRequired, "Upgrade your plan to use this feature"),
 				"requiredPlan": minPlan,
 				"currentPlan":  userPlan,
 			})
@@ -55,3 +58,13 @@ func RequirePlan(minPlan string, subRepo *repository.SubscriptionRepo) gin.Handl
 		c.Next()
 	}
 }
+
+// GetPlan returns the plan RequirePlan resolved, or free if it hasn't run
+func GetPlan(c *gin.Context) string {
+	if plan, ok := c.Get(ContextKeyPlan); ok {
+		if s, ok := plan.(string); ok && s != "" {
+			return s
+		}
+	}
+	return model.PlanFree
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// verifiedToken is what Authenticate needs from a verified Firebase ID token
+type verifiedToken struct {
+	uid           string
+	email         string
+	emailVerified bool
+	expiresAt     time.Time
+}
+
+// tokenCache remembers recently verified ID tokens by their SHA-256 until
+// they expire, so a client firing a burst of requests with the same token
+// pays for signature verification once. Only tokens that verified are kept.
+type tokenCache struct {
+	max int
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]verifiedToken
+}
+
+// newTokenCache returns a cache holding at most max tokens, or nil (caching
+// off) if max isn't positive
+func newTokenCache(max int) *tokenCache {
+	if max <= 0 {
+		return nil
+	}
+	return &tokenCache{max: max, entries: make(map[[sha256.Size]byte]verifiedToken)}
+}
+
+func (tc *tokenCache) get(raw string) (verifiedToken, bool) {
+	if tc == nil {
+		return verifiedToken{}, false
+	}
+	key := sha256.Sum256([]byte(raw))
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	v, ok := tc.entries[key]
+	if !ok {
+		return verifiedToken{}, false
+	}
+	if !time.Now().Before(v.expiresAt) {
+		delete(tc.entries, key)
+		return verifiedToken{}, false
+	}
+	return v, true
+}
+
+// put caches a verified token. When full, one arbitrary entry is dropped to
+// make room: Go randomizes map iteration order, so this is random eviction
+// in constant time. Expired entries need no sweep; get drops them, and
+// random eviction finds them as often as live ones.
+func (tc *tokenCache) put(raw string, v verifiedToken) {
+	if tc == nil || !time.Now().Before(v.expiresAt) {
+		return
+	}
+	key := sha256.Sum256([]byte(raw))
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if _, ok := tc.entries[key]; !ok && len(tc.entries) >= tc.max {
+		for k := range tc.entries {
+			delete(tc.entries, k)
+			break
+		}
+	}
+	tc.entries[key] = v
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultCompanyColor is the jobs.company_color column default, used when no
+// brand color is known
+const DefaultCompanyColor = "#4f46e5"
+
+// companySuffixes are legal-entity words dropped when comparing company names
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "company": true,
+	"plc": true, "gmbh": true, "ag": true, "sa": true, "lp": true, "llp": true,
+}
+
+// NormalizeCompanyName reduces a company name to a comparison key: lowercase,
+// punctuation as spaces, legal suffixes ("Inc", "LLC", ...) and a leading "The"
+// removed. "The Acme Co., Inc." and "acme" both normalize to "acme".
+func NormalizeCompanyName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	words := strings.Fields(cleaned)
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
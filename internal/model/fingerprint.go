@@ -0,0 +1,30 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ScoringFingerprint hashes the profile fields feed match scores depend on
+// (target roles and companies, skills, work style, location, salary range and
+// currency). Two profiles with the same fingerprint score every feed job the
+// same, so edits that leave it unchanged (bio, education, links) don't need
+// a rescore.
+func (u *User) ScoringFingerprint() string {
+	data, _ := json.Marshal(struct {
+		TargetRoles     []string
+		TargetCompanies []string
+		Skills          []string
+		WorkStyle       string
+		Location        string
+		SalaryMin       int
+		SalaryMax       int
+		SalaryCurrency  string
+	}{
+		u.TargetRoles, u.TargetCompanies, u.Skills, u.WorkStyle, u.Location,
+		u.SalaryMin, u.SalaryMax, u.SalaryCurrency,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
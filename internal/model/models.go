@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -49,51 +50,76 @@ type Volunteer struct {
 
 // User represents a HireIQ user profile
 type User struct {
-	ID             uuid.UUID       `json:"id"`
-	FirebaseUID    string          `json:"-"`
-	Email          string          `json:"email"`
-	Name           string          `json:"name"`
-	Bio            string          `json:"bio"`
-	Location       string          `json:"location"`
-	WorkStyle      string          `json:"workStyle"`
-	SalaryMin      int             `json:"salaryMin"`
-	SalaryMax      int             `json:"salaryMax"`
-	Skills         []string        `json:"skills"`
-	TargetRoles    []string        `json:"targetRoles"`
-	GithubURL      string          `json:"githubUrl"`
-	Experience     []Experience    `json:"experience"`
-	Education      []Education     `json:"education"`
-	Certifications []Certification `json:"certifications"`
-	Languages      []Language      `json:"languages"`
-	Volunteer      []Volunteer     `json:"volunteer"`
-	CreatedAt      time.Time       `json:"createdAt"`
-	UpdatedAt      time.Time       `json:"updatedAt"`
+	ID                 uuid.UUID       `json:"id"`
+	FirebaseUID        string          `json:"-"`
+	Email              string          `json:"email"`
+	Name               string          `json:"name"`
+	Bio                string          `json:"bio"`
+	Location           string          `json:"location"`
+	WorkStyle          string          `json:"workStyle"`
+	SalaryMin          int             `json:"salaryMin"`
+	SalaryMax          int             `json:"salaryMax"`
+	SalaryCurrency     string          `json:"salaryCurrency"` // currency of SalaryMin/Max (ISO 4217)
+	Skills             []string        `json:"skills"`
+	TargetRoles        []string        `json:"targetRoles"`
+	TargetCompanies    []string        `json:"targetCompanies"`    // employers whose feed jobs get a score boost
+	RemotiveCategories []string        `json:"remotiveCategories"` // Remotive category slugs searched on every refresh
+	GithubURL          string          `json:"githubUrl"`
+	Experience         []Experience    `json:"experience"`
+	Education          []Education     `json:"education"`
+	Certifications     []Certification `json:"certifications"`
+	Languages          []Language      `json:"languages"`
+	Volunteer          []Volunteer     `json:"volunteer"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	UpdatedAt          time.Time       `json:"updatedAt"`
 }
 
 // Job represents a saved/tracked job listing
 type Job struct {
-	ID              uuid.UUID  `json:"id"`
-	UserID          uuid.UUID  `json:"userId"`
-	ExternalID      string     `json:"externalId,omitempty"`
-	Source          string     `json:"source"`
-	Title           string     `json:"title"`
-	Company         string     `json:"company"`
-	Location        string     `json:"location"`
-	SalaryRange     string     `json:"salaryRange"`
-	JobType         string     `json:"jobType"`
-	Description     string     `json:"description"`
-	Tags            []string   `json:"tags"`
-	RequiredSkills  []string   `json:"requiredSkills"`
-	PreferredSkills []string   `json:"preferredSkills"`
-	ApplyURL        string     `json:"applyUrl,omitempty"`
-	HiringEmail     string     `json:"hiringEmail,omitempty"`
-	CompanyLogo     string     `json:"companyLogo,omitempty"`
-	CompanyColor    string     `json:"companyColor,omitempty"`
-	MatchScore      int        `json:"matchScore"`
-	Bookmarked      bool       `json:"bookmarked"`
-	Status          string     `json:"status"`
-	CreatedAt       time.Time  `json:"createdAt"`
-	UpdatedAt       time.Time  `json:"updatedAt"`
+	ID                uuid.UUID  `json:"id"`
+	UserID            uuid.UUID  `json:"userId"`
+	ExternalID        string     `json:"externalId,omitempty"`
+	Source            string     `json:"source"`
+	Title             string     `json:"title"`
+	Company           string     `json:"company"`
+	Location          string     `json:"location"`
+	SalaryRange       string     `json:"salaryRange"`
+	JobType           string     `json:"jobType"`
+	Description       string     `json:"description"`
+	Tags              []string   `json:"tags"`
+	RequiredSkills    []string   `json:"requiredSkills"`
+	PreferredSkills   []string   `json:"preferredSkills"`
+	ApplyURL          string     `json:"applyUrl,omitempty"`
+	HiringEmail       string     `json:"hiringEmail,omitempty"`
+	CompanyLogo       string     `json:"companyLogo,omitempty"`
+	CompanyColor      string     `json:"companyColor,omitempty"`
+	MatchScore        int        `json:"matchScore"`
+	Bookmarked        bool       `json:"bookmarked"`
+	Status            string     `json:"status"`
+	AppliedExternally bool       `json:"appliedExternally"` // applied outside HireIQ; counts as applied even while status is "saved"
+	ArchivedAt        *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// JobPatch is a sparse job update: nil fields are left as they are, so a
+// client can change one field without resending the rest of the job
+type JobPatch struct {
+	Title             *string   `json:"title"`
+	Company           *string   `json:"company"`
+	Location          *string   `json:"location"`
+	SalaryRange       *string   `json:"salaryRange"`
+	JobType           *string   `json:"jobType"`
+	Description       *string   `json:"description"`
+	Tags              *[]string `json:"tags"`
+	RequiredSkills    *[]string `json:"requiredSkills"`
+	PreferredSkills   *[]string `json:"preferredSkills"`
+	ApplyURL          *string   `json:"applyUrl"`
+	HiringEmail       *string   `json:"hiringEmail"`
+	MatchScore        *int      `json:"matchScore"`
+	Bookmarked        *bool     `json:"bookmarked"`
+	Status            *string   `json:"status"`
+	AppliedExternally *bool     `json:"appliedExternally"`
 }
 
 // Application represents a job application pipeline entry
@@ -107,6 +133,7 @@ type Application struct {
 	FollowUpDate   *time.Time `json:"followUpDate,omitempty"`
 	FollowUpType   string     `json:"followUpType,omitempty"`
 	FollowUpUrgent bool       `json:"followUpUrgent"`
+	Outcome        string     `json:"outcome,omitempty"` // set once the application is closed out
 	CreatedAt      time.Time  `json:"createdAt"`
 	UpdatedAt      time.Time  `json:"updatedAt"`
 
@@ -134,16 +161,108 @@ func ValidStatus(s string) bool {
 	return false
 }
 
+// Application outcomes record how an application ended, beyond its pipeline
+// stage: "ghosted" (no response) and "rejected" both end an applied
+// application, but mean different things to a job seeker.
+const (
+	OutcomeRejected      = "rejected"
+	OutcomeGhosted       = "ghosted"
+	OutcomeWithdrawn     = "withdrawn"
+	OutcomeAccepted      = "accepted"
+	OutcomeDeclinedOffer = "declined_offer"
+)
+
+// ValidOutcome reports whether o is a known outcome; "" (still open) is valid
+func ValidOutcome(o string) bool {
+	switch o {
+	case "", OutcomeRejected, OutcomeGhosted, OutcomeWithdrawn,
+		OutcomeAccepted, OutcomeDeclinedOffer:
+		return true
+	}
+	return false
+}
+
 // StatusHistory tracks application stage changes for timeline
 type StatusHistory struct {
 	ID            uuid.UUID  `json:"id"`
 	ApplicationID uuid.UUID  `json:"applicationId"`
 	FromStatus    string     `json:"fromStatus"`
 	ToStatus      string     `json:"toStatus"`
+	Outcome       string     `json:"outcome,omitempty"`
 	ChangedAt     time.Time  `json:"changedAt"`
 	Note          string     `json:"note,omitempty"`
 }
 
+// ApplicationProgress is how far one application got, for funnel metrics
+type ApplicationProgress struct {
+	Status       string
+	Outcome      string
+	HasAppliedAt bool
+	Reached      []string // every to_status in its history
+}
+
+// FunnelMetrics summarizes a user's pipeline. Rates are percentages of
+// applications that were actually submitted (reached "applied" or later).
+type FunnelMetrics struct {
+	Total         int            `json:"total"`
+	Applied       int            `json:"applied"`
+	Responded     int            `json:"responded"`  // reached screening+, or were rejected
+	Interviewed   int            `json:"interviewed"`
+	Offers        int            `json:"offers"`
+	ResponseRate  int            `json:"responseRate"`
+	InterviewRate int            `json:"interviewRate"`
+	OfferRate     int            `json:"offerRate"`
+	ByStatus      map[string]int `json:"byStatus"`
+	ByOutcome     map[string]int `json:"byOutcome"`
+}
+
+// DueFollowUp is an application whose follow-up date has arrived, joined with
+// the job and user details needed to send a reminder
+type DueFollowUp struct {
+	ApplicationID  uuid.UUID
+	UserID         uuid.UUID
+	UserEmail      string
+	UserName       string
+	JobID          uuid.UUID
+	JobTitle       string
+	Company        string
+	Status         string
+	NextStep       string
+	FollowUpDate   time.Time
+	FollowUpType   string
+	FollowUpUrgent bool
+}
+
+// StatusChangeEvent is published after an application moves between stages
+type StatusChangeEvent struct {
+	UserID        uuid.UUID
+	ApplicationID uuid.UUID
+	JobID         uuid.UUID
+	FromStatus    string // empty when the application was just created
+	ToStatus      string
+	Outcome       string // empty while the application is open
+	Note          string
+	ChangedAt     time.Time
+}
+
+// Notification is an in-app message for the user (e.g. "moved to interview")
+type Notification struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"userId"`
+	Type          string     `json:"type"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	ApplicationID *uuid.UUID `json:"applicationId,omitempty"`
+	JobID         *uuid.UUID `json:"jobId,omitempty"`
+	ReadAt        *time.Time `json:"readAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// Notification types
+const (
+	NotificationApplicationStatus = "application_status"
+)
+
 // Note represents a per-job note
 type Note struct {
 	ID        uuid.UUID `json:"id"`
@@ -153,6 +272,21 @@ type Note struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+// Timeline entry types
+const (
+	TimelineStatusChange = "status_change"
+	TimelineNote         = "note"
+)
+
+// TimelineEntry is one item in a job's activity timeline: a status change or
+// a note, whichever Type says is set
+type TimelineEntry struct {
+	Type   string         `json:"type"`
+	At     time.Time      `json:"at"`
+	Status *StatusHistory `json:"status,omitempty"`
+	Note   *Note          `json:"note,omitempty"`
+}
+
 // Contact represents a networking contact
 type Contact struct {
 	ID           uuid.UUID       `json:"id"`
@@ -166,6 +300,7 @@ type Contact struct {
 	Tip          string          `json:"tip"`
 	Enriched     bool            `json:"enriched"`
 	EnrichedData *map[string]any `json:"enrichedData,omitempty"`
+	JobID        *uuid.UUID      `json:"jobId,omitempty"` // set when auto-created from a job posting
 	CreatedAt    time.Time       `json:"createdAt"`
 	UpdatedAt    time.Time       `json:"updatedAt"`
 }
@@ -192,6 +327,13 @@ type CompetitiveSnapshot struct {
 	CreatedAt      time.Time `json:"createdAt"`
 }
 
+// Competitive snapshot trends, comparing applicant counts day over day
+const (
+	TrendRising  = "rising"
+	TrendFalling = "falling"
+	TrendSteady  = "steady"
+)
+
 // FeedJob represents a cached job listing from external APIs
 type FeedJob struct {
 	ID             uuid.UUID  `json:"id"`
@@ -203,6 +345,7 @@ type FeedJob struct {
 	SalaryMin      int        `json:"salaryMin"`
 	SalaryMax      int        `json:"salaryMax"`
 	SalaryText     string     `json:"salaryText"`
+	SalaryCurrency string     `json:"salaryCurrency"` // ISO 4217, e.g. USD, GBP
 	JobType        string     `json:"jobType"`
 	Description    string     `json:"description"`
 	RequiredSkills []string   `json:"requiredSkills"`
@@ -216,6 +359,36 @@ type FeedJob struct {
 	Dismissed      bool       `json:"dismissed"`
 	Saved          bool       `json:"saved"`
 	SavedJobID     *uuid.UUID `json:"savedJobId,omitempty"`
+	AlertID        *uuid.UUID `json:"alertId,omitempty"` // job alert whose query surfaced it, if any
+}
+
+// Per-item outcomes for a bulk feed save
+const (
+	FeedSaveSaved        = "saved"
+	FeedSaveAlreadySaved = "already_saved"
+	FeedSaveNotFound     = "not_found"
+)
+
+// FeedSaveResult is the outcome of saving one feed job in a bulk save
+type FeedSaveResult struct {
+	FeedJobID uuid.UUID `json:"feedJobId"`
+	Status    string    `json:"status"`
+	Job       *Job      `json:"job,omitempty"`
+}
+
+// Per-item outcomes for a bulk "mark applied" from the feed
+const (
+	FeedApplyApplied        = "applied"
+	FeedApplyAlreadyApplied = "already_applied" // the job already had an application; left unchanged
+	FeedApplyNotFound       = "not_found"
+)
+
+// FeedApplyResult is the outcome of marking one feed job applied
+type FeedApplyResult struct {
+	FeedJobID   uuid.UUID    `json:"feedJobId"`
+	Status      string       `json:"status"`
+	Job         *Job         `json:"job,omitempty"`
+	Application *Application `json:"application,omitempty"`
 }
 
 // UserFeed links a user to a feed job with personalized data
@@ -230,6 +403,119 @@ type UserFeed struct {
 	CreatedAt  time.Time  `json:"createdAt"`
 }
 
+// FeedRefreshLog records a completed feed refresh for a user
+type FeedRefreshLog struct {
+	ID          uuid.UUID       `json:"id"`
+	UserID      uuid.UUID       `json:"userId"`
+	QueryUsed   string          `json:"queryUsed"`
+	JobsFetched int             `json:"jobsFetched"`
+	JobsNew     int             `json:"jobsNew"`
+	Sources     json.RawMessage `json:"sources,omitempty"` // per-source results; null for refreshes logged before 020
+	RefreshedAt time.Time       `json:"refreshedAt"`
+}
+
+// JobAlert is a saved search the feed refresh runs for the user alongside the
+// queries built from their profile
+type JobAlert struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Name      string    `json:"name"`
+	Keywords  string    `json:"keywords"`
+	Location  string    `json:"location"`  // empty = anywhere
+	SalaryMin int       `json:"salaryMin"` // 0 = no floor
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// InterviewQuestion is a question the user was asked while interviewing at a
+// company, optionally tied to the application it came up in
+type InterviewQuestion struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"userId"`
+	ApplicationID *uuid.UUID `json:"applicationId,omitempty"`
+	Company       string     `json:"company"`
+	Question      string     `json:"question"`
+	Category      string     `json:"category,omitempty"`
+	Stage         string     `json:"stage,omitempty"` // e.g. "phone screen", "onsite"
+	Notes         string     `json:"notes,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// Interview question categories; empty means uncategorized
+const (
+	QuestionBehavioral   = "behavioral"
+	QuestionTechnical    = "technical"
+	QuestionCoding       = "coding"
+	QuestionSystemDesign = "system_design"
+	QuestionCulture      = "culture"
+	QuestionOther        = "other"
+)
+
+// ValidQuestionCategory reports whether c is a known category; "" (uncategorized) is valid
+func ValidQuestionCategory(c string) bool {
+	switch c {
+	case "", QuestionBehavioral, QuestionTechnical, QuestionCoding,
+		QuestionSystemDesign, QuestionCulture, QuestionOther:
+		return true
+	}
+	return false
+}
+
+// CompanyQuestion is one distinct question asked at a company, merged across
+// every time the user recorded it
+type CompanyQuestion struct {
+	Question    string              `json:"question"`
+	Category    string              `json:"category,omitempty"`
+	TimesAsked  int                 `json:"timesAsked"`
+	Stages      []string            `json:"stages"`
+	LastAskedAt time.Time           `json:"lastAskedAt"`
+	Entries     []InterviewQuestion `json:"entries"`
+}
+
+// SkillDemand is how many of a user's feed jobs ask for a skill
+type SkillDemand struct {
+	Skill string `json:"skill"`
+	Jobs  int    `json:"jobs"`
+	Have  bool   `json:"have"` // on the user's profile
+}
+
+// SalaryDistribution summarizes feed job salaries in one currency. Each job
+// contributes the midpoint of its range (or its one bound).
+type SalaryDistribution struct {
+	Currency string `json:"currency"`
+	Jobs     int    `json:"jobs"`
+	Min      int    `json:"min"`
+	P25      int    `json:"p25"`
+	Median   int    `json:"median"`
+	P75      int    `json:"p75"`
+	P90      int    `json:"p90"`
+	Max      int    `json:"max"`
+}
+
+// JobExport is one job with everything tracked against it, as returned by
+// GET /jobs/:id/export
+type JobExport struct {
+	ExportedAt  time.Time       `json:"exportedAt"`
+	Job         *Job            `json:"job"`
+	Application *Application    `json:"application"` // null if never tracked
+	History     []StatusHistory `json:"history"`
+	Notes       []Note          `json:"notes"`
+	Contacts    []Contact       `json:"contacts"` // contacts linked to the job
+}
+
+// AccountExport is everything stored for a user, as returned by
+// GET /profile/export for data portability
+type AccountExport struct {
+	ExportedAt         time.Time           `json:"exportedAt"`
+	Profile            *User               `json:"profile"`
+	Jobs               []Job               `json:"jobs"` // active and archived
+	Applications       []Application       `json:"applications"`
+	History            []StatusHistory     `json:"history"` // every application's status changes
+	Notes              []Note              `json:"notes"`
+	Contacts           []Contact           `json:"contacts"`
+	InterviewQuestions []InterviewQuestion `json:"interviewQuestions"`
+}
+
 // DashboardSummary is the aggregated response for the home tab
 type DashboardSummary struct {
 	PipelineCounts  map[string]int   `json:"pipelineCounts"`
@@ -324,6 +610,15 @@ func PlanLevel(plan string) int {
 	}
 }
 
+// EffectivePlan returns the plan a subscription currently grants.
+// Missing, canceled, or past-due subscriptions fall back to free.
+func EffectivePlan(sub *Subscription) string {
+	if sub != nil && (sub.Status == SubStatusActive || sub.Status == SubStatusTrialing) {
+		return sub.Plan
+	}
+	return PlanFree
+}
+
 // PaymentEvent stores a webhook event for audit
 type PaymentEvent struct {
 	ID               uuid.UUID `json:"id"`
@@ -334,3 +629,110 @@ type PaymentEvent struct {
 	Processed        bool      `json:"processed"`
 	CreatedAt        time.Time `json:"createdAt"`
 }
+
+// APIToken is a personal access token for programmatic API use.
+// Only the hash is stored; the plaintext token is returned once on creation.
+type APIToken struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"userId"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	TokenHash   string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// API token scope constants
+const (
+	ScopeRead          = "read"
+	ScopeJobsWrite     = "jobs:write"
+	ScopeContactsWrite = "contacts:write"
+)
+
+func ValidScope(s string) bool {
+	switch s {
+	case ScopeRead, ScopeJobsWrite, ScopeContactsWrite:
+		return true
+	}
+	return false
+}
+
+// AI usage categories — daily quotas are tracked per category
+const (
+	AICategoryParse        = "parse"
+	AICategoryCompare      = "compare"
+	AICategoryResume       = "resume"
+	AICategoryCompanyIntel = "company_intel"
+	AICategorySkillGap     = "skill_gap"
+	AICategoryCoverLetter  = "cover_letter"
+	AICategoryInterview    = "interview_prep"
+)
+
+// AICategories lists every quota category, in display order
+var AICategories = []string{AICategoryParse, AICategoryCompare, AICategoryResume, AICategoryCompanyIntel, AICategorySkillGap, AICategoryCoverLetter, AICategoryInterview}
+
+// AIDailyLimit returns how many AI calls of a category a plan allows per UTC day
+func AIDailyLimit(plan, category string) int {
+	limits := map[string]int{
+		AICategoryParse:        50,
+		AICategoryCompare:      20,
+		AICategoryResume:       50,
+		AICategoryCompanyIntel: 50,
+		AICategorySkillGap:     20,
+		AICategoryCoverLetter:  10,
+		AICategoryInterview:    20,
+	}
+	switch plan {
+	case PlanProPlus:
+		return limits[category] * 4
+	case PlanPro:
+		return limits[category]
+	default:
+		return 0
+	}
+}
+
+// AIQuota is the current user's usage against one category's daily limit
+type AIQuota struct {
+	Used     int       `json:"used"`
+	Limit    int       `json:"limit"`
+	ResetsAt time.Time `json:"resetsAt"`
+}
+
+// SharedJob is the read-only view of a job served from a share link.
+// It deliberately omits the owner, their pipeline state, and recruiter contact info.
+type SharedJob struct {
+	Title           string   `json:"title"`
+	Company         string   `json:"company"`
+	Location        string   `json:"location"`
+	SalaryRange     string   `json:"salaryRange"`
+	JobType         string   `json:"jobType"`
+	Description     string   `json:"description"`
+	Tags            []string `json:"tags"`
+	RequiredSkills  []string `json:"requiredSkills"`
+	PreferredSkills []string `json:"preferredSkills"`
+	ApplyURL        string   `json:"applyUrl,omitempty"`
+	CompanyLogo     string   `json:"companyLogo,omitempty"`
+	CompanyColor    string   `json:"companyColor,omitempty"`
+}
+
+// NewSharedJob strips a job down to its shareable fields
+func NewSharedJob(j *Job) SharedJob {
+	return SharedJob{
+		Title:           j.Title,
+		Company:         j.Company,
+		Location:        j.Location,
+		SalaryRange:     j.SalaryRange,
+		JobType:         j.JobType,
+		Description:     j.Description,
+		Tags:            j.Tags,
+		RequiredSkills:  j.RequiredSkills,
+		PreferredSkills: j.PreferredSkills,
+		ApplyURL:        j.ApplyURL,
+		CompanyLogo:     j.CompanyLogo,
+		CompanyColor:    j.CompanyColor,
+	}
+}
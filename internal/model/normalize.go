@@ -0,0 +1,25 @@
+package model
+
+import "strings"
+
+// NormalizeStringList cleans a user-entered list such as skills or target
+// roles: whitespace is trimmed and collapsed, empties are dropped, and
+// case-insensitive duplicates are removed keeping the first spelling.
+// Always returns a non-nil slice so it stores as '{}' rather than NULL.
+func NormalizeStringList(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.Join(strings.Fields(v), " ")
+		if v == "" {
+			continue
+		}
+		key := strings.ToLower(v)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AIUsageRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAIUsageRepo(pool *pgxpool.Pool) *AIUsageRepo {
+	return &AIUsageRepo{pool: pool}
+}
+
+// Reserve atomically counts one AI call in today's (UTC) bucket if the user
+// is still under limit. Returns false, without counting, once the limit is
+// reached — so concurrent requests can't overshoot it between a check and an
+// increment. Pair with Refund when the call fails.
+func (r *AIUsageRepo) Reserve(ctx context.Context, userID uuid.UUID, category string, limit int) (bool, error) {
+	if limit <= 0 {
+		return false, nil
+	}
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO ai_usage (user_id, category, usage_date, count)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (user_id, category, usage_date) DO UPDATE
+		SET count = ai_usage.count + 1
+		WHERE ai_usage.count < $4
+		RETURNING count
+	`, userID, category, usageDate(time.Now()), limit).Scan(&count)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reserving ai usage: %w", err)
+	}
+	return true, nil
+}
+
+// Refund gives back a call reserved today that didn't succeed
+func (r *AIUsageRepo) Refund(ctx context.Context, userID uuid.UUID, category string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE ai_usage SET count = count - 1
+		WHERE user_id = $1 AND category = $2 AND usage_date = $3 AND count > 0
+	`, userID, category, usageDate(time.Now()))
+	if err != nil {
+		return fmt.Errorf("refunding ai usage: %w", err)
+	}
+	return nil
+}
+
+// GetUsed returns today's count for one category
+func (r *AIUsageRepo) GetUsed(ctx context.Context, userID uuid.UUID, category string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT count FROM ai_usage
+		WHERE user_id = $1 AND category = $2 AND usage_date = $3
+	`, userID, category, usageDate(time.Now())).Scan(&count)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("getting ai usage: %w", err)
+	}
+	return count, nil
+}
+
+// GetDailyUsage returns today's counts for every category the user has used
+func (r *AIUsageRepo) GetDailyUsage(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT category, count FROM ai_usage
+		WHERE user_id = $1 AND usage_date = $2
+	`, userID, usageDate(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("getting daily ai usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[string]int)
+	for rows.Next() {
+		var category string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("scanning ai usage: %w", err)
+		}
+		usage[category] = count
+	}
+	return usage, nil
+}
+
+// usageDate truncates to the UTC calendar day quotas are counted in
+func usageDate(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NextQuotaReset returns the next UTC midnight, when daily AI quotas reset
+func NextQuotaReset(now time.Time) time.Time {
+	return usageDate(now).Add(24 * time.Hour)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+type APITokenRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPITokenRepo(pool *pgxpool.Pool) *APITokenRepo {
+	return &APITokenRepo{pool: pool}
+}
+
+// Create stores a new token (hash only)
+func (r *APITokenRepo) Create(ctx context.Context, t *model.APIToken) (*model.APIToken, error) {
+	var out model.APIToken
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO api_tokens (user_id, name, token_prefix, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, name, token_prefix, token_hash, scopes,
+		          last_used_at, expires_at, revoked_at, created_at
+	`, t.UserID, t.Name, t.TokenPrefix, t.TokenHash, t.Scopes, t.ExpiresAt,
+	).Scan(
+		&out.ID, &out.UserID, &out.Name, &out.TokenPrefix, &out.TokenHash, &out.Scopes,
+		&out.LastUsedAt, &out.ExpiresAt, &out.RevokedAt, &out.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating api token: %w", err)
+	}
+	return &out, nil
+}
+
+// ListByUser returns all of a user's tokens, including revoked ones
+func (r *APITokenRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.APIToken, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, name, token_prefix, token_hash, scopes,
+		       last_used_at, expires_at, revoked_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []model.APIToken
+	for rows.Next() {
+		var t model.APIToken
+		if err := rows.Scan(
+			&t.ID, &t.UserID, &t.Name, &t.TokenPrefix, &t.TokenHash, &t.Scopes,
+			&t.LastUsedAt, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning api token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// Authenticate looks up an active (unrevoked, unexpired) token by hash, stamps
+// last_used_at, and returns it with the owner's Firebase UID. Returns nil if
+// the token is unknown, revoked, or expired.
+func (r *APITokenRepo) Authenticate(ctx context.Context, tokenHash string) (*model.APIToken, string, error) {
+	var t model.APIToken
+	var firebaseUID string
+	err := r.pool.QueryRow(ctx, `
+		UPDATE api_tokens t
+		SET last_used_at = now()
+		FROM users u
+		WHERE t.token_hash = $1
+		  AND t.user_id = u.id
+		  AND t.revoked_at IS NULL
+		  AND (t.expires_at IS NULL OR t.expires_at > now())
+		RETURNING t.id, t.user_id, t.name, t.token_prefix, t.token_hash, t.scopes,
+		          t.last_used_at, t.expires_at, t.revoked_at, t.created_at, u.firebase_uid
+	`, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.Name, &t.TokenPrefix, &t.TokenHash, &t.Scopes,
+		&t.LastUsedAt, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt, &firebaseUID,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("authenticating api token: %w", err)
+	}
+	return &t, firebaseUID, nil
+}
+
+// Revoke marks a token as revoked. Returns false if no active token matched.
+func (r *APITokenRepo) Revoke(ctx context.Context, tokenID, userID uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE api_tokens SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, tokenID, userID)
+	if err != nil {
+		return false, fmt.Errorf("revoking api token: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -24,13 +24,13 @@ func (r *ApplicationRepo) FindByJobID(ctx context.Context, userID, jobID uuid.UU
 	var a model.Application
 	err := r.pool.QueryRow(ctx, `
 		SELECT id, user_id, job_id, status, applied_at, next_step,
-		       follow_up_date, follow_up_type, follow_up_urgent,
+		       follow_up_date, follow_up_type, follow_up_urgent, outcome,
 		       created_at, updated_at
 		FROM applications
 		WHERE user_id = $1 AND job_id = $2
 	`, userID, jobID).Scan(
 		&a.ID, &a.UserID, &a.JobID, &a.Status, &a.AppliedAt, &a.NextStep,
-		&a.FollowUpDate, &a.FollowUpType, &a.FollowUpUrgent,
+		&a.FollowUpDate, &a.FollowUpType, &a.FollowUpUrgent, &a.Outcome,
 		&a.CreatedAt, &a.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
@@ -42,18 +42,42 @@ func (r *ApplicationRepo) FindByJobID(ctx context.Context, userID, jobID uuid.UU
 	return &a, nil
 }
 
-// ListByUser returns all applications with joined job data
-func (r *ApplicationRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.Application, error) {
+// FindByID returns an application by its own ID, scoped to the owning user
+func (r *ApplicationRepo) FindByID(ctx context.Context, id, userID uuid.UUID) (*model.Application, error) {
+	var a model.Application
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, user_id, job_id, status, applied_at, next_step,
+		       follow_up_date, follow_up_type, follow_up_urgent, outcome,
+		       created_at, updated_at
+		FROM applications
+		WHERE id = $1 AND user_id = $2
+	`, id, userID).Scan(
+		&a.ID, &a.UserID, &a.JobID, &a.Status, &a.AppliedAt, &a.NextStep,
+		&a.FollowUpDate, &a.FollowUpType, &a.FollowUpUrgent, &a.Outcome,
+		&a.CreatedAt, &a.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("finding application by id: %w", err)
+	}
+	return &a, nil
+}
+
+// ListByUser returns all applications with joined job data, most recently
+// updated first. A non-empty status narrows to applications in that stage.
+func (r *ApplicationRepo) ListByUser(ctx context.Context, userID uuid.UUID, status string) ([]model.Application, error) {
 	rows, err := r.pool.Query(ctx, `
 		SELECT a.id, a.user_id, a.job_id, a.status, a.applied_at, a.next_step,
-		       a.follow_up_date, a.follow_up_type, a.follow_up_urgent,
+		       a.follow_up_date, a.follow_up_type, a.follow_up_urgent, a.outcome,
 		       a.created_at, a.updated_at,
 		       j.title, j.company, j.location, j.salary_range, j.company_color, j.company_logo
 		FROM applications a
 		JOIN jobs j ON j.id = a.job_id
-		WHERE a.user_id = $1
+		WHERE a.user_id = $1 AND ($2 = '' OR a.status = $2)
 		ORDER BY a.updated_at DESC
-	`, userID)
+	`, userID, status)
 	if err != nil {
 		return nil, fmt.Errorf("listing applications: %w", err)
 	}
@@ -65,7 +89,7 @@ func (r *ApplicationRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]m
 		var job model.Job
 		err := rows.Scan(
 			&a.ID, &a.UserID, &a.JobID, &a.Status, &a.AppliedAt, &a.NextStep,
-			&a.FollowUpDate, &a.FollowUpType, &a.FollowUpUrgent,
+			&a.FollowUpDate, &a.FollowUpType, &a.FollowUpUrgent, &a.Outcome,
 			&a.CreatedAt, &a.UpdatedAt,
 			&job.Title, &job.Company, &job.Location, &job.SalaryRange,
 			&job.CompanyColor, &job.CompanyLogo,
@@ -79,86 +103,238 @@ func (r *ApplicationRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]m
 	return apps, nil
 }
 
-// Create creates a new application
-func (r *ApplicationRepo) Create(ctx context.Context, a *model.Application) (*model.Application, error) {
+// ListFollowUps returns a user's active applications that have a follow-up
+// date set, joined with job data and ordered soonest first. Closed
+// applications, ones with an outcome, and archived jobs are skipped, as in
+// DueFollowUps.
+func (r *ApplicationRepo) ListFollowUps(ctx context.Context, userID uuid.UUID) ([]model.Application, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.id, a.user_id, a.job_id, a.status, a.applied_at, a.next_step,
+		       a.follow_up_date, a.follow_up_type, a.follow_up_urgent, a.outcome,
+		       a.created_at, a.updated_at,
+		       j.title, j.company, j.location, j.salary_range, j.company_color, j.company_logo
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		WHERE a.user_id = $1
+		  AND a.follow_up_date IS NOT NULL
+		  AND a.status NOT IN ('rejected', 'withdrawn')
+		  AND a.outcome = ''
+		  AND j.archived_at IS NULL
+		ORDER BY a.follow_up_date ASC, a.follow_up_urgent DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing follow-ups: %w", err)
+	}
+	defer rows.Close()
+
+	var apps []model.Application
+	for rows.Next() {
+		var a model.Application
+		var job model.Job
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.JobID, &a.Status, &a.AppliedAt, &a.NextStep,
+			&a.FollowUpDate, &a.FollowUpType, &a.FollowUpUrgent, &a.Outcome,
+			&a.CreatedAt, &a.UpdatedAt,
+			&job.Title, &job.Company, &job.Location, &job.SalaryRange,
+			&job.CompanyColor, &job.CompanyLogo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning follow-up row: %w", err)
+		}
+		job.ID = a.JobID
+		a.Job = &job
+		apps = append(apps, a)
+	}
+	return apps, nil
+}
+
+// Create creates a new application and its initial status_history row
+// (from "" to the starting status) so the timeline begins at creation. If
+// the job already has an application it is left untouched and returned
+// instead, with created false, so a double-submitted create is harmless.
+func (r *ApplicationRepo) Create(ctx context.Context, a *model.Application) (*model.Application, bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	created, err := insertApplicationTx(ctx, tx, a)
+	if err != nil {
+		return nil, false, err
+	}
+	if created == nil {
+		existing, err := r.FindByJobID(ctx, a.UserID, a.JobID)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing == nil {
+			// Deleted between the insert and the lookup
+			return nil, false, fmt.Errorf("creating application: conflicting row disappeared")
+		}
+		return existing, false, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return created, true, nil
+}
+
+// insertApplicationTx inserts an application and its initial status_history
+// row inside the caller's transaction. Returns nil (and no error) when the
+// job already has an application.
+func insertApplicationTx(ctx context.Context, tx pgx.Tx, a *model.Application) (*model.Application, error) {
 	var created model.Application
-	err := r.pool.QueryRow(ctx, `
+	err := tx.QueryRow(ctx, `
 		INSERT INTO applications (user_id, job_id, status, applied_at, next_step,
-		                          follow_up_date, follow_up_type, follow_up_urgent)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		                          follow_up_date, follow_up_type, follow_up_urgent, outcome)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, job_id) DO NOTHING
 		RETURNING id, user_id, job_id, status, applied_at, next_step,
-		          follow_up_date, follow_up_type, follow_up_urgent,
+		          follow_up_date, follow_up_type, follow_up_urgent, outcome,
 		          created_at, updated_at
 	`, a.UserID, a.JobID, a.Status, a.AppliedAt, a.NextStep,
-		a.FollowUpDate, a.FollowUpType, a.FollowUpUrgent,
+		a.FollowUpDate, a.FollowUpType, a.FollowUpUrgent, a.Outcome,
 	).Scan(
 		&created.ID, &created.UserID, &created.JobID, &created.Status,
 		&created.AppliedAt, &created.NextStep, &created.FollowUpDate,
-		&created.FollowUpType, &created.FollowUpUrgent,
+		&created.FollowUpType, &created.FollowUpUrgent, &created.Outcome,
 		&created.CreatedAt, &created.UpdatedAt,
 	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("creating application: %w", err)
 	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO status_history (application_id, from_status, to_status, outcome, note, changed_at)
+		VALUES ($1, '', $2, $3, 'Application created', $4)
+	`, created.ID, created.Status, created.Outcome, created.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("recording initial history: %w", err)
+	}
 	return &created, nil
 }
 
-// UpdateStatus changes application status and records history
-func (r *ApplicationRepo) UpdateStatus(ctx context.Context, id, userID uuid.UUID, newStatus, note string) (*model.Application, error) {
+// UpdateStatus changes application status and outcome and records history.
+// Setting the status and outcome it already has (e.g. a Kanban card dropped
+// back on its own column) changes nothing: no history row is written, and the
+// application is returned as is with changed false.
+func (r *ApplicationRepo) UpdateStatus(ctx context.Context, id, userID uuid.UUID, newStatus, outcome, note string) (*model.Application, bool, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("beginning transaction: %w", err)
+		return nil, false, fmt.Errorf("beginning transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	// Get current status
-	var currentStatus string
+	// Get current status, locking the row so concurrent changes can't both
+	// see the old status
+	var current model.Application
 	err = tx.QueryRow(ctx, `
-		SELECT status FROM applications WHERE id = $1 AND user_id = $2
-	`, id, userID).Scan(&currentStatus)
+		SELECT id, user_id, job_id, status, applied_at, next_step,
+		       follow_up_date, follow_up_type, follow_up_urgent, outcome,
+		       created_at, updated_at
+		FROM applications WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`, id, userID).Scan(
+		&current.ID, &current.UserID, &current.JobID, &current.Status,
+		&current.AppliedAt, &current.NextStep, &current.FollowUpDate,
+		&current.FollowUpType, &current.FollowUpUrgent, &current.Outcome,
+		&current.CreatedAt, &current.UpdatedAt,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("fetching current status: %w", err)
+		return nil, false, fmt.Errorf("fetching current status: %w", err)
+	}
+	if current.Status == newStatus && current.Outcome == outcome {
+		return &current, false, nil
 	}
 
 	// Update status
 	var updated model.Application
 	err = tx.QueryRow(ctx, `
 		UPDATE applications
-		SET status = $3, updated_at = now()
+		SET status = $3, outcome = $4, updated_at = now()
 		WHERE id = $1 AND user_id = $2
 		RETURNING id, user_id, job_id, status, applied_at, next_step,
-		          follow_up_date, follow_up_type, follow_up_urgent,
+		          follow_up_date, follow_up_type, follow_up_urgent, outcome,
 		          created_at, updated_at
-	`, id, userID, newStatus).Scan(
+	`, id, userID, newStatus, outcome).Scan(
 		&updated.ID, &updated.UserID, &updated.JobID, &updated.Status,
 		&updated.AppliedAt, &updated.NextStep, &updated.FollowUpDate,
-		&updated.FollowUpType, &updated.FollowUpUrgent,
+		&updated.FollowUpType, &updated.FollowUpUrgent, &updated.Outcome,
 		&updated.CreatedAt, &updated.UpdatedAt,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("updating application status: %w", err)
+		return nil, false, fmt.Errorf("updating application status: %w", err)
 	}
 
 	// Record status change history
 	_, err = tx.Exec(ctx, `
-		INSERT INTO status_history (application_id, from_status, to_status, note)
-		VALUES ($1, $2, $3, $4)
-	`, id, currentStatus, newStatus, note)
+		INSERT INTO status_history (application_id, from_status, to_status, outcome, note)
+		VALUES ($1, $2, $3, $4, $5)
+	`, id, current.Status, newStatus, outcome, note)
 	if err != nil {
-		return nil, fmt.Errorf("recording status history: %w", err)
+		return nil, false, fmt.Errorf("recording status history: %w", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("committing transaction: %w", err)
+		return nil, false, fmt.Errorf("committing transaction: %w", err)
 	}
 
-	return &updated, nil
+	return &updated, true, nil
+}
+
+// Delete removes a user's application and its status history, and moves the
+// job back to "saved" so the Kanban board matches, all in one transaction.
+// Returns false if no such application exists.
+func (r *ApplicationRepo) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM status_history
+		WHERE application_id = (SELECT id FROM applications WHERE id = $1 AND user_id = $2)
+	`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("deleting application history: %w", err)
+	}
+
+	var jobID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		DELETE FROM applications WHERE id = $1 AND user_id = $2
+		RETURNING job_id
+	`, id, userID).Scan(&jobID)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("deleting application: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE jobs SET status = $3, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+	`, jobID, userID, model.StatusSaved)
+	if err != nil {
+		return false, fmt.Errorf("resetting job status: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return true, nil
 }
 
 // GetHistory returns status change history for an application
 func (r *ApplicationRepo) GetHistory(ctx context.Context, applicationID uuid.UUID) ([]model.StatusHistory, error) {
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, application_id, from_status, to_status, changed_at, note
+		SELECT id, application_id, from_status, to_status, outcome, changed_at, note
 		FROM status_history
 		WHERE application_id = $1
 		ORDER BY changed_at ASC
@@ -171,7 +347,7 @@ func (r *ApplicationRepo) GetHistory(ctx context.Context, applicationID uuid.UUI
 	var history []model.StatusHistory
 	for rows.Next() {
 		var h model.StatusHistory
-		if err := rows.Scan(&h.ID, &h.ApplicationID, &h.FromStatus, &h.ToStatus, &h.ChangedAt, &h.Note); err != nil {
+		if err := rows.Scan(&h.ID, &h.ApplicationID, &h.FromStatus, &h.ToStatus, &h.Outcome, &h.ChangedAt, &h.Note); err != nil {
 			return nil, fmt.Errorf("scanning history row: %w", err)
 		}
 		history = append(history, h)
@@ -179,6 +355,33 @@ func (r *ApplicationRepo) GetHistory(ctx context.Context, applicationID uuid.UUI
 	return history, nil
 }
 
+// GetHistoryBatch returns status history for several of the user's
+// applications in one query, keyed by application ID. IDs that aren't the
+// user's are left out.
+func (r *ApplicationRepo) GetHistoryBatch(ctx context.Context, userID uuid.UUID, applicationIDs []uuid.UUID) (map[uuid.UUID][]model.StatusHistory, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT h.id, h.application_id, h.from_status, h.to_status, h.outcome, h.changed_at, h.note
+		FROM status_history h
+		JOIN applications a ON a.id = h.application_id
+		WHERE a.user_id = $1 AND h.application_id = ANY($2)
+		ORDER BY h.application_id, h.changed_at ASC
+	`, userID, applicationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching status history batch: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[uuid.UUID][]model.StatusHistory)
+	for rows.Next() {
+		var h model.StatusHistory
+		if err := rows.Scan(&h.ID, &h.ApplicationID, &h.FromStatus, &h.ToStatus, &h.Outcome, &h.ChangedAt, &h.Note); err != nil {
+			return nil, fmt.Errorf("scanning history row: %w", err)
+		}
+		history[h.ApplicationID] = append(history[h.ApplicationID], h)
+	}
+	return history, rows.Err()
+}
+
 // UpdateDetails updates follow-up fields without changing status
 func (r *ApplicationRepo) UpdateDetails(ctx context.Context, id, userID uuid.UUID, nextStep string, followUpDate *time.Time, followUpType string, followUpUrgent bool) (*model.Application, error) {
 	var updated model.Application
@@ -188,12 +391,12 @@ func (r *ApplicationRepo) UpdateDetails(ctx context.Context, id, userID uuid.UUI
 		    follow_up_urgent = $6, updated_at = now()
 		WHERE id = $1 AND user_id = $2
 		RETURNING id, user_id, job_id, status, applied_at, next_step,
-		          follow_up_date, follow_up_type, follow_up_urgent,
+		          follow_up_date, follow_up_type, follow_up_urgent, outcome,
 		          created_at, updated_at
 	`, id, userID, nextStep, followUpDate, followUpType, followUpUrgent).Scan(
 		&updated.ID, &updated.UserID, &updated.JobID, &updated.Status,
 		&updated.AppliedAt, &updated.NextStep, &updated.FollowUpDate,
-		&updated.FollowUpType, &updated.FollowUpUrgent,
+		&updated.FollowUpType, &updated.FollowUpUrgent, &updated.Outcome,
 		&updated.CreatedAt, &updated.UpdatedAt,
 	)
 	if err != nil {
@@ -202,6 +405,97 @@ func (r *ApplicationRepo) UpdateDetails(ctx context.Context, id, userID uuid.UUI
 	return &updated, nil
 }
 
+// DueFollowUps returns follow-ups dated before the cutoff (due today or
+// overdue) on active applications, ordered by user so reminders can be grouped.
+// Closed applications (including any with an outcome) and archived jobs are skipped.
+func (r *ApplicationRepo) DueFollowUps(ctx context.Context, before time.Time) ([]model.DueFollowUp, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.id, a.user_id, u.email, u.name, a.job_id, j.title, j.company,
+		       a.status, a.next_step, a.follow_up_date, a.follow_up_type, a.follow_up_urgent
+		FROM applications a
+		JOIN users u ON u.id = a.user_id
+		JOIN jobs j ON j.id = a.job_id
+		WHERE a.follow_up_date IS NOT NULL
+		  AND a.follow_up_date < $1
+		  AND a.status NOT IN ('rejected', 'withdrawn')
+		  AND a.outcome = ''
+		  AND j.archived_at IS NULL
+		ORDER BY a.user_id, a.follow_up_urgent DESC, a.follow_up_date ASC
+	`, before)
+	if err != nil {
+		return nil, fmt.Errorf("listing due follow-ups: %w", err)
+	}
+	defer rows.Close()
+
+	var due []model.DueFollowUp
+	for rows.Next() {
+		var d model.DueFollowUp
+		if err := rows.Scan(
+			&d.ApplicationID, &d.UserID, &d.UserEmail, &d.UserName, &d.JobID,
+			&d.JobTitle, &d.Company, &d.Status, &d.NextStep, &d.FollowUpDate,
+			&d.FollowUpType, &d.FollowUpUrgent,
+		); err != nil {
+			return nil, fmt.Errorf("scanning due follow-up: %w", err)
+		}
+		due = append(due, d)
+	}
+	return due, nil
+}
+
+// ClaimFollowUpDigest records that the user's follow-up digest for the UTC
+// day of now is being sent. Returns false if another run (e.g. another
+// replica) already claimed it.
+func (r *ApplicationRepo) ClaimFollowUpDigest(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		INSERT INTO followup_digests (user_id, digest_date)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, digest_date) DO NOTHING
+	`, userID, usageDate(now))
+	if err != nil {
+		return false, fmt.Errorf("claiming follow-up digest: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// ReleaseFollowUpDigest drops a claim whose email failed to send, so a later
+// run that day can retry it
+func (r *ApplicationRepo) ReleaseFollowUpDigest(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM followup_digests WHERE user_id = $1 AND digest_date = $2
+	`, userID, usageDate(now))
+	if err != nil {
+		return fmt.Errorf("releasing follow-up digest: %w", err)
+	}
+	return nil
+}
+
+// ListProgress returns, for each of a user's applications, its current
+// status and outcome plus every status it has ever been moved to
+func (r *ApplicationRepo) ListProgress(ctx context.Context, userID uuid.UUID) ([]model.ApplicationProgress, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.status, a.outcome, a.applied_at IS NOT NULL,
+		       COALESCE(ARRAY_AGG(h.to_status) FILTER (WHERE h.to_status IS NOT NULL), '{}')
+		FROM applications a
+		LEFT JOIN status_history h ON h.application_id = a.id
+		WHERE a.user_id = $1
+		GROUP BY a.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing application progress: %w", err)
+	}
+	defer rows.Close()
+
+	var progress []model.ApplicationProgress
+	for rows.Next() {
+		var p model.ApplicationProgress
+		if err := rows.Scan(&p.Status, &p.Outcome, &p.HasAppliedAt, &p.Reached); err != nil {
+			return nil, fmt.Errorf("scanning application progress: %w", err)
+		}
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
+
 // CountByStatus returns pipeline counts for the dashboard
 func (r *ApplicationRepo) CountByStatus(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
 	rows, err := r.pool.Query(ctx, `
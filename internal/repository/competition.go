@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+type CompetitiveSnapshotRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewCompetitiveSnapshotRepo(pool *pgxpool.Pool) *CompetitiveSnapshotRepo {
+	return &CompetitiveSnapshotRepo{pool: pool}
+}
+
+// Record computes today's competition figures for a saved job and upserts
+// them as the job's snapshot for the day. The job is matched to feed jobs
+// through user_feed.saved_job_id or its source/external ID; applicant count is
+// how many users saved that listing and the average match score spans every
+// user it was matched to. Returns nil if the job isn't linked to any feed job.
+func (r *CompetitiveSnapshotRepo) Record(ctx context.Context, job *model.Job) (*model.CompetitiveSnapshot, error) {
+	var matched, applicants, avgScore int
+	err := r.pool.QueryRow(ctx, `
+		WITH linked AS (
+			SELECT feed_job_id FROM user_feed WHERE saved_job_id = $1
+			UNION
+			SELECT id FROM feed_jobs WHERE source = $2 AND external_id = $3 AND $3 <> ''
+		)
+		SELECT COUNT(*),
+		       COUNT(DISTINCT uf.user_id) FILTER (WHERE uf.saved),
+		       COALESCE(ROUND(AVG(uf.match_score)), 0)::int
+		FROM user_feed uf
+		WHERE uf.feed_job_id IN (SELECT feed_job_id FROM linked)
+	`, job.ID, job.Source, job.ExternalID).Scan(&matched, &applicants, &avgScore)
+	if err != nil {
+		return nil, fmt.Errorf("computing competition: %w", err)
+	}
+	if matched == 0 {
+		return nil, nil
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	trend := model.TrendSteady
+	var prevApplicants int
+	err = r.pool.QueryRow(ctx, `
+		SELECT applicant_count FROM competitive_snapshots
+		WHERE job_id = $1 AND snapshot_date < $2
+		ORDER BY snapshot_date DESC
+		LIMIT 1
+	`, job.ID, today).Scan(&prevApplicants)
+	switch {
+	case err == pgx.ErrNoRows:
+	case err != nil:
+		return nil, fmt.Errorf("finding previous snapshot: %w", err)
+	case applicants > prevApplicants:
+		trend = model.TrendRising
+	case applicants < prevApplicants:
+		trend = model.TrendFalling
+	}
+
+	var s model.CompetitiveSnapshot
+	err = r.pool.QueryRow(ctx, `
+		INSERT INTO competitive_snapshots (job_id, snapshot_date, applicant_count, avg_match_score, trend)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (job_id, snapshot_date) DO UPDATE
+		SET applicant_count = EXCLUDED.applicant_count,
+		    avg_match_score = EXCLUDED.avg_match_score,
+		    trend = EXCLUDED.trend
+		RETURNING id, job_id, snapshot_date, applicant_count, avg_match_score, trend, created_at
+	`, job.ID, today, applicants, avgScore, trend).Scan(
+		&s.ID, &s.JobID, &s.SnapshotDate, &s.ApplicantCount, &s.AvgMatchScore, &s.Trend, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("saving competitive snapshot: %w", err)
+	}
+	return &s, nil
+}
+
+// List returns a job's most recent snapshots, newest first
+func (r *CompetitiveSnapshotRepo) List(ctx context.Context, jobID uuid.UUID, limit int) ([]model.CompetitiveSnapshot, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, job_id, snapshot_date, applicant_count, avg_match_score, trend, created_at
+		FROM competitive_snapshots
+		WHERE job_id = $1
+		ORDER BY snapshot_date DESC
+		LIMIT $2
+	`, jobID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing competitive snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []model.CompetitiveSnapshot
+	for rows.Next() {
+		var s model.CompetitiveSnapshot
+		if err := rows.Scan(
+			&s.ID, &s.JobID, &s.SnapshotDate, &s.ApplicantCount, &s.AvgMatchScore, &s.Trend, &s.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning competitive snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrStaleUpdate is returned by conditional updates when the row changed
+// after the caller read it (its updated_at no longer matches)
+var ErrStaleUpdate = errors.New("record was modified since it was read")
+
+// NewPool opens the Postgres connection pool. timestamptz columns are always
+// scanned in UTC, so API responses serialize as RFC3339 with a "Z" offset no
+// matter what timezone the database session or host is configured with.
+func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing database URL: %w", err)
+	}
+
+	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		scanTimestamptzInUTC(conn.TypeMap())
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating pool: %w", err)
+	}
+	return pool, nil
+}
+
+// scanTimestamptzInUTC makes the type map scan timestamptz values in UTC
+// rather than the host's local zone
+func scanTimestamptzInUTC(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{
+		Name:  "timestamptz",
+		OID:   pgtype.TimestamptzOID,
+		Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+	})
+}
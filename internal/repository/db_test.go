@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestScanTimestamptzInUTC(t *testing.T) {
+	m := pgtype.NewMap()
+	scanTimestamptzInUTC(m)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, newYork)
+
+	// pgx's default codec scans into the host's zone; make that not UTC
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = newYork
+
+	tests := []struct {
+		name   string
+		format int16
+		src    []byte
+	}{
+		{"text with offset", pgtype.TextFormatCode, []byte("2024-01-02 03:04:05-05")},
+		{"binary", pgtype.BinaryFormatCode, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := tt.src
+			if src == nil {
+				src, err = m.Encode(pgtype.TimestamptzOID, tt.format, want, nil)
+				if err != nil {
+					t.Fatalf("encoding: %v", err)
+				}
+			}
+
+			var got time.Time
+			if err := m.Scan(pgtype.TimestamptzOID, tt.format, src, &got); err != nil {
+				t.Fatalf("scanning: %v", err)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("scanned in %v, want UTC", got.Location())
+			}
+			if !got.Equal(want) {
+				t.Errorf("scanned %v, want the same instant as %v", got, want)
+			}
+		})
+	}
+}
+
+// A session configured for another zone still scans timestamps as UTC, so
+// JSON responses always carry a "Z" offset
+func TestNewPoolScansUTC(t *testing.T) {
+	url := os.Getenv("HIREIQ_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("HIREIQ_TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+	pool, err := NewPool(ctx, url)
+	if err != nil {
+		t.Fatalf("connecting: %v", err)
+	}
+	defer pool.Close()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquiring connection: %v", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SET TIME ZONE 'America/New_York'"); err != nil {
+		t.Fatalf("setting session time zone: %v", err)
+	}
+	// The setting is per connection; put it back before the pool reuses it
+	defer conn.Exec(ctx, "RESET TIME ZONE")
+
+	var got time.Time
+	if err := conn.QueryRow(ctx, "SELECT timestamptz '2024-01-02 03:04:05-05'").Scan(&got); err != nil {
+		t.Fatalf("querying: %v", err)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("scanned in %v, want UTC", got.Location())
+	}
+	if want := time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("scanned %v, want %v", got, want)
+	}
+	wantJSON := `"2024-01-02T08:04:05Z"`
+	if b, _ := got.MarshalJSON(); string(b) != wantJSON {
+		t.Errorf("JSON = %s, want %s", b, wantJSON)
+	}
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,11 +15,12 @@ import (
 )
 
 type FeedRepo struct {
-	pool *pgxpool.Pool
+	pool  *pgxpool.Pool
+	reads *ReadPool // feed pages and aggregates, on the replica when there is one
 }
 
-func NewFeedRepo(pool *pgxpool.Pool) *FeedRepo {
-	return &FeedRepo{pool: pool}
+func NewFeedRepo(pool *pgxpool.Pool, reads *ReadPool) *FeedRepo {
+	return &FeedRepo{pool: pool, reads: reads}
 }
 
 // UpsertFeedJob inserts a feed job or returns the existing one (dedup by external_id + source)
@@ -24,25 +28,25 @@ func (r *FeedRepo) UpsertFeedJob(ctx context.Context, job *model.FeedJob) (*mode
 	var result model.FeedJob
 	err := r.pool.QueryRow(ctx, `
 		INSERT INTO feed_jobs (external_id, source, title, company, location,
-		                       salary_min, salary_max, salary_text, job_type,
+		                       salary_min, salary_max, salary_text, salary_currency, job_type,
 		                       description, required_skills, apply_url, company_logo,
 		                       posted_at, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		ON CONFLICT (external_id, source) DO UPDATE SET
 			title = EXCLUDED.title,
 			fetched_at = now()
 		RETURNING id, external_id, source, title, company, location,
-		          salary_min, salary_max, salary_text, job_type,
+		          salary_min, salary_max, salary_text, salary_currency, job_type,
 		          description, required_skills, apply_url, company_logo,
 		          posted_at, fetched_at
 	`, job.ExternalID, job.Source, job.Title, job.Company, job.Location,
-		job.SalaryMin, job.SalaryMax, job.SalaryText, job.JobType,
+		job.SalaryMin, job.SalaryMax, job.SalaryText, job.SalaryCurrency, job.JobType,
 		job.Description, job.RequiredSkills, job.ApplyURL, job.CompanyLogo,
 		job.PostedAt, time.Now().Add(14*24*time.Hour), // Expires in 14 days
 	).Scan(
 		&result.ID, &result.ExternalID, &result.Source, &result.Title, &result.Company,
 		&result.Location, &result.SalaryMin, &result.SalaryMax, &result.SalaryText,
-		&result.JobType, &result.Description, &result.RequiredSkills, &result.ApplyURL,
+		&result.SalaryCurrency, &result.JobType, &result.Description, &result.RequiredSkills, &result.ApplyURL,
 		&result.CompanyLogo, &result.PostedAt, &result.FetchedAt,
 	)
 	if err != nil {
@@ -51,40 +55,136 @@ func (r *FeedRepo) UpsertFeedJob(ctx context.Context, job *model.FeedJob) (*mode
 	return &result, nil
 }
 
-// LinkJobToUser creates a user_feed entry linking a feed job to a user with a match score
-func (r *FeedRepo) LinkJobToUser(ctx context.Context, userID, feedJobID uuid.UUID, matchScore int) error {
-	_, err := r.pool.Exec(ctx, `
-		INSERT INTO user_feed (user_id, feed_job_id, match_score)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (user_id, feed_job_id) DO UPDATE SET
-			match_score = EXCLUDED.match_score
-	`, userID, feedJobID, matchScore)
-	if err != nil {
-		return fmt.Errorf("linking job to user: %w", err)
+// FeedLink is one user_feed entry written by LinkJobsToUser. AlertID is the
+// job alert whose query found the job, or nil for profile queries.
+type FeedLink struct {
+	FeedJobID  uuid.UUID
+	MatchScore int
+	AlertID    *uuid.UUID
+}
+
+// LinkJobsToUser creates or updates the user_feed entries linking feed jobs to
+// a user, in a single batch round trip. The batch runs as one implicit
+// transaction, so either every link is written or none is. Links apply in
+// order: a job listed twice keeps the first alert that surfaced it.
+func (r *FeedRepo) LinkJobsToUser(ctx context.Context, userID uuid.UUID, links []FeedLink) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, l := range links {
+		batch.Queue(`
+			INSERT INTO user_feed (user_id, feed_job_id, match_score, alert_id)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, feed_job_id) DO UPDATE SET
+				match_score = EXCLUDED.match_score,
+				alert_id = COALESCE(user_feed.alert_id, EXCLUDED.alert_id)
+		`, userID, l.FeedJobID, l.MatchScore, l.AlertID)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for range links {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("linking jobs to user: %w", err)
+		}
 	}
+
+	r.reads.NoteWrite(userID)
 	return nil
 }
 
-// GetUserFeed returns feed jobs for a user, ordered by match score, excluding dismissed
-func (r *FeedRepo) GetUserFeed(ctx context.Context, userID uuid.UUID, limit int) ([]model.FeedJob, error) {
+// FeedFilter holds query parameters for reading a user's feed
+type FeedFilter struct {
+	Limit          int
+	Offset         int
+	PostedWithin   time.Duration // 0 = no recency filter
+	IncludeUndated bool          // with PostedWithin, keep jobs that have no posted_at
+	MinScore       int           // hide jobs matching below this score (0 = show all)
+	Sort           string        // one of the Sort constants; "" or unknown sorts by match
+	AlertID        *uuid.UUID    // only jobs surfaced by this job alert
+}
+
+// feedSortOrders maps each sort option to its ORDER BY clause; fj.id breaks
+// ties so pages don't overlap or skip jobs with equal keys. Salary compares
+// raw amounts, so mixed currencies aren't converted.
+var feedSortOrders = map[string]string{
+	SortMatch:   "uf.match_score DESC, fj.posted_at DESC NULLS LAST, fj.id",
+	SortNewest:  "fj.posted_at DESC NULLS LAST, fj.fetched_at DESC, fj.id",
+	SortSalary:  "GREATEST(fj.salary_min, fj.salary_max) DESC, uf.match_score DESC, fj.id",
+	SortCompany: "LOWER(fj.company) ASC, uf.match_score DESC, fj.id",
+}
+
+// feedWhere builds the WHERE clause shared by GetUserFeed and CountUserFeed:
+// the user's undismissed, unsnoozed, unexpired feed jobs, narrowed by the
+// filter.
+// Returns the clause, its args, and the next free placeholder index.
+func feedWhere(userID uuid.UUID, filter FeedFilter) (string, []any, int) {
+	where := `
+		WHERE uf.user_id = $1
+		  AND uf.dismissed = false
+		  AND (uf.snoozed_until IS NULL OR uf.snoozed_until <= now())
+		  AND (fj.expires_at IS NULL OR fj.expires_at > now())
+	`
+	args := []any{userID}
+	argIdx := 2
+
+	if filter.PostedWithin > 0 {
+		cutoff := time.Now().Add(-filter.PostedWithin)
+		if filter.IncludeUndated {
+			where += fmt.Sprintf(" AND (fj.posted_at IS NULL OR fj.posted_at >= $%d)", argIdx)
+		} else {
+			where += fmt.Sprintf(" AND fj.posted_at >= $%d", argIdx)
+		}
+		args = append(args, cutoff)
+		argIdx++
+	}
+
+	// Jobs a job alert asked for are shown whatever their profile match
+	if filter.MinScore > 0 {
+		where += fmt.Sprintf(" AND (uf.match_score >= $%d OR uf.alert_id IS NOT NULL)", argIdx)
+		args = append(args, filter.MinScore)
+		argIdx++
+	}
+
+	if filter.AlertID != nil {
+		where += fmt.Sprintf(" AND uf.alert_id = $%d", argIdx)
+		args = append(args, *filter.AlertID)
+		argIdx++
+	}
+
+	return where, args, argIdx
+}
+
+// GetUserFeed returns a page of feed jobs for a user, excluding dismissed,
+// ordered by filter.Sort (match score by default)
+func (r *FeedRepo) GetUserFeed(ctx context.Context, userID uuid.UUID, filter FeedFilter) ([]model.FeedJob, error) {
+	limit := filter.Limit
 	if limit == 0 {
 		limit = 30
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	where, args, argIdx := feedWhere(userID, filter)
+	query := `
 		SELECT fj.id, fj.external_id, fj.source, fj.title, fj.company, fj.location,
-		       fj.salary_min, fj.salary_max, fj.salary_text, fj.job_type,
+		       fj.salary_min, fj.salary_max, fj.salary_text, fj.salary_currency, fj.job_type,
 		       fj.description, fj.required_skills, fj.apply_url, fj.company_logo,
 		       fj.posted_at, fj.fetched_at,
-		       uf.match_score, uf.dismissed, uf.saved, uf.saved_job_id
+		       uf.match_score, uf.dismissed, uf.saved, uf.saved_job_id, uf.alert_id
 		FROM user_feed uf
 		JOIN feed_jobs fj ON fj.id = uf.feed_job_id
-		WHERE uf.user_id = $1
-		  AND uf.dismissed = false
-		  AND (fj.expires_at IS NULL OR fj.expires_at > now())
-		ORDER BY uf.match_score DESC, fj.posted_at DESC NULLS LAST
-		LIMIT $2
-	`, userID, limit)
+	` + where
+
+	order, ok := feedSortOrders[filter.Sort]
+	if !ok {
+		order = feedSortOrders[SortMatch]
+	}
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", order, argIdx, argIdx+1)
+	args = append(args, limit, filter.Offset)
+
+	rows, err := r.reads.Query(ctx, userID, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("getting user feed: %w", err)
 	}
@@ -95,10 +195,10 @@ func (r *FeedRepo) GetUserFeed(ctx context.Context, userID uuid.UUID, limit int)
 		var j model.FeedJob
 		err := rows.Scan(
 			&j.ID, &j.ExternalID, &j.Source, &j.Title, &j.Company, &j.Location,
-			&j.SalaryMin, &j.SalaryMax, &j.SalaryText, &j.JobType,
+			&j.SalaryMin, &j.SalaryMax, &j.SalaryText, &j.SalaryCurrency, &j.JobType,
 			&j.Description, &j.RequiredSkills, &j.ApplyURL, &j.CompanyLogo,
 			&j.PostedAt, &j.FetchedAt,
-			&j.MatchScore, &j.Dismissed, &j.Saved, &j.SavedJobID,
+			&j.MatchScore, &j.Dismissed, &j.Saved, &j.SavedJobID, &j.AlertID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning feed job: %w", err)
@@ -109,6 +209,22 @@ func (r *FeedRepo) GetUserFeed(ctx context.Context, userID uuid.UUID, limit int)
 	return jobs, nil
 }
 
+// CountUserFeed returns how many feed jobs match the filter in total, ignoring
+// Limit and Offset
+func (r *FeedRepo) CountUserFeed(ctx context.Context, userID uuid.UUID, filter FeedFilter) (int, error) {
+	where, args, _ := feedWhere(userID, filter)
+	var total int
+	err := r.reads.QueryRow(ctx, userID, `
+		SELECT COUNT(*)
+		FROM user_feed uf
+		JOIN feed_jobs fj ON fj.id = uf.feed_job_id
+	`+where, args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("counting user feed: %w", err)
+	}
+	return total, nil
+}
+
 // DismissFeedJob marks a feed job as dismissed for a user
 func (r *FeedRepo) DismissFeedJob(ctx context.Context, userID, feedJobID uuid.UUID) error {
 	_, err := r.pool.Exec(ctx, `
@@ -121,46 +237,266 @@ func (r *FeedRepo) DismissFeedJob(ctx context.Context, userID, feedJobID uuid.UU
 	return nil
 }
 
-// SaveFeedJobToCRM copies a feed job into the user's jobs table and marks it saved
-func (r *FeedRepo) SaveFeedJobToCRM(ctx context.Context, userID, feedJobID uuid.UUID) (*model.Job, error) {
+// BulkDismiss dismisses several of the user's feed jobs in one statement and
+// returns how many were newly dismissed. IDs not in the user's feed, or
+// already dismissed, are skipped.
+func (r *FeedRepo) BulkDismiss(ctx context.Context, userID uuid.UUID, feedJobIDs []uuid.UUID) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE user_feed SET dismissed = true
+		WHERE user_id = $1 AND feed_job_id = ANY($2) AND dismissed = false
+	`, userID, feedJobIDs)
+	if err != nil {
+		return 0, fmt.Errorf("bulk dismissing feed jobs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// ErrFeedJobNotFound is returned when a feed job doesn't exist in the user's feed
+var ErrFeedJobNotFound = errors.New("feed job not found")
+
+// SnoozeFeedJob hides a feed job from the user's feed until the given time.
+// Returns ErrFeedJobNotFound if the job isn't in the user's feed.
+func (r *FeedRepo) SnoozeFeedJob(ctx context.Context, userID, feedJobID uuid.UUID, until time.Time) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE user_feed SET snoozed_until = $3
+		WHERE user_id = $1 AND feed_job_id = $2
+	`, userID, feedJobID, until)
+	if err != nil {
+		return fmt.Errorf("snoozing feed job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFeedJobNotFound
+	}
+	return nil
+}
+
+// UndismissFeedJob restores a dismissed feed job to the user's feed.
+// Returns ErrFeedJobNotFound if the job isn't in the user's feed.
+func (r *FeedRepo) UndismissFeedJob(ctx context.Context, userID, feedJobID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE user_feed SET dismissed = false
+		WHERE user_id = $1 AND feed_job_id = $2
+	`, userID, feedJobID)
+	if err != nil {
+		return fmt.Errorf("undismissing feed job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFeedJobNotFound
+	}
+	return nil
+}
+
+// SaveFeedJobToCRM copies a feed job into the user's jobs table and marks it saved.
+// If the job was already saved, the existing CRM job is returned instead of a
+// duplicate, with alreadySaved true.
+func (r *FeedRepo) SaveFeedJobToCRM(ctx context.Context, userID, feedJobID uuid.UUID) (job *model.Job, alreadySaved bool, err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	job, alreadySaved, err = saveFeedJobTx(ctx, tx, userID, feedJobID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return job, alreadySaved, nil
+}
+
+// SaveFeedJobsToCRM saves several feed jobs in a single transaction.
+// Missing IDs are reported per-item rather than failing the batch; any
+// database error rolls back the whole batch.
+func (r *FeedRepo) SaveFeedJobsToCRM(ctx context.Context, userID uuid.UUID, feedJobIDs []uuid.UUID) ([]model.FeedSaveResult, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("starting transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	// Get the feed job
+	byID := make(map[uuid.UUID]model.FeedSaveResult, len(feedJobIDs))
+	for _, id := range lockOrder(feedJobIDs) {
+		job, alreadySaved, err := saveFeedJobTx(ctx, tx, userID, id)
+		switch {
+		case errors.Is(err, ErrFeedJobNotFound):
+			byID[id] = model.FeedSaveResult{FeedJobID: id, Status: model.FeedSaveNotFound}
+		case err != nil:
+			return nil, err
+		case alreadySaved:
+			byID[id] = model.FeedSaveResult{FeedJobID: id, Status: model.FeedSaveAlreadySaved, Job: job}
+		default:
+			byID[id] = model.FeedSaveResult{FeedJobID: id, Status: model.FeedSaveSaved, Job: job}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return inRequestOrder(feedJobIDs, byID), nil
+}
+
+// ApplyFeedJobs marks several feed jobs as applied in a single transaction:
+// each is saved to the CRM (reusing the existing CRM job if it was saved
+// before), gets an "applied" application dated appliedAt, and has its job
+// status moved to applied. Jobs that already have an application are left
+// as they are and reported as already applied. Missing IDs are reported
+// per-item; any database error rolls back the whole batch.
+func (r *FeedRepo) ApplyFeedJobs(ctx context.Context, userID uuid.UUID, feedJobIDs []uuid.UUID, appliedAt time.Time) ([]model.FeedApplyResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	byID := make(map[uuid.UUID]model.FeedApplyResult, len(feedJobIDs))
+	for _, id := range lockOrder(feedJobIDs) {
+		job, _, err := saveFeedJobTx(ctx, tx, userID, id)
+		if errors.Is(err, ErrFeedJobNotFound) {
+			byID[id] = model.FeedApplyResult{FeedJobID: id, Status: model.FeedApplyNotFound}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		app, err := insertApplicationTx(ctx, tx, &model.Application{
+			UserID:    userID,
+			JobID:     job.ID,
+			Status:    model.StatusApplied,
+			AppliedAt: &appliedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if app == nil {
+			byID[id] = model.FeedApplyResult{FeedJobID: id, Status: model.FeedApplyAlreadyApplied, Job: job}
+			continue
+		}
+
+		err = tx.QueryRow(ctx, `
+			UPDATE jobs SET status = $3, updated_at = now()
+			WHERE id = $1 AND user_id = $2
+			RETURNING status, updated_at
+		`, job.ID, userID, model.StatusApplied).Scan(&job.Status, &job.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("updating applied job status: %w", err)
+		}
+
+		byID[id] = model.FeedApplyResult{FeedJobID: id, Status: model.FeedApplyApplied, Job: job, Application: app}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return inRequestOrder(feedJobIDs, byID), nil
+}
+
+// lockOrder dedupes and sorts feed job IDs so batch saves lock user_feed
+// rows in the same order. Two batches with overlapping IDs in different
+// orders would otherwise deadlock.
+func lockOrder(ids []uuid.UUID) []uuid.UUID {
+	sorted := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			sorted = append(sorted, id)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return sorted
+}
+
+// inRequestOrder lists per-ID results in the order the IDs were requested,
+// once each
+func inRequestOrder[T any](ids []uuid.UUID, byID map[uuid.UUID]T) []T {
+	results := make([]T, 0, len(byID))
+	seen := make(map[uuid.UUID]bool, len(byID))
+	for _, id := range ids {
+		if r, ok := byID[id]; ok && !seen[id] {
+			seen[id] = true
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// saveFeedJobTx does the copy for SaveFeedJobToCRM / SaveFeedJobsToCRM inside
+// the caller's transaction. Returns alreadySaved=true with the existing CRM job
+// when the feed job was saved before (dedup), restoring it if it has since
+// been archived, or ErrFeedJobNotFound if the feed job isn't in the user's
+// feed.
+func saveFeedJobTx(ctx context.Context, tx pgx.Tx, userID, feedJobID uuid.UUID) (*model.Job, bool, error) {
+	// Get the feed job, scoped to the user's feed. The row lock makes a
+	// concurrent save of the same job (a double click) wait and then see
+	// saved_job_id, instead of both inserting a job.
 	var fj model.FeedJob
-	err = tx.QueryRow(ctx, `
-		SELECT id, external_id, source, title, company, location,
-		       salary_min, salary_max, salary_text, job_type,
-		       description, required_skills, apply_url, company_logo
-		FROM feed_jobs WHERE id = $1
-	`, feedJobID).Scan(
+	err := tx.QueryRow(ctx, `
+		SELECT fj.id, fj.external_id, fj.source, fj.title, fj.company, fj.location,
+		       fj.salary_min, fj.salary_max, fj.salary_text, fj.salary_currency, fj.job_type,
+		       fj.description, fj.required_skills, fj.apply_url, fj.company_logo,
+		       uf.match_score, uf.saved, uf.saved_job_id
+		FROM user_feed uf
+		JOIN feed_jobs fj ON fj.id = uf.feed_job_id
+		WHERE uf.user_id = $1 AND uf.feed_job_id = $2
+		FOR UPDATE OF uf
+	`, userID, feedJobID).Scan(
 		&fj.ID, &fj.ExternalID, &fj.Source, &fj.Title, &fj.Company, &fj.Location,
-		&fj.SalaryMin, &fj.SalaryMax, &fj.SalaryText, &fj.JobType,
+		&fj.SalaryMin, &fj.SalaryMax, &fj.SalaryText, &fj.SalaryCurrency, &fj.JobType,
 		&fj.Description, &fj.RequiredSkills, &fj.ApplyURL, &fj.CompanyLogo,
+		&fj.MatchScore, &fj.Saved, &fj.SavedJobID,
 	)
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("feed job not found")
+		return nil, false, ErrFeedJobNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("getting feed job: %w", err)
+		return nil, false, fmt.Errorf("getting feed job: %w", err)
+	}
+
+	// Dedup: return the existing CRM job if this feed job was already saved.
+	// Saving it again unarchives it, so the user can see the job they saved.
+	if fj.Saved && fj.SavedJobID != nil {
+		var existing model.Job
+		err = tx.QueryRow(ctx, `
+			UPDATE jobs
+			SET archived_at = NULL,
+			    updated_at = CASE WHEN archived_at IS NULL THEN updated_at ELSE now() END
+			WHERE id = $1 AND user_id = $2
+			RETURNING id, user_id, external_id, source, title, company, location,
+			          salary_range, job_type, description, tags, required_skills,
+			          preferred_skills, apply_url, hiring_email, company_logo,
+			          company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
+		`, *fj.SavedJobID, userID).Scan(
+			&existing.ID, &existing.UserID, &existing.ExternalID, &existing.Source, &existing.Title, &existing.Company,
+			&existing.Location, &existing.SalaryRange, &existing.JobType, &existing.Description, &existing.Tags,
+			&existing.RequiredSkills, &existing.PreferredSkills, &existing.ApplyURL, &existing.HiringEmail,
+			&existing.CompanyLogo, &existing.CompanyColor, &existing.MatchScore, &existing.Bookmarked, &existing.Status, &existing.AppliedExternally, &existing.ArchivedAt,
+			&existing.CreatedAt, &existing.UpdatedAt,
+		)
+		if err == nil {
+			return &existing, true, nil
+		}
+		if err != pgx.ErrNoRows {
+			return nil, false, fmt.Errorf("getting saved job: %w", err)
+		}
+		// The CRM job is gone — fall through and save it again
 	}
 
 	// Build salary range text
 	salaryRange := fj.SalaryText
 	if salaryRange == "" && fj.SalaryMin > 0 {
-		salaryRange = fmt.Sprintf("$%dk - $%dk", fj.SalaryMin/1000, fj.SalaryMax/1000)
+		cur := model.CurrencyPrefix(fj.SalaryCurrency)
+		salaryRange = fmt.Sprintf("%s%dk - %s%dk", cur, fj.SalaryMin/1000, cur, fj.SalaryMax/1000)
 	}
 
-	// Get the match score from user_feed
-	var matchScore int
-	_ = tx.QueryRow(ctx, `
-		SELECT match_score FROM user_feed
-		WHERE user_id = $1 AND feed_job_id = $2
-	`, userID, feedJobID).Scan(&matchScore)
-
 	// Insert into user's jobs
 	var job model.Job
 	err = tx.QueryRow(ctx, `
@@ -171,19 +507,19 @@ func (r *FeedRepo) SaveFeedJobToCRM(ctx context.Context, userID, feedJobID uuid.
 		RETURNING id, user_id, external_id, source, title, company, location,
 		          salary_range, job_type, description, tags, required_skills,
 		          preferred_skills, apply_url, hiring_email, company_logo,
-		          company_color, match_score, bookmarked, status, created_at, updated_at
+		          company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
 	`, userID, fj.ExternalID, fj.Source, fj.Title, fj.Company, fj.Location,
 		salaryRange, fj.JobType, fj.Description, fj.RequiredSkills,
-		fj.ApplyURL, fj.CompanyLogo, matchScore,
+		fj.ApplyURL, fj.CompanyLogo, fj.MatchScore,
 	).Scan(
 		&job.ID, &job.UserID, &job.ExternalID, &job.Source, &job.Title, &job.Company,
 		&job.Location, &job.SalaryRange, &job.JobType, &job.Description, &job.Tags,
 		&job.RequiredSkills, &job.PreferredSkills, &job.ApplyURL, &job.HiringEmail,
-		&job.CompanyLogo, &job.CompanyColor, &job.MatchScore, &job.Bookmarked, &job.Status,
+		&job.CompanyLogo, &job.CompanyColor, &job.MatchScore, &job.Bookmarked, &job.Status, &job.AppliedExternally, &job.ArchivedAt,
 		&job.CreatedAt, &job.UpdatedAt,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("saving job to CRM: %w", err)
+		return nil, false, fmt.Errorf("saving job to CRM: %w", err)
 	}
 
 	// Mark as saved in user_feed
@@ -192,14 +528,10 @@ func (r *FeedRepo) SaveFeedJobToCRM(ctx context.Context, userID, feedJobID uuid.
 		WHERE user_id = $1 AND feed_job_id = $2
 	`, userID, feedJobID, job.ID)
 	if err != nil {
-		return nil, fmt.Errorf("marking feed job as saved: %w", err)
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("committing transaction: %w", err)
+		return nil, false, fmt.Errorf("marking feed job as saved: %w", err)
 	}
 
-	return &job, nil
+	return &job, false, nil
 }
 
 // GetLastRefresh returns when a user's feed was last refreshed
@@ -220,24 +552,191 @@ func (r *FeedRepo) GetLastRefresh(ctx context.Context, userID uuid.UUID) (*time.
 	return &refreshedAt, nil
 }
 
-// LogRefresh records a feed refresh
-func (r *FeedRepo) LogRefresh(ctx context.Context, userID uuid.UUID, query string, fetched, newJobs int) error {
+// GetLatestRefreshLog returns the user's most recent feed_refresh_log row, or nil
+func (r *FeedRepo) GetLatestRefreshLog(ctx context.Context, userID uuid.UUID) (*model.FeedRefreshLog, error) {
+	var l model.FeedRefreshLog
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, user_id, COALESCE(query_used, ''), COALESCE(jobs_fetched, 0),
+		       COALESCE(jobs_new, 0), sources, refreshed_at
+		FROM feed_refresh_log
+		WHERE user_id = $1
+		ORDER BY refreshed_at DESC
+		LIMIT 1
+	`, userID).Scan(&l.ID, &l.UserID, &l.QueryUsed, &l.JobsFetched, &l.JobsNew, &l.Sources, &l.RefreshedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting latest refresh log: %w", err)
+	}
+	return &l, nil
+}
+
+// What started a feed refresh, as stored in feed_refresh_log.triggered_by
+const (
+	RefreshTriggerUser      = "user"
+	RefreshTriggerScheduler = "scheduler"
+)
+
+// RecentlyRefreshedUsers returns users who refreshed their feed themselves
+// since the given time, least recently refreshed first. Scheduled refreshes
+// don't count, so users who stop coming back age out.
+func (r *FeedRepo) RecentlyRefreshedUsers(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT user_id
+		FROM feed_refresh_log
+		WHERE refreshed_at >= $1 AND triggered_by = 'user'
+		GROUP BY user_id
+		ORDER BY MAX(refreshed_at) ASC
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("listing recently refreshed users: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// LogRefresh records a feed refresh. sources is the JSON per-source
+// breakdown (status, counts, errors); nil stores NULL. trigger is one of the
+// RefreshTrigger constants.
+func (r *FeedRepo) LogRefresh(ctx context.Context, userID uuid.UUID, query string, fetched, newJobs int, sources []byte, trigger string) error {
 	_, err := r.pool.Exec(ctx, `
-		INSERT INTO feed_refresh_log (user_id, query_used, jobs_fetched, jobs_new)
-		VALUES ($1, $2, $3, $4)
-	`, userID, query, fetched, newJobs)
+		INSERT INTO feed_refresh_log (user_id, query_used, jobs_fetched, jobs_new, sources, triggered_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, userID, query, fetched, newJobs, sources, trigger)
 	if err != nil {
 		return fmt.Errorf("logging refresh: %w", err)
 	}
+	r.reads.NoteWrite(userID)
 	return nil
 }
 
+// SkillDemand counts how many of the user's active feed jobs list each
+// required skill, most requested first. Skills are grouped ignoring case and
+// surrounding whitespace.
+func (r *FeedRepo) SkillDemand(ctx context.Context, userID uuid.UUID, limit int) ([]model.SkillDemand, error) {
+	rows, err := r.reads.Query(ctx, userID, `
+		SELECT MIN(btrim(s.skill)), COUNT(DISTINCT fj.id) AS jobs
+		FROM user_feed uf
+		JOIN feed_jobs fj ON fj.id = uf.feed_job_id
+		CROSS JOIN LATERAL unnest(fj.required_skills) AS s(skill)
+		WHERE uf.user_id = $1
+		  AND uf.dismissed = false
+		  AND (fj.expires_at IS NULL OR fj.expires_at > now())
+		  AND btrim(s.skill) <> ''
+		GROUP BY lower(btrim(s.skill))
+		ORDER BY jobs DESC, MIN(btrim(s.skill))
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting skill demand: %w", err)
+	}
+	defer rows.Close()
+
+	var demand []model.SkillDemand
+	for rows.Next() {
+		var d model.SkillDemand
+		if err := rows.Scan(&d.Skill, &d.Jobs); err != nil {
+			return nil, fmt.Errorf("scanning skill demand: %w", err)
+		}
+		demand = append(demand, d)
+	}
+	return demand, rows.Err()
+}
+
+// PopularSkills counts how many active feed jobs fetched since the cutoff
+// list each required skill, across all users, most requested first. Skills
+// are grouped ignoring case and surrounding whitespace.
+func (r *FeedRepo) PopularSkills(ctx context.Context, since time.Time, limit int) ([]model.SkillDemand, error) {
+	rows, err := r.reads.Query(ctx, uuid.Nil, `
+		SELECT MIN(btrim(s.skill)), COUNT(DISTINCT fj.id) AS jobs
+		FROM feed_jobs fj
+		CROSS JOIN LATERAL unnest(fj.required_skills) AS s(skill)
+		WHERE fj.fetched_at >= $1
+		  AND (fj.expires_at IS NULL OR fj.expires_at > now())
+		  AND btrim(s.skill) <> ''
+		GROUP BY lower(btrim(s.skill))
+		ORDER BY jobs DESC, MIN(btrim(s.skill))
+		LIMIT $2
+	`, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting popular skills: %w", err)
+	}
+	defer rows.Close()
+
+	var popular []model.SkillDemand
+	for rows.Next() {
+		var d model.SkillDemand
+		if err := rows.Scan(&d.Skill, &d.Jobs); err != nil {
+			return nil, fmt.Errorf("scanning popular skill: %w", err)
+		}
+		popular = append(popular, d)
+	}
+	return popular, rows.Err()
+}
+
+// SalaryDistribution computes salary percentiles over the user's active feed
+// jobs with a numeric salary, one row per currency, most jobs first
+func (r *FeedRepo) SalaryDistribution(ctx context.Context, userID uuid.UUID) ([]model.SalaryDistribution, error) {
+	rows, err := r.reads.Query(ctx, userID, `
+		WITH salaries AS (
+			SELECT fj.salary_currency AS currency,
+			       CASE
+			           WHEN fj.salary_min > 0 AND fj.salary_max > 0 THEN (fj.salary_min + fj.salary_max) / 2.0
+			           ELSE GREATEST(fj.salary_min, fj.salary_max)
+			       END AS salary
+			FROM user_feed uf
+			JOIN feed_jobs fj ON fj.id = uf.feed_job_id
+			WHERE uf.user_id = $1
+			  AND uf.dismissed = false
+			  AND (fj.expires_at IS NULL OR fj.expires_at > now())
+			  AND (fj.salary_min > 0 OR fj.salary_max > 0)
+		)
+		SELECT currency, COUNT(*),
+		       MIN(salary),
+		       percentile_cont(0.25) WITHIN GROUP (ORDER BY salary),
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY salary),
+		       percentile_cont(0.75) WITHIN GROUP (ORDER BY salary),
+		       percentile_cont(0.9) WITHIN GROUP (ORDER BY salary),
+		       MAX(salary)
+		FROM salaries
+		GROUP BY currency
+		ORDER BY COUNT(*) DESC, currency
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting salary distribution: %w", err)
+	}
+	defer rows.Close()
+
+	var dists []model.SalaryDistribution
+	for rows.Next() {
+		var d model.SalaryDistribution
+		var min, p25, median, p75, p90, max float64
+		if err := rows.Scan(&d.Currency, &d.Jobs, &min, &p25, &median, &p75, &p90, &max); err != nil {
+			return nil, fmt.Errorf("scanning salary distribution: %w", err)
+		}
+		d.Min, d.P25, d.Median = int(min), int(p25), int(median)
+		d.P75, d.P90, d.Max = int(p75), int(p90), int(max)
+		dists = append(dists, d)
+	}
+	return dists, rows.Err()
+}
+
 // GetUserFeedForRescore returns all non-dismissed feed jobs for a user,
 // used to recalculate match scores when the user's profile changes.
 func (r *FeedRepo) GetUserFeedForRescore(ctx context.Context, userID uuid.UUID) ([]model.FeedJob, error) {
 	rows, err := r.pool.Query(ctx, `
 		SELECT fj.id, fj.external_id, fj.source, fj.title, fj.company, fj.location,
-		       fj.salary_min, fj.salary_max, fj.salary_text, fj.job_type,
+		       fj.salary_min, fj.salary_max, fj.salary_text, fj.salary_currency, fj.job_type,
 		       fj.description, fj.required_skills, fj.apply_url, fj.company_logo,
 		       fj.posted_at, fj.fetched_at,
 		       uf.match_score, uf.dismissed, uf.saved, uf.saved_job_id
@@ -257,7 +756,7 @@ func (r *FeedRepo) GetUserFeedForRescore(ctx context.Context, userID uuid.UUID)
 		var j model.FeedJob
 		err := rows.Scan(
 			&j.ID, &j.ExternalID, &j.Source, &j.Title, &j.Company, &j.Location,
-			&j.SalaryMin, &j.SalaryMax, &j.SalaryText, &j.JobType,
+			&j.SalaryMin, &j.SalaryMax, &j.SalaryText, &j.SalaryCurrency, &j.JobType,
 			&j.Description, &j.RequiredSkills, &j.ApplyURL, &j.CompanyLogo,
 			&j.PostedAt, &j.FetchedAt,
 			&j.MatchScore, &j.Dismissed, &j.Saved, &j.SavedJobID,
@@ -295,6 +794,7 @@ func (r *FeedRepo) BatchUpdateMatchScores(ctx context.Context, userID uuid.UUID,
 		}
 	}
 
+	r.reads.NoteWrite(userID)
 	return nil
 }
 
@@ -302,7 +802,7 @@ func (r *FeedRepo) BatchUpdateMatchScores(ctx context.Context, userID uuid.UUID,
 func (r *FeedRepo) GetFeedJobsByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]model.FeedJob, error) {
 	rows, err := r.pool.Query(ctx, `
 		SELECT fj.id, fj.external_id, fj.source, fj.title, fj.company, fj.location,
-		       fj.salary_min, fj.salary_max, fj.salary_text, fj.job_type,
+		       fj.salary_min, fj.salary_max, fj.salary_text, fj.salary_currency, fj.job_type,
 		       fj.description, fj.required_skills, fj.apply_url, fj.company_logo,
 		       fj.posted_at, fj.fetched_at,
 		       uf.match_score, uf.dismissed, uf.saved, uf.saved_job_id
@@ -321,7 +821,7 @@ func (r *FeedRepo) GetFeedJobsByIDs(ctx context.Context, userID uuid.UUID, ids [
 		var j model.FeedJob
 		err := rows.Scan(
 			&j.ID, &j.ExternalID, &j.Source, &j.Title, &j.Company, &j.Location,
-			&j.SalaryMin, &j.SalaryMax, &j.SalaryText, &j.JobType,
+			&j.SalaryMin, &j.SalaryMax, &j.SalaryText, &j.SalaryCurrency, &j.JobType,
 			&j.Description, &j.RequiredSkills, &j.ApplyURL, &j.CompanyLogo,
 			&j.PostedAt, &j.FetchedAt,
 			&j.MatchScore, &j.Dismissed, &j.Saved, &j.SavedJobID,
@@ -345,3 +845,103 @@ func (r *FeedRepo) CleanExpiredFeedJobs(ctx context.Context) (int, error) {
 	}
 	return int(result.RowsAffected()), nil
 }
+
+// LivenessCandidate is a feed job whose apply URL is due a liveness check
+type LivenessCandidate struct {
+	FeedJobID uuid.UUID
+	ApplyURL  string
+}
+
+// LivenessCandidates returns unexpired feed jobs worth checking for a dead
+// apply link: ones any user has saved, plus each user's topN feed jobs by
+// match score. Jobs checked since checkedBefore are skipped; jobs whose
+// last checks failed wait longer, doubling per consecutive failure up to 32
+// days. Never-checked and least recently checked come first.
+func (r *FeedRepo) LivenessCandidates(ctx context.Context, topN int, checkedBefore time.Time, limit int) ([]LivenessCandidate, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH wanted AS (
+			SELECT feed_job_id FROM user_feed WHERE saved
+			UNION
+			SELECT feed_job_id FROM (
+				SELECT feed_job_id,
+				       ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY match_score DESC) AS rn
+				FROM user_feed
+				WHERE NOT dismissed
+			) ranked
+			WHERE rn <= $1
+		)
+		SELECT fj.id, fj.apply_url
+		FROM feed_jobs fj
+		WHERE fj.id IN (SELECT feed_job_id FROM wanted)
+		  AND COALESCE(fj.apply_url, '') <> ''
+		  AND (fj.expires_at IS NULL OR fj.expires_at > now())
+		  AND (fj.liveness_checked_at IS NULL
+		       OR fj.liveness_checked_at < $2 - (power(2, LEAST(fj.liveness_failures, 5)) - 1) * interval '1 day')
+		ORDER BY fj.liveness_checked_at ASC NULLS FIRST
+		LIMIT $3
+	`, topN, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing liveness candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []LivenessCandidate
+	for rows.Next() {
+		var c LivenessCandidate
+		if err := rows.Scan(&c.FeedJobID, &c.ApplyURL); err != nil {
+			return nil, fmt.Errorf("scanning liveness candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// MarkLivenessChecked records that the feed jobs' apply URLs were checked
+func (r *FeedRepo) MarkLivenessChecked(ctx context.Context, feedJobIDs []uuid.UUID) error {
+	if len(feedJobIDs) == 0 {
+		return nil
+	}
+	_, err := r.pool.Exec(ctx, `
+		UPDATE feed_jobs SET liveness_checked_at = now(), liveness_failures = 0 WHERE id = ANY($1)
+	`, feedJobIDs)
+	if err != nil {
+		return fmt.Errorf("marking liveness checked: %w", err)
+	}
+	return nil
+}
+
+// MarkLivenessFailed records a liveness check that got no usable answer (bad
+// URL, network error), so LivenessCandidates backs off before the next try
+func (r *FeedRepo) MarkLivenessFailed(ctx context.Context, feedJobIDs []uuid.UUID) error {
+	if len(feedJobIDs) == 0 {
+		return nil
+	}
+	_, err := r.pool.Exec(ctx, `
+		UPDATE feed_jobs
+		SET liveness_checked_at = now(), liveness_failures = liveness_failures + 1
+		WHERE id = ANY($1)
+	`, feedJobIDs)
+	if err != nil {
+		return fmt.Errorf("marking liveness failed: %w", err)
+	}
+	return nil
+}
+
+// MarkExpired expires feed jobs early (e.g. their posting was taken down), so
+// they drop out of feeds immediately and are removed by CleanExpiredFeedJobs.
+// Saved copies in users' trackers are unaffected.
+func (r *FeedRepo) MarkExpired(ctx context.Context, feedJobIDs []uuid.UUID) (int, error) {
+	if len(feedJobIDs) == 0 {
+		return 0, nil
+	}
+	result, err := r.pool.Exec(ctx, `
+		UPDATE feed_jobs
+		SET expires_at = now(), liveness_checked_at = now()
+		WHERE id = ANY($1)
+		  AND (expires_at IS NULL OR expires_at > now())
+	`, feedJobIDs)
+	if err != nil {
+		return 0, fmt.Errorf("marking feed jobs expired: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
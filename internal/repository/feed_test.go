@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLockOrder(t *testing.T) {
+	a := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	b := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	c := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	// Any request order locks in the same order
+	for _, ids := range [][]uuid.UUID{{c, a, b}, {b, c, a, c}, {a, b, c}} {
+		if got := lockOrder(ids); !slices.Equal(got, []uuid.UUID{a, b, c}) {
+			t.Errorf("lockOrder(%v) = %v", ids, got)
+		}
+	}
+
+	// Results still come back in request order, once each
+	byID := map[uuid.UUID]string{a: "a", b: "b", c: "c"}
+	if got := inRequestOrder([]uuid.UUID{c, a, c, b}, byID); !slices.Equal(got, []string{"c", "a", "b"}) {
+		t.Errorf("inRequestOrder = %v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+type InterviewQuestionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewInterviewQuestionRepo(pool *pgxpool.Pool) *InterviewQuestionRepo {
+	return &InterviewQuestionRepo{pool: pool}
+}
+
+const interviewQuestionColumns = `id, user_id, application_id, company, question,
+		       category, stage, notes, created_at, updated_at`
+
+func scanInterviewQuestion(row pgx.Row) (*model.InterviewQuestion, error) {
+	var q model.InterviewQuestion
+	err := row.Scan(
+		&q.ID, &q.UserID, &q.ApplicationID, &q.Company, &q.Question,
+		&q.Category, &q.Stage, &q.Notes, &q.CreatedAt, &q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// Create stores a new interview question
+func (r *InterviewQuestionRepo) Create(ctx context.Context, q *model.InterviewQuestion) (*model.InterviewQuestion, error) {
+	out, err := scanInterviewQuestion(r.pool.QueryRow(ctx, `
+		INSERT INTO interview_questions (user_id, application_id, company, question, category, stage, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+interviewQuestionColumns,
+		q.UserID, q.ApplicationID, q.Company, q.Question, q.Category, q.Stage, q.Notes,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating interview question: %w", err)
+	}
+	return out, nil
+}
+
+// ListByUser returns all of a user's interview questions, newest first
+func (r *InterviewQuestionRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.InterviewQuestion, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+interviewQuestionColumns+`
+		FROM interview_questions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing interview questions: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []model.InterviewQuestion
+	for rows.Next() {
+		q, err := scanInterviewQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning interview question: %w", err)
+		}
+		questions = append(questions, *q)
+	}
+	return questions, rows.Err()
+}
+
+// ListByCompanyNormalized returns a user's interview questions for a company,
+// newest first, matching company names the same way as
+// ContactRepo.ListByCompanyNormalized.
+func (r *InterviewQuestionRepo) ListByCompanyNormalized(ctx context.Context, userID uuid.UUID, company string) ([]model.InterviewQuestion, error) {
+	key := model.NormalizeCompanyName(company)
+	if key == "" {
+		return nil, nil
+	}
+	firstWord := strings.Fields(key)[0]
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+interviewQuestionColumns+`
+		FROM interview_questions
+		WHERE user_id = $1 AND LOWER(company) LIKE '%' || $2 || '%'
+		ORDER BY created_at DESC
+	`, userID, firstWord)
+	if err != nil {
+		return nil, fmt.Errorf("listing interview questions by company: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []model.InterviewQuestion
+	for rows.Next() {
+		q, err := scanInterviewQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning interview question: %w", err)
+		}
+		if model.NormalizeCompanyName(q.Company) == key {
+			questions = append(questions, *q)
+		}
+	}
+	return questions, rows.Err()
+}
+
+// Update saves the editable fields of a user's interview question. Returns
+// nil if no such question exists.
+func (r *InterviewQuestionRepo) Update(ctx context.Context, q *model.InterviewQuestion) (*model.InterviewQuestion, error) {
+	out, err := scanInterviewQuestion(r.pool.QueryRow(ctx, `
+		UPDATE interview_questions
+		SET question = $3, category = $4, stage = $5, notes = $6, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+interviewQuestionColumns,
+		q.ID, q.UserID, q.Question, q.Category, q.Stage, q.Notes,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating interview question: %w", err)
+	}
+	return out, nil
+}
+
+// Delete removes a user's interview question. Returns false if no such
+// question exists.
+func (r *InterviewQuestionRepo) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM interview_questions WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("deleting interview question: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
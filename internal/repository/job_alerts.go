@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+type JobAlertRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewJobAlertRepo(pool *pgxpool.Pool) *JobAlertRepo {
+	return &JobAlertRepo{pool: pool}
+}
+
+// Create stores a new job alert
+func (r *JobAlertRepo) Create(ctx context.Context, a *model.JobAlert) (*model.JobAlert, error) {
+	var out model.JobAlert
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO job_alerts (user_id, name, keywords, location, salary_min)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, name, keywords, location, salary_min, created_at
+	`, a.UserID, a.Name, a.Keywords, a.Location, a.SalaryMin,
+	).Scan(
+		&out.ID, &out.UserID, &out.Name, &out.Keywords, &out.Location, &out.SalaryMin, &out.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating job alert: %w", err)
+	}
+	return &out, nil
+}
+
+// ListByUser returns a user's job alerts, oldest first
+func (r *JobAlertRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.JobAlert, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, name, keywords, location, salary_min, created_at
+		FROM job_alerts
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing job alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []model.JobAlert
+	for rows.Next() {
+		var a model.JobAlert
+		if err := rows.Scan(
+			&a.ID, &a.UserID, &a.Name, &a.Keywords, &a.Location, &a.SalaryMin, &a.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning job alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, nil
+}
+
+// CountByUser returns how many job alerts a user has
+func (r *JobAlertRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM job_alerts WHERE user_id = $1`, userID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("counting job alerts: %w", err)
+	}
+	return n, nil
+}
+
+// Delete removes a user's job alert. Feed jobs it surfaced stay in the feed
+// with their alert marker cleared. Returns false if no such alert exists.
+func (r *JobAlertRepo) Delete(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM job_alerts WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("deleting job alert: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -11,26 +13,32 @@ import (
 )
 
 type JobRepo struct {
-	pool *pgxpool.Pool
+	pool  *pgxpool.Pool
+	reads *ReadPool // list queries, on the replica when there is one
 }
 
-func NewJobRepo(pool *pgxpool.Pool) *JobRepo {
-	return &JobRepo{pool: pool}
+func NewJobRepo(pool *pgxpool.Pool, reads *ReadPool) *JobRepo {
+	return &JobRepo{pool: pool, reads: reads}
 }
 
-// List returns all jobs for a user, with optional filters
+// List returns all jobs for a user, with optional filters and sort
 func (r *JobRepo) List(ctx context.Context, userID uuid.UUID, filter JobFilter) ([]model.Job, error) {
 	query := `
 		SELECT id, user_id, external_id, source, title, company, location,
 		       salary_range, job_type, description, tags, required_skills,
 		       preferred_skills, apply_url, hiring_email, company_logo,
-		       company_color, match_score, bookmarked, status, created_at, updated_at
+		       company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
 		FROM jobs
 		WHERE user_id = $1
 	`
 	args := []any{userID}
 	argIdx := 2
 
+	if filter.Archived {
+		query += " AND archived_at IS NOT NULL"
+	} else {
+		query += " AND archived_at IS NULL"
+	}
 	if filter.BookmarkedOnly {
 		query += fmt.Sprintf(" AND bookmarked = $%d", argIdx)
 		args = append(args, true)
@@ -41,15 +49,25 @@ func (r *JobRepo) List(ctx context.Context, userID uuid.UUID, filter JobFilter)
 		args = append(args, "%"+filter.Search+"%")
 		argIdx++
 	}
+	switch filter.Tracking {
+	case TrackingApplied:
+		query += " AND " + appliedCondition
+	case TrackingWatching:
+		query += " AND NOT " + appliedCondition
+	}
 	if filter.LocationType == "remote" {
 		query += " AND LOWER(location) LIKE '%remote%'"
 	} else if filter.LocationType == "onsite" {
 		query += " AND LOWER(location) NOT LIKE '%remote%'"
 	}
 
-	query += " ORDER BY match_score DESC, created_at DESC"
+	order, ok := jobSortOrders[filter.Sort]
+	if !ok {
+		order = jobSortOrders[SortMatch]
+	}
+	query += " ORDER BY " + order
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.reads.Query(ctx, userID, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing jobs: %w", err)
 	}
@@ -63,7 +81,7 @@ func (r *JobRepo) List(ctx context.Context, userID uuid.UUID, filter JobFilter)
 			&j.Location, &j.SalaryRange, &j.JobType, &j.Description, &j.Tags,
 			&j.RequiredSkills, &j.PreferredSkills, &j.ApplyURL, &j.HiringEmail,
 			&j.CompanyLogo, &j.CompanyColor, &j.MatchScore, &j.Bookmarked,
-			&j.Status,
+			&j.Status, &j.AppliedExternally, &j.ArchivedAt,
 			&j.CreatedAt, &j.UpdatedAt,
 		)
 		if err != nil {
@@ -82,14 +100,14 @@ func (r *JobRepo) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID)
 		SELECT id, user_id, external_id, source, title, company, location,
 		       salary_range, job_type, description, tags, required_skills,
 		       preferred_skills, apply_url, hiring_email, company_logo,
-		       company_color, match_score, bookmarked, status, created_at, updated_at
+		       company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
 		FROM jobs
 		WHERE id = $1 AND user_id = $2
 	`, id, userID).Scan(
 		&j.ID, &j.UserID, &j.ExternalID, &j.Source, &j.Title, &j.Company,
 		&j.Location, &j.SalaryRange, &j.JobType, &j.Description, &j.Tags,
 		&j.RequiredSkills, &j.PreferredSkills, &j.ApplyURL, &j.HiringEmail,
-		&j.CompanyLogo, &j.CompanyColor, &j.MatchScore, &j.Bookmarked, &j.Status,
+		&j.CompanyLogo, &j.CompanyColor, &j.MatchScore, &j.Bookmarked, &j.Status, &j.AppliedExternally, &j.ArchivedAt,
 		&j.CreatedAt, &j.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
@@ -108,23 +126,23 @@ func (r *JobRepo) Create(ctx context.Context, j *model.Job) (*model.Job, error)
 		INSERT INTO jobs (user_id, external_id, source, title, company, location,
 		                  salary_range, job_type, description, tags, required_skills,
 		                  preferred_skills, apply_url, hiring_email, company_logo,
-		                  company_color, match_score, bookmarked, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		                  company_color, match_score, bookmarked, status, applied_externally)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id, user_id, external_id, source, title, company, location,
 		          salary_range, job_type, description, tags, required_skills,
 		          preferred_skills, apply_url, hiring_email, company_logo,
-		          company_color, match_score, bookmarked, status, created_at, updated_at
+		          company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
 	`, j.UserID, j.ExternalID, j.Source, j.Title, j.Company, j.Location,
 		j.SalaryRange, j.JobType, j.Description, j.Tags, j.RequiredSkills,
 		j.PreferredSkills, j.ApplyURL, j.HiringEmail, j.CompanyLogo,
-		j.CompanyColor, j.MatchScore, j.Bookmarked, j.Status,
+		j.CompanyColor, j.MatchScore, j.Bookmarked, j.Status, j.AppliedExternally,
 	).Scan(
 		&created.ID, &created.UserID, &created.ExternalID, &created.Source,
 		&created.Title, &created.Company, &created.Location, &created.SalaryRange,
 		&created.JobType, &created.Description, &created.Tags, &created.RequiredSkills,
 		&created.PreferredSkills, &created.ApplyURL, &created.HiringEmail,
 		&created.CompanyLogo, &created.CompanyColor, &created.MatchScore,
-		&created.Bookmarked, &created.Status, &created.CreatedAt, &created.UpdatedAt,
+		&created.Bookmarked, &created.Status, &created.AppliedExternally, &created.ArchivedAt, &created.CreatedAt, &created.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating job: %w", err)
@@ -132,39 +150,242 @@ func (r *JobRepo) Create(ctx context.Context, j *model.Job) (*model.Job, error)
 	return &created, nil
 }
 
-// Update updates a job
-func (r *JobRepo) Update(ctx context.Context, j *model.Job) (*model.Job, error) {
+// Duplicate copies a job into a new, unarchived "saved" job with the bookmark
+// cleared. The copy is the user's own variant, so it drops the source listing
+// (external ID, source) rather than sharing it. Returns nil if the job isn't
+// found.
+func (r *JobRepo) Duplicate(ctx context.Context, id, userID uuid.UUID) (*model.Job, error) {
+	var j model.Job
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO jobs (user_id, title, company, location,
+		                  salary_range, job_type, description, tags, required_skills,
+		                  preferred_skills, apply_url, hiring_email, company_logo,
+		                  company_color, match_score, bookmarked, status)
+		SELECT user_id, title, company, location,
+		       salary_range, job_type, description, tags, required_skills,
+		       preferred_skills, apply_url, hiring_email, company_logo,
+		       company_color, match_score, false, 'saved'
+		FROM jobs
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, external_id, source, title, company, location,
+		          salary_range, job_type, description, tags, required_skills,
+		          preferred_skills, apply_url, hiring_email, company_logo,
+		          company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
+	`, id, userID).Scan(
+		&j.ID, &j.UserID, &j.ExternalID, &j.Source, &j.Title, &j.Company,
+		&j.Location, &j.SalaryRange, &j.JobType, &j.Description, &j.Tags,
+		&j.RequiredSkills, &j.PreferredSkills, &j.ApplyURL, &j.HiringEmail,
+		&j.CompanyLogo, &j.CompanyColor, &j.MatchScore, &j.Bookmarked, &j.Status, &j.AppliedExternally, &j.ArchivedAt,
+		&j.CreatedAt, &j.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("duplicating job: %w", err)
+	}
+	return &j, nil
+}
+
+// Update updates a job. With expectedUpdatedAt set, the update only applies
+// if the job hasn't changed since then, and returns ErrStaleUpdate otherwise.
+func (r *JobRepo) Update(ctx context.Context, j *model.Job, expectedUpdatedAt *time.Time) (*model.Job, error) {
 	var updated model.Job
 	err := r.pool.QueryRow(ctx, `
 		UPDATE jobs
 		SET title = $3, company = $4, location = $5, salary_range = $6,
 		    job_type = $7, description = $8, tags = $9, required_skills = $10,
 		    preferred_skills = $11, apply_url = $12, hiring_email = $13,
-		    match_score = $14, bookmarked = $15, status = $16, updated_at = now()
-		WHERE id = $1 AND user_id = $2
+		    match_score = $14, bookmarked = $15, status = $16, applied_externally = $17,
+		    updated_at = now()
+		WHERE id = $1 AND user_id = $2 AND ($18::timestamptz IS NULL OR updated_at = $18)
 		RETURNING id, user_id, external_id, source, title, company, location,
 		          salary_range, job_type, description, tags, required_skills,
 		          preferred_skills, apply_url, hiring_email, company_logo,
-		          company_color, match_score, bookmarked, status, created_at, updated_at
+		          company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
 	`, j.ID, j.UserID, j.Title, j.Company, j.Location, j.SalaryRange,
 		j.JobType, j.Description, j.Tags, j.RequiredSkills, j.PreferredSkills,
 		j.ApplyURL, j.HiringEmail, j.MatchScore, j.Bookmarked,
-		j.Status,
+		j.Status, j.AppliedExternally, expectedUpdatedAt,
 	).Scan(
 		&updated.ID, &updated.UserID, &updated.ExternalID, &updated.Source,
 		&updated.Title, &updated.Company, &updated.Location, &updated.SalaryRange,
 		&updated.JobType, &updated.Description, &updated.Tags, &updated.RequiredSkills,
 		&updated.PreferredSkills, &updated.ApplyURL, &updated.HiringEmail,
 		&updated.CompanyLogo, &updated.CompanyColor, &updated.MatchScore,
-		&updated.Bookmarked, &updated.Status, &updated.CreatedAt, &updated.UpdatedAt,
+		&updated.Bookmarked, &updated.Status, &updated.AppliedExternally, &updated.ArchivedAt, &updated.CreatedAt, &updated.UpdatedAt,
 	)
+	if err == pgx.ErrNoRows && expectedUpdatedAt != nil {
+		var exists bool
+		if err := r.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM jobs WHERE id = $1 AND user_id = $2)
+		`, j.ID, j.UserID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("checking job for stale update: %w", err)
+		}
+		if exists {
+			return nil, ErrStaleUpdate
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("updating job: %w", err)
 	}
 	return &updated, nil
 }
 
-// Delete removes a job
+// PartialUpdate applies only the fields set in p, leaving the rest of the job
+// untouched. An empty patch returns the job unchanged. Returns nil if the job
+// doesn't exist, or ErrStaleUpdate if expectedUpdatedAt no longer matches,
+// whether or not the patch is empty.
+func (r *JobRepo) PartialUpdate(ctx context.Context, id, userID uuid.UUID, p *model.JobPatch, expectedUpdatedAt *time.Time) (*model.Job, error) {
+	args := []any{id, userID}
+	var sets []string
+	set := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if p.Title != nil {
+		set("title", *p.Title)
+	}
+	if p.Company != nil {
+		set("company", *p.Company)
+	}
+	if p.Location != nil {
+		set("location", *p.Location)
+	}
+	if p.SalaryRange != nil {
+		set("salary_range", *p.SalaryRange)
+	}
+	if p.JobType != nil {
+		set("job_type", *p.JobType)
+	}
+	if p.Description != nil {
+		set("description", *p.Description)
+	}
+	if p.Tags != nil {
+		set("tags", *p.Tags)
+	}
+	if p.RequiredSkills != nil {
+		set("required_skills", *p.RequiredSkills)
+	}
+	if p.PreferredSkills != nil {
+		set("preferred_skills", *p.PreferredSkills)
+	}
+	if p.ApplyURL != nil {
+		set("apply_url", *p.ApplyURL)
+	}
+	if p.HiringEmail != nil {
+		set("hiring_email", *p.HiringEmail)
+	}
+	if p.MatchScore != nil {
+		set("match_score", *p.MatchScore)
+	}
+	if p.Bookmarked != nil {
+		set("bookmarked", *p.Bookmarked)
+	}
+	if p.Status != nil {
+		set("status", *p.Status)
+	}
+	if p.AppliedExternally != nil {
+		set("applied_externally", *p.AppliedExternally)
+	}
+
+	if len(sets) == 0 {
+		job, err := r.FindByID(ctx, id, userID)
+		if err != nil || job == nil {
+			return job, err
+		}
+		if expectedUpdatedAt != nil && !job.UpdatedAt.Equal(*expectedUpdatedAt) {
+			return nil, ErrStaleUpdate
+		}
+		return job, nil
+	}
+
+	args = append(args, expectedUpdatedAt)
+	query := fmt.Sprintf(`
+		UPDATE jobs
+		SET %s, updated_at = now()
+		WHERE id = $1 AND user_id = $2 AND ($%d::timestamptz IS NULL OR updated_at = $%d)
+		RETURNING id, user_id, external_id, source, title, company, location,
+		          salary_range, job_type, description, tags, required_skills,
+		          preferred_skills, apply_url, hiring_email, company_logo,
+		          company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
+	`, strings.Join(sets, ", "), len(args), len(args))
+
+	var updated model.Job
+	err := r.pool.QueryRow(ctx, query, args...).Scan(
+		&updated.ID, &updated.UserID, &updated.ExternalID, &updated.Source,
+		&updated.Title, &updated.Company, &updated.Location, &updated.SalaryRange,
+		&updated.JobType, &updated.Description, &updated.Tags, &updated.RequiredSkills,
+		&updated.PreferredSkills, &updated.ApplyURL, &updated.HiringEmail,
+		&updated.CompanyLogo, &updated.CompanyColor, &updated.MatchScore,
+		&updated.Bookmarked, &updated.Status, &updated.AppliedExternally, &updated.ArchivedAt, &updated.CreatedAt, &updated.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		var exists bool
+		if err := r.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM jobs WHERE id = $1 AND user_id = $2)
+		`, id, userID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("checking job for partial update: %w", err)
+		}
+		if exists {
+			return nil, ErrStaleUpdate
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("partially updating job: %w", err)
+	}
+	return &updated, nil
+}
+
+// UpdateBranding sets a job's company logo and color, leaving either one
+// untouched if it is already set (the column-default color counts as unset)
+func (r *JobRepo) UpdateBranding(ctx context.Context, id, userID uuid.UUID, logo, color string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE jobs
+		SET company_logo = CASE WHEN company_logo = '' THEN $3 ELSE company_logo END,
+		    company_color = CASE WHEN company_color IN ('', $5) THEN COALESCE(NULLIF($4, ''), company_color) ELSE company_color END
+		WHERE id = $1 AND user_id = $2
+	`, id, userID, logo, color, model.DefaultCompanyColor)
+	if err != nil {
+		return fmt.Errorf("updating job branding: %w", err)
+	}
+	return nil
+}
+
+// Archive soft-deletes a job: it drops out of lists but keeps its application
+// history and notes, and can be restored with Unarchive
+func (r *JobRepo) Archive(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE jobs SET archived_at = now(), updated_at = now()
+		WHERE id = $1 AND user_id = $2 AND archived_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return fmt.Errorf("archiving job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("job not found")
+	}
+	return nil
+}
+
+// Unarchive restores an archived job
+func (r *JobRepo) Unarchive(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE jobs SET archived_at = NULL, updated_at = now()
+		WHERE id = $1 AND user_id = $2 AND archived_at IS NOT NULL
+	`, id, userID)
+	if err != nil {
+		return fmt.Errorf("unarchiving job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("job not found")
+	}
+	return nil
+}
+
+// Delete permanently removes a job, cascading its application history and notes
 func (r *JobRepo) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
 	result, err := r.pool.Exec(ctx, `DELETE FROM jobs WHERE id = $1 AND user_id = $2`, id, userID)
 	if err != nil {
@@ -195,18 +416,61 @@ type JobFilter struct {
 	Search        string
 	LocationType  string // "", "remote", "onsite"
 	BookmarkedOnly bool
+	Archived       bool // true lists only archived jobs; default excludes them
+	Sort           string // one of the Sort constants; "" or unknown sorts by match
+	Tracking       string // "", TrackingWatching, or TrackingApplied
+}
+
+// Tracking filters for GET /jobs: a job counts as applied once its status is
+// past "saved" or the user marked it applied externally; otherwise the user
+// is just watching it
+const (
+	TrackingWatching = "watching"
+	TrackingApplied  = "applied"
+)
+
+// appliedCondition is the SQL test for a job the user has applied to
+const appliedCondition = "(applied_externally OR status <> 'saved')"
+
+// List sort options for GET /jobs and GET /feed
+const (
+	SortMatch   = "match"   // best match first (default)
+	SortNewest  = "newest"  // most recently saved / posted first
+	SortSalary  = "salary"  // highest salary first
+	SortCompany = "company" // company name A-Z
+)
+
+// ValidSort reports whether s is a supported list sort
+func ValidSort(s string) bool {
+	_, ok := jobSortOrders[s]
+	return ok
+}
+
+// jobSalarySortKey pulls the first amount out of the free-text salary_range
+// ("$120k - $150k", "$120,000+") as a number, so saved jobs can be sorted by
+// salary; ranges with no number sort last
+const jobSalarySortKey = `(SELECT CASE WHEN m[2] <> '' THEN m[1]::numeric * 1000 ELSE m[1]::numeric END
+	FROM regexp_match(replace(salary_range, ',', ''), '(\d+(?:\.\d+)?)\s*([kK]?)') AS m)`
+
+// jobSortOrders maps each sort option to its ORDER BY clause. Only these
+// fixed strings ever reach the query.
+var jobSortOrders = map[string]string{
+	SortMatch:   "match_score DESC, created_at DESC",
+	SortNewest:  "created_at DESC",
+	SortSalary:  jobSalarySortKey + " DESC NULLS LAST, match_score DESC",
+	SortCompany: "LOWER(company) ASC, created_at DESC",
 }
 
 // ListCompanies returns aggregated company data from the user's saved jobs
 func (r *JobRepo) ListCompanies(ctx context.Context, userID uuid.UUID) ([]model.CompanySummary, error) {
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.reads.Query(ctx, userID, `
 		SELECT j.company,
 		       COALESCE(MAX(j.company_logo), '') as company_logo,
 		       COALESCE(MAX(j.company_color), '') as company_color,
 		       COUNT(*) as job_count,
 		       (SELECT COUNT(*) FROM contacts c WHERE c.user_id = $1 AND LOWER(c.company) = LOWER(j.company)) as contact_count
 		FROM jobs j
-		WHERE j.user_id = $1
+		WHERE j.user_id = $1 AND j.archived_at IS NULL
 		GROUP BY j.company
 		ORDER BY j.company ASC
 	`, userID)
@@ -226,17 +490,41 @@ func (r *JobRepo) ListCompanies(ctx context.Context, userID uuid.UUID) ([]model.
 	return companies, nil
 }
 
-// ListByCompany returns all jobs for a specific company
-func (r *JobRepo) ListByCompany(ctx context.Context, userID uuid.UUID, company string) ([]model.Job, error) {
-	rows, err := r.pool.Query(ctx, `
+// CountByTracking returns how many active (unarchived) jobs the user is
+// watching and how many they've applied to
+func (r *JobRepo) CountByTracking(ctx context.Context, userID uuid.UUID) (watching, applied int, err error) {
+	err = r.reads.QueryRow(ctx, userID, `
+		SELECT COUNT(*) FILTER (WHERE NOT `+appliedCondition+`),
+		       COUNT(*) FILTER (WHERE `+appliedCondition+`)
+		FROM jobs
+		WHERE user_id = $1 AND archived_at IS NULL
+	`, userID).Scan(&watching, &applied)
+	if err != nil {
+		return 0, 0, fmt.Errorf("counting jobs by tracking: %w", err)
+	}
+	return watching, applied, nil
+}
+
+// ListByCompanyNormalized returns the active jobs whose company matches after
+// normalization, like ContactRepo.ListByCompanyNormalized, so "Stripe, Inc."
+// jobs show on the Stripe page. SQL narrows candidates by the first word of
+// the normalized name; the exact comparison happens here.
+func (r *JobRepo) ListByCompanyNormalized(ctx context.Context, userID uuid.UUID, company string) ([]model.Job, error) {
+	key := model.NormalizeCompanyName(company)
+	if key == "" {
+		return nil, nil
+	}
+	firstWord := strings.Fields(key)[0]
+
+	rows, err := r.reads.Query(ctx, userID, `
 		SELECT id, user_id, external_id, source, title, company, location,
 		       salary_range, job_type, description, tags, required_skills,
 		       preferred_skills, apply_url, hiring_email, company_logo,
-		       company_color, match_score, bookmarked, status, created_at, updated_at
+		       company_color, match_score, bookmarked, status, applied_externally, archived_at, created_at, updated_at
 		FROM jobs
-		WHERE user_id = $1 AND LOWER(company) = LOWER($2)
+		WHERE user_id = $1 AND LOWER(company) LIKE '%' || $2 || '%' AND archived_at IS NULL
 		ORDER BY created_at DESC
-	`, userID, company)
+	`, userID, firstWord)
 	if err != nil {
 		return nil, fmt.Errorf("listing jobs by company: %w", err)
 	}
@@ -250,21 +538,27 @@ func (r *JobRepo) ListByCompany(ctx context.Context, userID uuid.UUID, company s
 			&j.Location, &j.SalaryRange, &j.JobType, &j.Description, &j.Tags,
 			&j.RequiredSkills, &j.PreferredSkills, &j.ApplyURL, &j.HiringEmail,
 			&j.CompanyLogo, &j.CompanyColor, &j.MatchScore, &j.Bookmarked,
-			&j.Status,
+			&j.Status, &j.AppliedExternally, &j.ArchivedAt,
 			&j.CreatedAt, &j.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning job row: %w", err)
 		}
-		jobs = append(jobs, j)
+		if model.NormalizeCompanyName(j.Company) == key {
+			jobs = append(jobs, j)
+		}
 	}
-	return jobs, nil
+	return jobs, rows.Err()
 }
 
-// UpdateStatus updates only the status field of a job
+// UpdateStatus updates only the status field of a job. updated_at is left
+// alone when the status already matches, so syncing an unchanged status
+// doesn't make clients' copies look stale.
 func (r *JobRepo) UpdateStatus(ctx context.Context, jobID, userID uuid.UUID, status string) error {
 	result, err := r.pool.Exec(ctx,
-		`UPDATE jobs SET status = $1, updated_at = now()
+		`UPDATE jobs
+		 SET status = $1,
+		     updated_at = CASE WHEN status = $1 THEN updated_at ELSE now() END
 		 WHERE id = $2 AND user_id = $3`,
 		status, jobID, userID,
 	)
@@ -2,22 +2,32 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/yourusername/hireiq-api/internal/model"
 )
 
 // ---- Notes ----
 
+// Note errors callers can branch on
+var (
+	ErrNoteLimitReached = errors.New("note limit reached for this job")
+	ErrNoteNotFound     = errors.New("note not found")
+)
+
 type NoteRepo struct {
-	pool *pgxpool.Pool
+	pool      *pgxpool.Pool
+	maxPerJob int // 0 = unlimited
 }
 
-func NewNoteRepo(pool *pgxpool.Pool) *NoteRepo {
-	return &NoteRepo{pool: pool}
+func NewNoteRepo(pool *pgxpool.Pool, maxPerJob int) *NoteRepo {
+	return &NoteRepo{pool: pool, maxPerJob: maxPerJob}
 }
 
 func (r *NoteRepo) ListByJob(ctx context.Context, userID, jobID uuid.UUID) ([]model.Note, error) {
@@ -43,13 +53,49 @@ func (r *NoteRepo) ListByJob(ctx context.Context, userID, jobID uuid.UUID) ([]mo
 	return notes, nil
 }
 
+// ListByUser returns all of a user's notes across jobs, oldest first
+func (r *NoteRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.Note, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, job_id, content, created_at
+		FROM notes
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing user notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []model.Note
+	for rows.Next() {
+		var n model.Note
+		if err := rows.Scan(&n.ID, &n.UserID, &n.JobID, &n.Content, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning note: %w", err)
+		}
+		notes = append(notes, n)
+	}
+	return notes, nil
+}
+
+// Create adds a note to a job. Returns ErrNoteLimitReached once the job has
+// maxPerJob notes; the count and insert are one statement so concurrent
+// creates can't overshoot by much.
 func (r *NoteRepo) Create(ctx context.Context, userID, jobID uuid.UUID, content string) (*model.Note, error) {
+	limit := r.maxPerJob
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+
 	var n model.Note
 	err := r.pool.QueryRow(ctx, `
 		INSERT INTO notes (user_id, job_id, content)
-		VALUES ($1, $2, $3)
+		SELECT $1, $2, $3
+		WHERE (SELECT COUNT(*) FROM notes WHERE user_id = $1 AND job_id = $2) < $4
 		RETURNING id, user_id, job_id, content, created_at
-	`, userID, jobID, content).Scan(&n.ID, &n.UserID, &n.JobID, &n.Content, &n.CreatedAt)
+	`, userID, jobID, content, limit).Scan(&n.ID, &n.UserID, &n.JobID, &n.Content, &n.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNoteLimitReached
+	}
 	if err != nil {
 		return nil, fmt.Errorf("creating note: %w", err)
 	}
@@ -62,7 +108,7 @@ func (r *NoteRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
 		return fmt.Errorf("deleting note: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("note not found")
+		return ErrNoteNotFound
 	}
 	return nil
 }
@@ -107,7 +153,7 @@ func NewContactRepo(pool *pgxpool.Pool) *ContactRepo {
 func (r *ContactRepo) List(ctx context.Context, userID uuid.UUID, search string) ([]model.Contact, error) {
 	query := `
 		SELECT id, user_id, name, company, role, connection, phone, email,
-		       tip, enriched, enriched_data, created_at, updated_at
+		       tip, enriched, enriched_data, job_id, created_at, updated_at
 		FROM contacts
 		WHERE user_id = $1
 	`
@@ -132,7 +178,7 @@ func (r *ContactRepo) List(ctx context.Context, userID uuid.UUID, search string)
 		if err := rows.Scan(
 			&c.ID, &c.UserID, &c.Name, &c.Company, &c.Role, &c.Connection,
 			&c.Phone, &c.Email, &c.Tip, &c.Enriched, &c.EnrichedData,
-			&c.CreatedAt, &c.UpdatedAt,
+			&c.JobID, &c.CreatedAt, &c.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scanning contact: %w", err)
 		}
@@ -144,15 +190,15 @@ func (r *ContactRepo) List(ctx context.Context, userID uuid.UUID, search string)
 func (r *ContactRepo) Create(ctx context.Context, c *model.Contact) (*model.Contact, error) {
 	var created model.Contact
 	err := r.pool.QueryRow(ctx, `
-		INSERT INTO contacts (user_id, name, company, role, connection, phone, email, tip)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO contacts (user_id, name, company, role, connection, phone, email, tip, job_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, user_id, name, company, role, connection, phone, email,
-		          tip, enriched, enriched_data, created_at, updated_at
-	`, c.UserID, c.Name, c.Company, c.Role, c.Connection, c.Phone, c.Email, c.Tip,
+		          tip, enriched, enriched_data, job_id, created_at, updated_at
+	`, c.UserID, c.Name, c.Company, c.Role, c.Connection, c.Phone, c.Email, c.Tip, c.JobID,
 	).Scan(
 		&created.ID, &created.UserID, &created.Name, &created.Company, &created.Role,
 		&created.Connection, &created.Phone, &created.Email, &created.Tip,
-		&created.Enriched, &created.EnrichedData, &created.CreatedAt, &created.UpdatedAt,
+		&created.Enriched, &created.EnrichedData, &created.JobID, &created.CreatedAt, &created.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating contact: %w", err)
@@ -168,13 +214,13 @@ func (r *ContactRepo) Update(ctx context.Context, c *model.Contact) (*model.Cont
 		    phone = $7, email = $8, tip = $9, updated_at = now()
 		WHERE id = $1 AND user_id = $2
 		RETURNING id, user_id, name, company, role, connection, phone, email,
-		          tip, enriched, enriched_data, created_at, updated_at
+		          tip, enriched, enriched_data, job_id, created_at, updated_at
 	`, c.ID, c.UserID, c.Name, c.Company, c.Role, c.Connection,
 		c.Phone, c.Email, c.Tip,
 	).Scan(
 		&updated.ID, &updated.UserID, &updated.Name, &updated.Company, &updated.Role,
 		&updated.Connection, &updated.Phone, &updated.Email, &updated.Tip,
-		&updated.Enriched, &updated.EnrichedData, &updated.CreatedAt, &updated.UpdatedAt,
+		&updated.Enriched, &updated.EnrichedData, &updated.JobID, &updated.CreatedAt, &updated.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("updating contact: %w", err)
@@ -193,11 +239,34 @@ func (r *ContactRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
 	return nil
 }
 
+// FindByEmail returns the user's contact with the given email (case-insensitive), or nil
+func (r *ContactRepo) FindByEmail(ctx context.Context, userID uuid.UUID, email string) (*model.Contact, error) {
+	var c model.Contact
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, user_id, name, company, role, connection, phone, email,
+		       tip, enriched, enriched_data, job_id, created_at, updated_at
+		FROM contacts
+		WHERE user_id = $1 AND email != '' AND LOWER(email) = LOWER($2)
+		LIMIT 1
+	`, userID, email).Scan(
+		&c.ID, &c.UserID, &c.Name, &c.Company, &c.Role, &c.Connection,
+		&c.Phone, &c.Email, &c.Tip, &c.Enriched, &c.EnrichedData,
+		&c.JobID, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("finding contact by email: %w", err)
+	}
+	return &c, nil
+}
+
 // ListByCompany returns contacts for a specific company
 func (r *ContactRepo) ListByCompany(ctx context.Context, userID uuid.UUID, company string) ([]model.Contact, error) {
 	rows, err := r.pool.Query(ctx, `
 		SELECT id, user_id, name, company, role, connection, phone, email,
-		       tip, enriched, enriched_data, created_at, updated_at
+		       tip, enriched, enriched_data, job_id, created_at, updated_at
 		FROM contacts
 		WHERE user_id = $1 AND LOWER(company) = LOWER($2)
 		ORDER BY name ASC
@@ -213,7 +282,76 @@ func (r *ContactRepo) ListByCompany(ctx context.Context, userID uuid.UUID, compa
 		if err := rows.Scan(
 			&c.ID, &c.UserID, &c.Name, &c.Company, &c.Role, &c.Connection,
 			&c.Phone, &c.Email, &c.Tip, &c.Enriched, &c.EnrichedData,
-			&c.CreatedAt, &c.UpdatedAt,
+			&c.JobID, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning contact: %w", err)
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, nil
+}
+
+// ListByCompanyNormalized returns contacts whose company matches after
+// normalization (case, punctuation, "Inc"/"LLC" suffixes), so "Acme, Inc."
+// finds contacts imported as "ACME". SQL narrows candidates by the first word
+// of the normalized name; the exact comparison happens here.
+func (r *ContactRepo) ListByCompanyNormalized(ctx context.Context, userID uuid.UUID, company string) ([]model.Contact, error) {
+	key := model.NormalizeCompanyName(company)
+	if key == "" {
+		return nil, nil
+	}
+	firstWord := strings.Fields(key)[0]
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, name, company, role, connection, phone, email,
+		       tip, enriched, enriched_data, job_id, created_at, updated_at
+		FROM contacts
+		WHERE user_id = $1 AND LOWER(company) LIKE '%' || $2 || '%'
+		ORDER BY name ASC
+	`, userID, firstWord)
+	if err != nil {
+		return nil, fmt.Errorf("listing contacts by normalized company: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []model.Contact
+	for rows.Next() {
+		var c model.Contact
+		if err := rows.Scan(
+			&c.ID, &c.UserID, &c.Name, &c.Company, &c.Role, &c.Connection,
+			&c.Phone, &c.Email, &c.Tip, &c.Enriched, &c.EnrichedData,
+			&c.JobID, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning contact: %w", err)
+		}
+		if model.NormalizeCompanyName(c.Company) == key {
+			contacts = append(contacts, c)
+		}
+	}
+	return contacts, nil
+}
+
+// ListByJobID returns contacts explicitly linked to a job (e.g. its hiring contact)
+func (r *ContactRepo) ListByJobID(ctx context.Context, userID, jobID uuid.UUID) ([]model.Contact, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, name, company, role, connection, phone, email,
+		       tip, enriched, enriched_data, job_id, created_at, updated_at
+		FROM contacts
+		WHERE user_id = $1 AND job_id = $2
+		ORDER BY name ASC
+	`, userID, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("listing contacts by job: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []model.Contact
+	for rows.Next() {
+		var c model.Contact
+		if err := rows.Scan(
+			&c.ID, &c.UserID, &c.Name, &c.Company, &c.Role, &c.Connection,
+			&c.Phone, &c.Email, &c.Tip, &c.Enriched, &c.EnrichedData,
+			&c.JobID, &c.CreatedAt, &c.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scanning contact: %w", err)
 		}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+type NotificationRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationRepo(pool *pgxpool.Pool) *NotificationRepo {
+	return &NotificationRepo{pool: pool}
+}
+
+// Create inserts a notification for the user
+func (r *NotificationRepo) Create(ctx context.Context, n *model.Notification) (*model.Notification, error) {
+	var created model.Notification
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO notifications (user_id, type, title, body, application_id, job_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, type, title, body, application_id, job_id, read_at, created_at
+	`, n.UserID, n.Type, n.Title, n.Body, n.ApplicationID, n.JobID).Scan(
+		&created.ID, &created.UserID, &created.Type, &created.Title, &created.Body,
+		&created.ApplicationID, &created.JobID, &created.ReadAt, &created.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating notification: %w", err)
+	}
+	return &created, nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// readYourWritesWindow is how long after a user's write their reads stay
+	// on the primary, comfortably longer than normal replication lag
+	readYourWritesWindow = 10 * time.Second
+
+	// replicaRetryAfter is how long reads skip the replica after a query on
+	// it fails, so an outage doesn't cost every request a failed attempt
+	replicaRetryAfter = 30 * time.Second
+
+	// maxRecentWriters bounds the read-your-writes map before it's swept
+	maxRecentWriters = 10000
+)
+
+// ReadPool routes feed and job list reads to a read replica. A user's reads
+// go to the primary for readYourWritesWindow after they write, so a list
+// fetched right after a change includes it, and any read falls back to the
+// primary when the replica query fails. Without a replica every read uses the
+// primary.
+type ReadPool struct {
+	primary *pgxpool.Pool
+	replica *pgxpool.Pool // nil without a replica
+
+	mu          sync.Mutex
+	recent      map[uuid.UUID]time.Time // user -> last write
+	replicaDown time.Time               // skip the replica until then
+}
+
+// NewReadPool creates a router over the primary and an optional replica
+func NewReadPool(primary, replica *pgxpool.Pool) *ReadPool {
+	if replica == primary {
+		replica = nil
+	}
+	return &ReadPool{primary: primary, replica: replica, recent: make(map[uuid.UUID]time.Time)}
+}
+
+// NoteWrite records that the user just wrote, keeping their reads on the
+// primary until the replica has caught up
+func (p *ReadPool) NoteWrite(userID uuid.UUID) {
+	if p.replica == nil {
+		return
+	}
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.recent[userID]; !ok && len(p.recent) >= maxRecentWriters {
+		for id, at := range p.recent {
+			if now.Sub(at) >= readYourWritesWindow {
+				delete(p.recent, id)
+			}
+		}
+	}
+	p.recent[userID] = now
+}
+
+// pick returns the pool a user's read should use, and whether it's the replica
+func (p *ReadPool) pick(userID uuid.UUID) (*pgxpool.Pool, bool) {
+	if p.replica == nil {
+		return p.primary, false
+	}
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if now.Before(p.replicaDown) {
+		return p.primary, false
+	}
+	if at, ok := p.recent[userID]; ok && now.Sub(at) < readYourWritesWindow {
+		return p.primary, false
+	}
+	return p.replica, true
+}
+
+// replicaFailed logs a failed replica query and rests the replica briefly.
+// Returns false when the caller gave up, so there's no point retrying.
+func (p *ReadPool) replicaFailed(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	log.Warn().Err(err).Msg("Read replica query failed, using the primary")
+	p.mu.Lock()
+	p.replicaDown = time.Now().Add(replicaRetryAfter)
+	p.mu.Unlock()
+	return true
+}
+
+// Query runs a read for the user, on the primary if the replica fails.
+// Reads not tied to one user pass uuid.Nil.
+func (p *ReadPool) Query(ctx context.Context, userID uuid.UUID, sql string, args ...any) (pgx.Rows, error) {
+	pool, onReplica := p.pick(userID)
+	rows, err := pool.Query(ctx, sql, args...)
+	if err != nil && onReplica && p.replicaFailed(ctx, err) {
+		return p.primary.Query(ctx, sql, args...)
+	}
+	return rows, err
+}
+
+// QueryRow runs a single-row read for the user, on the primary if the replica
+// fails. pgx.ErrNoRows is a result, not a failure, and isn't retried.
+func (p *ReadPool) QueryRow(ctx context.Context, userID uuid.UUID, sql string, args ...any) pgx.Row {
+	pool, onReplica := p.pick(userID)
+	row := pool.QueryRow(ctx, sql, args...)
+	if !onReplica {
+		return row
+	}
+	return &fallbackRow{ctx: ctx, pool: p, row: row, sql: sql, args: args}
+}
+
+type fallbackRow struct {
+	ctx  context.Context
+	pool *ReadPool
+	row  pgx.Row
+	sql  string
+	args []any
+}
+
+func (r *fallbackRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || !r.pool.replicaFailed(r.ctx, err) {
+		return err
+	}
+	return r.pool.primary.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestReadPoolPick(t *testing.T) {
+	// pick only compares pools, so unconnected ones will do
+	primary, replica := &pgxpool.Pool{}, &pgxpool.Pool{}
+	writer, reader := uuid.New(), uuid.New()
+
+	p := NewReadPool(primary, replica)
+	p.NoteWrite(writer)
+
+	if pool, _ := p.pick(reader); pool != replica {
+		t.Error("a user who hasn't written should read from the replica")
+	}
+	if pool, _ := p.pick(writer); pool != primary {
+		t.Error("a user who just wrote should read from the primary")
+	}
+
+	p.recent[writer] = time.Now().Add(-readYourWritesWindow)
+	if pool, _ := p.pick(writer); pool != replica {
+		t.Error("reads should return to the replica once the window passes")
+	}
+
+	p.replicaDown = time.Now().Add(time.Minute)
+	if pool, _ := p.pick(reader); pool != primary {
+		t.Error("reads should skip the replica after it failed")
+	}
+
+	if pool, _ := NewReadPool(primary, primary).pick(reader); pool != primary {
+		t.Error("without a replica every read should use the primary")
+	}
+}
@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/skills"
 )
 
 type UserRepo struct {
@@ -21,7 +25,7 @@ func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
 
 // userColumns is the shared column list for all user queries
 const userColumns = `id, firebase_uid, email, name, bio, location, work_style,
-       salary_min, salary_max, skills, target_roles, github_url,
+       salary_min, salary_max, salary_currency, skills, target_roles, target_companies, remotive_categories, github_url,
        experience, education, certifications, languages, volunteer,
        created_at, updated_at`
 
@@ -32,7 +36,7 @@ func scanUser(row pgx.Row) (*model.User, error) {
 
 	err := row.Scan(
 		&u.ID, &u.FirebaseUID, &u.Email, &u.Name, &u.Bio, &u.Location,
-		&u.WorkStyle, &u.SalaryMin, &u.SalaryMax, &u.Skills, &u.TargetRoles, &u.GithubURL,
+		&u.WorkStyle, &u.SalaryMin, &u.SalaryMax, &u.SalaryCurrency, &u.Skills, &u.TargetRoles, &u.TargetCompanies, &u.RemotiveCategories, &u.GithubURL,
 		&expJSON, &eduJSON, &certJSON, &langJSON, &volJSON,
 		&u.CreatedAt, &u.UpdatedAt,
 	)
@@ -61,6 +65,12 @@ func scanUser(row pgx.Row) (*model.User, error) {
 	if u.TargetRoles == nil {
 		u.TargetRoles = []string{}
 	}
+	if u.TargetCompanies == nil {
+		u.TargetCompanies = []string{}
+	}
+	if u.RemotiveCategories == nil {
+		u.RemotiveCategories = []string{}
+	}
 	if u.Experience == nil {
 		u.Experience = []model.Experience{}
 	}
@@ -132,41 +142,171 @@ func (r *UserRepo) Create(ctx context.Context, firebaseUID, email, name string)
 }
 
 // Update updates a user's profile fields
-func (r *UserRepo) Update(ctx context.Context, id uuid.UUID, updates *model.User) (*model.User, error) {
+// Target roles and companies are normalized (trimmed, deduplicated) before saving.
+// A nil RemotiveCategories leaves the stored categories as they are.
+// With expectedUpdatedAt set, the update only applies if the profile hasn't
+// changed since then, and returns ErrStaleUpdate otherwise.
+func (r *UserRepo) Update(ctx context.Context, id uuid.UUID, updates *model.User, expectedUpdatedAt *time.Time) (*model.User, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	u, err := updateUserTx(ctx, tx, id, updates, expectedUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return u, nil
+}
+
+// UpdateWithSkills applies Update and UpdateSkills together in one
+// transaction, so a stale expectedUpdatedAt leaves both untouched
+func (r *UserRepo) UpdateWithSkills(ctx context.Context, id uuid.UUID, updates *model.User, expectedUpdatedAt *time.Time) (*model.User, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	u, err := updateUserTx(ctx, tx, id, updates, expectedUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	u.Skills, err = updateSkillsTx(ctx, tx, id, updates.Skills)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return u, nil
+}
+
+// updateUserTx runs Update inside the caller's transaction
+func updateUserTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, updates *model.User, expectedUpdatedAt *time.Time) (*model.User, error) {
+	targetRoles := model.NormalizeStringList(updates.TargetRoles)
+	targetCompanies := model.NormalizeStringList(updates.TargetCompanies)
 	expJSON, _ := json.Marshal(updates.Experience)
 	eduJSON, _ := json.Marshal(updates.Education)
 	certJSON, _ := json.Marshal(updates.Certifications)
 	langJSON, _ := json.Marshal(updates.Languages)
 	volJSON, _ := json.Marshal(updates.Volunteer)
 
-	row := r.pool.QueryRow(ctx, `
+	row := tx.QueryRow(ctx, `
 		UPDATE users
 		SET name = $2, bio = $3, location = $4, work_style = $5,
 		    salary_min = $6, salary_max = $7, target_roles = $8, github_url = $9,
 		    experience = $10, education = $11, certifications = $12,
 		    languages = $13, volunteer = $14,
+		    salary_currency = COALESCE(NULLIF($15, ''), salary_currency),
+		    target_companies = $16,
+		    remotive_categories = COALESCE($18, remotive_categories),
 		    updated_at = now()
-		WHERE id = $1
+		WHERE id = $1 AND ($17::timestamptz IS NULL OR updated_at = $17)
 		RETURNING `+userColumns+`
 	`, id, updates.Name, updates.Bio, updates.Location, updates.WorkStyle,
-		updates.SalaryMin, updates.SalaryMax, updates.TargetRoles, updates.GithubURL,
+		updates.SalaryMin, updates.SalaryMax, targetRoles, updates.GithubURL,
 		expJSON, eduJSON, certJSON, langJSON, volJSON,
+		strings.ToUpper(strings.TrimSpace(updates.SalaryCurrency)),
+		targetCompanies, expectedUpdatedAt, updates.RemotiveCategories,
 	)
 
 	u, err := scanUser(row)
+	if errors.Is(err, pgx.ErrNoRows) && expectedUpdatedAt != nil {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("checking user for stale update: %w", err)
+		}
+		if exists {
+			return nil, ErrStaleUpdate
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("updating user: %w", err)
 	}
 	return u, nil
 }
 
-// UpdateSkills replaces the user's skills array
-func (r *UserRepo) UpdateSkills(ctx context.Context, id uuid.UUID, skills []string) error {
+// UpdateSkills replaces the user's skills array after normalizing it
+// (canonical taxonomy names, deduplicated across aliases) and returns what
+// was stored
+func (r *UserRepo) UpdateSkills(ctx context.Context, id uuid.UUID, list []string) ([]string, error) {
+	list = skills.Normalize(list)
 	_, err := r.pool.Exec(ctx, `
 		UPDATE users SET skills = $2, updated_at = now() WHERE id = $1
-	`, id, skills)
+	`, id, list)
+	if err != nil {
+		return nil, fmt.Errorf("updating skills: %w", err)
+	}
+	return list, nil
+}
+
+// updateSkillsTx runs UpdateSkills inside the caller's transaction
+func updateSkillsTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, list []string) ([]string, error) {
+	list = skills.Normalize(list)
+	_, err := tx.Exec(ctx, `
+		UPDATE users SET skills = $2, updated_at = now() WHERE id = $1
+	`, id, list)
 	if err != nil {
-		return fmt.Errorf("updating skills: %w", err)
+		return nil, fmt.Errorf("updating skills: %w", err)
+	}
+	return list, nil
+}
+
+// accountDeletes removes everything a user owns, children before parents.
+// Most of these tables also cascade from users, but deleting explicitly keeps
+// the order independent of FK options (user_feed.saved_job_id has none) and
+// covers rows keyed by something other than user_id.
+var accountDeletes = []struct{ table, sql string }{
+	{"notifications", `DELETE FROM notifications WHERE user_id = $1`},
+	{"ai_usage", `DELETE FROM ai_usage WHERE user_id = $1`},
+	{"api_tokens", `DELETE FROM api_tokens WHERE user_id = $1`},
+	{"user_feed", `DELETE FROM user_feed WHERE user_id = $1`},
+	{"feed_refresh_log", `DELETE FROM feed_refresh_log WHERE user_id = $1`},
+	{"job_alerts", `DELETE FROM job_alerts WHERE user_id = $1`},
+	{"interview_questions", `DELETE FROM interview_questions WHERE user_id = $1`},
+	{"status_history", `DELETE FROM status_history WHERE application_id IN (SELECT id FROM applications WHERE user_id = $1)`},
+	{"applications", `DELETE FROM applications WHERE user_id = $1`},
+	{"notes", `DELETE FROM notes WHERE user_id = $1`},
+	{"contacts", `DELETE FROM contacts WHERE user_id = $1`},
+	{"competitive_snapshots", `DELETE FROM competitive_snapshots WHERE job_id IN (SELECT id FROM jobs WHERE user_id = $1)`},
+	{"jobs", `DELETE FROM jobs WHERE user_id = $1`},
+	{"resumes", `DELETE FROM resumes WHERE user_id = $1`},
+	{"subscriptions", `DELETE FROM subscriptions WHERE user_id = $1`},
+	{"payment_events", `DELETE FROM payment_events WHERE stripe_customer_id IN (SELECT stripe_customer_id FROM stripe_customers WHERE user_id = $1)`},
+	{"stripe_customers", `DELETE FROM stripe_customers WHERE user_id = $1`},
+}
+
+// DeleteCascade permanently deletes a user and all of their data in one
+// transaction. Returns false if the user doesn't exist. Cancel any Stripe
+// subscription first; this only removes local records.
+func (r *UserRepo) DeleteCascade(ctx context.Context, id uuid.UUID) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("beginning account deletion: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, d := range accountDeletes {
+		if _, err := tx.Exec(ctx, d.sql, id); err != nil {
+			return false, fmt.Errorf("deleting %s: %w", d.table, err)
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("deleting user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("committing account deletion: %w", err)
 	}
-	return nil
+	return true, nil
 }
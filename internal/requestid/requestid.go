@@ -0,0 +1,47 @@
+// Package requestid carries a per-request correlation ID through
+// context.Context, so log lines from handlers and from background work the
+// request started can be tied together.
+package requestid
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Header is the HTTP header the ID is read from and echoed in
+const Header = "X-Request-ID"
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or ""
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// Detach returns a background context that keeps ctx's request ID but none of
+// its cancellation, for goroutines that outlive the request
+func Detach(ctx context.Context) context.Context {
+	bg := context.Background()
+	if id := FromContext(ctx); id != "" {
+		bg = NewContext(bg, id)
+	}
+	return bg
+}
+
+// Logger returns the global logger, tagged with requestId when ctx has one
+func Logger(ctx context.Context) *zerolog.Logger {
+	id := FromContext(ctx)
+	if id == "" {
+		return &log.Logger
+	}
+	l := log.With().Str("requestId", id).Logger()
+	return &l
+}
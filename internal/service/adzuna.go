@@ -13,6 +13,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/skills"
 )
 
 // AdzunaClient wraps the Adzuna job search API.
@@ -23,13 +24,11 @@ type AdzunaClient struct {
 	client *http.Client
 }
 
-func NewAdzunaClient(appID, appKey string) *AdzunaClient {
+func NewAdzunaClient(appID, appKey string, timeout time.Duration) *AdzunaClient {
 	return &AdzunaClient{
 		appID:  appID,
 		appKey: appKey,
-		client: &http.Client{
-			Timeout: 20 * time.Second,
-		},
+		client: NewHTTPClient(timeout),
 	}
 }
 
@@ -77,13 +76,13 @@ type adzunaCategory struct {
 // ── Search parameters ────────────────────────────────
 
 type AdzunaQuery struct {
-	Keywords       string // "what" parameter
-	Location       string // "where" parameter
-	Country        string // 2-letter country code (default "us")
-	ResultsPerPage int    // max 50
-	MaxDaysOld     int    // filter by recency
-	FullTime       bool
-	SalaryMin      int
+	Keywords       string `json:"keywords"`       // "what" parameter
+	Location       string `json:"location"`       // "where" parameter
+	Country        string `json:"country"`        // 2-letter country code (default "us")
+	ResultsPerPage int    `json:"resultsPerPage"` // max 50
+	MaxDaysOld     int    `json:"maxDaysOld"`     // filter by recency
+	FullTime       bool   `json:"fullTime"`
+	SalaryMin      int    `json:"salaryMin"`
 }
 
 // ── Search method ────────────────────────────────────
@@ -171,8 +170,8 @@ func (c *AdzunaClient) Search(ctx context.Context, q AdzunaQuery) ([]AdzunaJob,
 // ── Query builder ────────────────────────────────────
 
 // BuildAdzunaQueries generates Adzuna queries from a user profile.
-// Target roles are the PRIMARY search driver.
-func BuildAdzunaQueries(user *model.User) []AdzunaQuery {
+// Target roles are the PRIMARY search driver. At most maxQueries are returned.
+func BuildAdzunaQueries(user *model.User, maxQueries int) []AdzunaQuery {
 	isRemote := strings.EqualFold(user.WorkStyle, "remote")
 	location := user.Location
 
@@ -235,24 +234,18 @@ func BuildAdzunaQueries(user *model.User) []AdzunaQuery {
 		add("developer")
 	}
 
-	// Cap at 6 queries
-	if len(queries) > 6 {
-		queries = queries[:6]
-	}
-
-	return queries
+	return capQueries(queries, maxQueries)
 }
 
 // ── Converter ────────────────────────────────────────
 
 // convertAdzunaJob transforms an Adzuna API result into our FeedJob model.
-func convertAdzunaJob(aj AdzunaJob) *model.FeedJob {
-	salaryMin := int(aj.SalaryMin)
-	salaryMax := int(aj.SalaryMax)
-	salaryText := ""
-	if salaryMin > 0 || salaryMax > 0 {
-		salaryText = fmt.Sprintf("$%dk - $%dk/yr", salaryMin/1000, salaryMax/1000)
-	}
+// country is the endpoint the job came from, which determines its currency.
+func convertAdzunaJob(aj AdzunaJob, country string) *model.FeedJob {
+	// Adzuna always reports annual salaries, in the country's local currency
+	currency := adzunaCurrency(country)
+	salaryText := formatSalaryText(int(aj.SalaryMin), int(aj.SalaryMax), SalaryPeriodYear, currency)
+	salaryMin, salaryMax := normalizeToAnnual(aj.SalaryMin, aj.SalaryMax, SalaryPeriodYear)
 
 	// Parse job type
 	jobType := "full-time"
@@ -291,9 +284,10 @@ func convertAdzunaJob(aj AdzunaJob) *model.FeedJob {
 		SalaryMin:      salaryMin,
 		SalaryMax:      salaryMax,
 		SalaryText:     salaryText,
+		SalaryCurrency: currency,
 		JobType:        jobType,
 		Description:    desc,
-		RequiredSkills: []string{}, // Adzuna doesn't provide skills
+		RequiredSkills: skills.Extract(aj.Title+" "+aj.Description, maxExtractedSkills), // Adzuna doesn't provide skills
 		ApplyURL:       aj.RedirectURL,
 		CompanyLogo:    "", // Adzuna doesn't provide logos
 		PostedAt:       postedAt,
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrAIBusy is returned when every Claude slot is taken and the wait queue
+// is full. Handlers surface it as a 503 with Retry-After.
+var ErrAIBusy = errors.New("AI service is busy")
+
+// AIConcurrency bounds Claude calls across the process
+type AIConcurrency struct {
+	MaxInFlight int // concurrent API calls; 0 disables the limit
+	MaxQueued   int // callers allowed to wait for a slot before new ones are rejected
+}
+
+// aiLimiter caps in-flight Claude calls so a burst of AI requests stays
+// under Anthropic's rate limits and doesn't pile up response buffers. Callers
+// past the cap wait in a bounded queue; beyond that they get ErrAIBusy right
+// away instead of holding a connection open.
+type aiLimiter struct {
+	slots     chan struct{}
+	maxQueued int64
+	waiting   atomic.Int64
+}
+
+// newAILimiter returns nil (no limit) when MaxInFlight isn't positive
+func newAILimiter(cfg AIConcurrency) *aiLimiter {
+	if cfg.MaxInFlight <= 0 {
+		return nil
+	}
+	return &aiLimiter{
+		slots:     make(chan struct{}, cfg.MaxInFlight),
+		maxQueued: int64(max(cfg.MaxQueued, 0)),
+	}
+}
+
+// acquire takes a slot, waiting in the queue if there's room. The returned
+// release must be called once the call finishes.
+func (l *aiLimiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	if l.waiting.Add(1) > l.maxQueued {
+		l.waiting.Add(-1)
+		return nil, ErrAIBusy
+	}
+	defer l.waiting.Add(-1)
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *aiLimiter) release() {
+	<-l.slots
+}
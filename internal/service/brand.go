@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for logo images
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// Brand is the logo and dominant color found for a company domain
+type Brand struct {
+	Logo  string // logo image URL
+	Color string // "#rrggbb", empty if no color could be computed
+}
+
+// BrandService fills in company logos and brand colors for jobs whose source
+// didn't supply them. Lookups are best-effort and cached by domain, including
+// misses, so a company without a logo isn't re-fetched on every save. The
+// cache is capped at brandMaxCacheEntries domains.
+type BrandService struct {
+	client  *http.Client
+	logoURL string // logo endpoint prefix; the domain is appended
+	cache   map[string]*cachedBrand
+	mu      sync.RWMutex
+}
+
+type cachedBrand struct {
+	brand     *Brand // nil for a cached miss
+	expiresAt time.Time
+}
+
+const (
+	brandCacheTTL     = 7 * 24 * time.Hour
+	brandMissTTL      = 24 * time.Hour
+	brandMaxLogoBytes = 1 << 20
+
+	// brandMaxLogoPixels rejects images whose header claims dimensions that
+	// would take far more memory to decode than a logo needs
+	brandMaxLogoPixels = 4096 * 4096
+
+	// brandMaxCacheEntries bounds memory; job saves bring an open-ended
+	// stream of domains
+	brandMaxCacheEntries = 5000
+)
+
+// jobBoardHosts are ATS and job-board domains an apply URL may point at;
+// their logos belong to the board, not the hiring company
+var jobBoardHosts = []string{
+	"greenhouse.io", "lever.co", "workday.com", "myworkdayjobs.com",
+	"ashbyhq.com", "smartrecruiters.com", "icims.com", "jobvite.com",
+	"bamboohr.com", "workable.com", "recruitee.com", "breezy.hr",
+	"linkedin.com", "indeed.com", "glassdoor.com", "ziprecruiter.com",
+	"adzuna.com", "adzuna.co.uk", "remotive.com", "remotive.io",
+	"google.com", "monster.com", "wellfound.com", "angel.co",
+	"dice.com", "simplyhired.com", "builtin.com", "taleo.net",
+}
+
+// NewBrandService creates a brand lookup against a Clearbit-style logo
+// endpoint (e.g. "https://logo.clearbit.com/"). An empty logoURL disables
+// enrichment.
+func NewBrandService(logoURL string) *BrandService {
+	return &BrandService{
+		client:  NewHTTPClient(5 * time.Second),
+		logoURL: logoURL,
+		cache:   make(map[string]*cachedBrand),
+	}
+}
+
+// Enabled reports whether a logo endpoint is configured
+func (b *BrandService) Enabled() bool {
+	return b.logoURL != ""
+}
+
+// Enrich fills an empty CompanyLogo (and CompanyColor) on the job in place.
+// Jobs that already carry a logo are left alone; their logo URL may be user
+// supplied, so it is never fetched. Failures leave the job unchanged.
+func (b *BrandService) Enrich(ctx context.Context, job *model.Job) {
+	if !b.Enabled() || job == nil || job.CompanyLogo != "" {
+		return
+	}
+
+	domain := CompanyDomain(job.ApplyURL, job.Company)
+	if domain == "" {
+		return
+	}
+	brand := b.lookup(ctx, domain)
+	if brand == nil {
+		return
+	}
+
+	job.CompanyLogo = brand.Logo
+	if brand.Color != "" && (job.CompanyColor == "" || job.CompanyColor == model.DefaultCompanyColor) {
+		job.CompanyColor = brand.Color
+	}
+}
+
+// lookup returns the cached brand for a domain, fetching its logo on a miss
+func (b *BrandService) lookup(ctx context.Context, domain string) *Brand {
+	b.mu.RLock()
+	cached, ok := b.cache[domain]
+	b.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.brand
+	}
+
+	brand, err := b.fetch(ctx, b.logoURL+domain)
+	ttl := brandCacheTTL
+	if err != nil {
+		log.Debug().Err(err).Str("domain", domain).Msg("Brand lookup failed")
+		ttl = brandMissTTL
+		// Don't cache a miss caused by the caller giving up
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+
+	b.mu.Lock()
+	b.store(domain, &cachedBrand{brand: brand, expiresAt: time.Now().Add(ttl)})
+	b.mu.Unlock()
+	return brand
+}
+
+// store adds an entry, first sweeping expired ones when the cache is full and
+// then dropping arbitrary entries if that wasn't enough. Callers hold mu.
+func (b *BrandService) store(domain string, entry *cachedBrand) {
+	if _, ok := b.cache[domain]; !ok && len(b.cache) >= brandMaxCacheEntries {
+		now := time.Now()
+		for k, cached := range b.cache {
+			if !now.Before(cached.expiresAt) {
+				delete(b.cache, k)
+			}
+		}
+		for k := range b.cache {
+			if len(b.cache) < brandMaxCacheEntries {
+				break
+			}
+			delete(b.cache, k)
+		}
+	}
+	b.cache[domain] = entry
+}
+
+// fetch downloads a logo and computes its dominant color
+func (b *BrandService) fetch(ctx context.Context, logoURL string) (*Brand, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating logo request: %w", err)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching logo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("logo returned status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "image/") {
+		return nil, fmt.Errorf("logo has content type %q", ct)
+	}
+
+	brand := &Brand{Logo: logoURL}
+
+	// The logo is usable even if the format can't be decoded (e.g. SVG) or is
+	// too large to decode safely
+	data, err := io.ReadAll(io.LimitReader(resp.Body, brandMaxLogoBytes))
+	if err != nil {
+		return brand, nil
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > brandMaxLogoPixels {
+		return brand, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err == nil {
+		brand.Color = dominantColor(img)
+	}
+	return brand, nil
+}
+
+// dominantColor buckets opaque, non-background pixels into a coarse palette
+// and returns the average of the most common bucket as "#rrggbb"
+func dominantColor(img image.Image) string {
+	bounds := img.Bounds()
+	// Sample at most ~64x64 points regardless of image size
+	step := max(1, max(bounds.Dx(), bounds.Dy())/64)
+
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := make(map[int]*bucket)
+	var best *bucket
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			r16, g16, b16, a16 := img.At(x, y).RGBA()
+			if a16 < 0x8000 {
+				continue // transparent
+			}
+			r, g, bl := int(r16>>8), int(g16>>8), int(b16>>8)
+			if r > 235 && g > 235 && bl > 235 {
+				continue // white background
+			}
+
+			key := (r>>5)<<6 | (g>>5)<<3 | bl>>5
+			bk := buckets[key]
+			if bk == nil {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.count++
+			bk.r += r
+			bk.g += g
+			bk.b += bl
+			if best == nil || bk.count > best.count {
+				best = bk
+			}
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.count, best.g/best.count, best.b/best.count)
+}
+
+// CompanyDomain derives the company's website domain from a job's apply URL,
+// falling back to a "<name>.com" guess when the URL is missing or points at a
+// job board. Returns "" if neither gives a usable domain.
+func CompanyDomain(applyURL, company string) string {
+	if u, err := url.Parse(applyURL); err == nil && u.Hostname() != "" {
+		host := strings.ToLower(u.Hostname())
+		if !isJobBoardHost(host) {
+			return registrableDomain(host)
+		}
+	}
+
+	name := strings.ReplaceAll(model.NormalizeCompanyName(company), " ", "")
+	if name == "" {
+		return ""
+	}
+	return name + ".com"
+}
+
+func isJobBoardHost(host string) bool {
+	for _, board := range jobBoardHosts {
+		if host == board || strings.HasSuffix(host, "."+board) {
+			return true
+		}
+	}
+	return false
+}
+
+// registrableDomain trims subdomains like "careers." or "jobs.", keeping a
+// two-part country suffix such as "co.uk" intact
+func registrableDomain(host string) string {
+	labels := strings.Split(host, ".")
+	keep := 2
+	if n := len(labels); n >= 3 && len(labels[n-1]) == 2 {
+		switch labels[n-2] {
+		case "co", "com", "ac", "org", "net", "gov":
+			keep = 3
+		}
+	}
+	if len(labels) <= keep {
+		return host
+	}
+	return strings.Join(labels[len(labels)-keep:], ".")
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func encodePNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestBrandFetchSkipsOversizedImages(t *testing.T) {
+	small := encodePNG(t, color.RGBA{R: 200, A: 255})
+
+	// Same image with a header claiming 100000x100000 pixels
+	huge := bytes.Clone(small)
+	binary.BigEndian.PutUint32(huge[16:], 100000)
+	binary.BigEndian.PutUint32(huge[20:], 100000)
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+
+	tests := []struct {
+		name      string
+		body      []byte
+		wantColor bool
+	}{
+		{"small", small, true},
+		{"oversized", huge, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "image/png")
+				w.Write(tt.body)
+			}))
+			defer srv.Close()
+
+			b := NewBrandService(srv.URL + "/")
+			brand, err := b.fetch(context.Background(), srv.URL+"/logo.png")
+			if err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+			if brand.Logo == "" {
+				t.Error("logo should be kept even when the color isn't computed")
+			}
+			if got := brand.Color != ""; got != tt.wantColor {
+				t.Errorf("color = %q, want computed=%v", brand.Color, tt.wantColor)
+			}
+		})
+	}
+}
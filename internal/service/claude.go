@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -16,18 +18,26 @@ import (
 
 // ClaudeClient wraps the Anthropic Messages API
 type ClaudeClient struct {
-	apiKey  string
-	baseURL string
-	client  *http.Client
+	apiKey         string
+	baseURL        string
+	client         *http.Client
+	critiquePrompt string
+	limiter        *aiLimiter // nil when calls are unbounded
 }
 
-func NewClaudeClient(apiKey, baseURL string) *ClaudeClient {
+// NewClaudeClient creates a client. critiqueRubric replaces the built-in
+// resume critique rubric when non-empty; check it with ValidateCritiqueRubric
+// first. timeout bounds each API call; limits caps how many run at once.
+func NewClaudeClient(apiKey, baseURL, critiqueRubric string, timeout time.Duration, limits AIConcurrency) *ClaudeClient {
+	if strings.TrimSpace(critiqueRubric) == "" {
+		critiqueRubric = defaultCritiqueRubric
+	}
 	return &ClaudeClient{
-		apiKey:  apiKey,
-		baseURL: baseURL,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		apiKey:         apiKey,
+		baseURL:        baseURL,
+		client:         NewHTTPClient(timeout),
+		critiquePrompt: buildCritiquePrompt(critiqueRubric),
+		limiter:        newAILimiter(limits),
 	}
 }
 
@@ -85,6 +95,12 @@ func (c *ClaudeClient) callClaude(ctx context.Context, systemPrompt, userContent
 	req.Header.Set("x-api-key", c.apiKey)
 	req.Header.Set("anthropic-version", "2023-06-01")
 
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("calling Claude API: %w", err)
@@ -174,27 +190,200 @@ func (c *ClaudeClient) ParseJobPosting(ctx context.Context, rawText string) (*Pa
 	return &result, nil
 }
 
+// ── Parse job listings (careers index pages) ─────────
+
+const parseListingsSystemPrompt = `You are a job listings parser. The input is a careers page or job board listing that may contain many job postings.
+
+Always respond with ONLY a JSON object (no markdown, no backticks, no explanation) in this shape:
+{
+  "jobs": [
+    {
+      "title": "Job title",
+      "company": "Company name",
+      "location": "Location (include Remote if applicable)",
+      "salary_range": "Salary range if mentioned, empty string if not",
+      "job_type": "full-time, part-time, contract, or internship",
+      "description": "One or two sentence summary of the role, empty string if the listing has no detail",
+      "required_skills": ["skill1", "skill2"],
+      "preferred_skills": ["skill1", "skill2"],
+      "apply_url": "Link to this specific posting if present, empty string if not",
+      "hiring_email": "Recruiter/hiring email if found, empty string if not",
+      "tags": ["relevant", "category", "tags"],
+      "source": "linkedin, greenhouse, lever, indeed, glassdoor, angellist, or other"
+    }
+  ]
+}
+
+Rules:
+- Return one entry per distinct open role. Don't merge roles or repeat the same role.
+- Skip navigation, footer, and marketing text that isn't a job.
+- Extract only what's explicitly stated. Don't invent data.
+- Listing pages are often terse — empty strings and arrays are fine.
+- If the page has no job postings, return {"jobs": []}.`
+
+// ParseJobListings extracts every job from a multi-posting page (e.g. a careers index)
+func (c *ClaudeClient) ParseJobListings(ctx context.Context, rawText string) ([]ParsedJob, error) {
+	var result struct {
+		Jobs []ParsedJob `json:"jobs"`
+	}
+	if err := c.callClaude(ctx, parseListingsSystemPrompt, "Extract all job postings from this page and return the JSON:\n\n"+rawText, 8000, &result); err != nil {
+		return nil, err
+	}
+	return result.Jobs, nil
+}
+
+// ── Parse a batch of pasted postings ─────────────────
+
+// MaxBatchPostings caps how many postings one batch parse returns
+const MaxBatchPostings = 10
+
+const parseBatchSystemPrompt = `You are a job posting parser. The input contains several job postings a user copied in one go. Postings may be marked with "=== POSTING n ===" headers; otherwise, split the text into postings yourself.
+
+Always respond with ONLY a JSON object (no markdown, no backticks, no explanation) in this shape:
+{
+  "jobs": [
+    {
+      "index": 1,
+      "error": "Empty string, or a short reason if this block isn't a job posting",
+      "title": "Job title",
+      "company": "Company name",
+      "location": "Location (include Remote if applicable)",
+      "salary_range": "Salary range if mentioned, empty string if not",
+      "job_type": "full-time, part-time, contract, or internship",
+      "description": "Brief 2-3 sentence summary of the role",
+      "required_skills": ["skill1", "skill2"],
+      "preferred_skills": ["skill1", "skill2"],
+      "apply_url": "Application URL if found, empty string if not",
+      "hiring_email": "Recruiter/hiring email if found, empty string if not",
+      "tags": ["relevant", "category", "tags"],
+      "source": "linkedin, greenhouse, lever, indeed, glassdoor, angellist, or other"
+    }
+  ]
+}
+
+Rules:
+- With "=== POSTING n ===" headers, return exactly one entry per header, with "index" set to n, in order. If a block isn't a job posting, keep its entry with "error" set and the other fields empty.
+- Without headers, return one entry per distinct posting in the order they appear, numbering "index" from 1. Don't merge postings or repeat one.
+- Extract only what's explicitly stated. Don't invent data.
+- Keep each description concise — summarize the role, don't copy the full posting.
+- If a field isn't present in a posting, use an empty string or empty array.`
+
+// ParsedBatchItem is one posting from a batch parse. Index is the 1-based
+// position of the posting in the input; Error is set when Claude couldn't
+// read that block as a job posting.
+type ParsedBatchItem struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+	ParsedJob
+}
+
+// ParsePostingBatch splits and parses several postings in one call. Pass
+// either text holding several postings, or the postings already separated
+// (they're sent with numbered headers so results map back by Index).
+func (c *ClaudeClient) ParsePostingBatch(ctx context.Context, text string, postings []string) ([]ParsedBatchItem, error) {
+	input := text
+	if len(postings) > 0 {
+		var b strings.Builder
+		for i, p := range postings {
+			fmt.Fprintf(&b, "=== POSTING %d ===\n%s\n\n", i+1, p)
+		}
+		input = b.String()
+	}
+
+	var result struct {
+		Jobs []ParsedBatchItem `json:"jobs"`
+	}
+	if err := c.callClaude(ctx, parseBatchSystemPrompt, "Parse each job posting below and return the JSON:\n\n"+input, 8000, &result); err != nil {
+		return nil, err
+	}
+	return result.Jobs, nil
+}
+
 // ── Fetch URL content ─────────────────────────────────
 
+// DefaultScraperUserAgents is the browser user-agent pool used when none is configured.
+var DefaultScraperUserAgents = []string{
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_6) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.6 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64; rv:132.0) Gecko/20100101 Firefox/132.0",
+}
+
+const (
+	defaultScraperAccept         = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	defaultScraperAcceptLanguage = "en-US,en;q=0.9"
+	plainScraperUserAgent        = "HireIQ/1.0 (+https://hireiq.app)"
+)
+
+// ScraperConfig controls the headers sent when fetching job posting pages
+type ScraperConfig struct {
+	UserAgents     []string // rotated round-robin; defaults to DefaultScraperUserAgents
+	Accept         string
+	AcceptLanguage string
+	SpoofBrowser   bool          // false sends a plain bot user-agent for every site
+	PlainHosts     []string      // hosts that get the plain user-agent even when spoofing
+	Timeout        time.Duration // per fetch; defaults to 20s
+}
+
+// URLFetcher retrieves job posting pages using the configured header set
+type URLFetcher struct {
+	cfg    ScraperConfig
+	client *http.Client
+	next   atomic.Uint64
+}
+
+func NewURLFetcher(cfg ScraperConfig) *URLFetcher {
+	if len(cfg.UserAgents) == 0 {
+		cfg.UserAgents = DefaultScraperUserAgents
+	}
+	if cfg.Accept == "" {
+		cfg.Accept = defaultScraperAccept
+	}
+	if cfg.AcceptLanguage == "" {
+		cfg.AcceptLanguage = defaultScraperAcceptLanguage
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 20 * time.Second
+	}
+	return &URLFetcher{
+		cfg:    cfg,
+		client: NewHTTPClient(cfg.Timeout),
+	}
+}
+
+// userAgentFor picks the user-agent for a request. Browser user-agents are
+// rotated so a single blocked UA doesn't break every fetch.
+func (f *URLFetcher) userAgentFor(rawURL string) string {
+	if !f.cfg.SpoofBrowser {
+		return plainScraperUserAgent
+	}
+	if u, err := neturl.Parse(rawURL); err == nil {
+		host := strings.ToLower(u.Hostname())
+		for _, h := range f.cfg.PlainHosts {
+			h = strings.ToLower(h)
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return plainScraperUserAgent
+			}
+		}
+	}
+	idx := f.next.Add(1) - 1
+	return f.cfg.UserAgents[idx%uint64(len(f.cfg.UserAgents))]
+}
+
 // FetchURLContent retrieves the text content of a URL for parsing.
 // It extracts JSON-LD structured data if available, strips HTML tags,
 // and attempts to fetch additional tab content from common ATS platforms.
-func FetchURLContent(ctx context.Context, url string) (string, error) {
-	client := &http.Client{
-		Timeout: 20 * time.Second,
-	}
-
+func (f *URLFetcher) FetchURLContent(ctx context.Context, url string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("creating request: %w", err)
 	}
 
-	// Set a browser-like user agent so job sites don't block us
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("User-Agent", f.userAgentFor(url))
+	req.Header.Set("Accept", f.cfg.Accept)
+	req.Header.Set("Accept-Language", f.cfg.AcceptLanguage)
 
-	resp, err := client.Do(req)
+	resp, err := f.client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("fetching URL: %w", err)
 	}
@@ -603,14 +792,29 @@ type CritiqueIssue struct {
 	Msg string `json:"msg"`
 }
 
-const critiqueSystemPrompt = `You are HireIQ's resume critique AI. Analyze resumes and provide actionable feedback.
+const critiquePromptIntro = `You are HireIQ's resume critique AI. Analyze resumes and provide actionable feedback.
 
 CRITICAL RULES:
 - Do NOT rewrite the resume. Only provide specific recommendations.
 - Be direct and actionable — every issue should tell the user exactly what to change.
-- Focus on what will make the biggest difference for getting interviews.
+- Focus on what will make the biggest difference for getting interviews.`
 
-Respond with ONLY a JSON object (no markdown, no backticks, no explanation):
+// defaultCritiqueRubric is the scoring rubric used unless the deployment
+// configures its own (CRITIQUE_RUBRIC_FILE)
+const defaultCritiqueRubric = `Categories: Impact, Language, Structure, Formatting, Alignment, Clarity, Punctuation, Length, ATS
+Severities: critical (blocks interviews), warning (weakens impression), info (nice to improve)
+
+Guidelines:
+- Give 4-8 issues, ordered by severity (critical first)
+- Give 2-5 strengths — find genuine positives
+- Score 0-100: 90+ is exceptional, 70-89 is solid, 50-69 needs work, <50 has major issues
+- Check for: quantifiable metrics, action verbs vs weak verbs (worked, helped, used, did, made, attended), clichés (fast-paced, team player, detail-oriented), ATS compatibility, consistent formatting, appropriate length
+- If a target role is provided, check skill alignment and tailor advice accordingly
+- topTip should be the single highest-impact change they can make`
+
+// critiqueResponseFormat always closes the prompt, after any custom rubric,
+// so the response stays parseable as CritiqueResult
+const critiqueResponseFormat = `Respond with ONLY a JSON object (no markdown, no backticks, no explanation):
 {
   "score": 72,
   "issues": [
@@ -620,17 +824,44 @@ Respond with ONLY a JSON object (no markdown, no backticks, no explanation):
   "strengths": ["Clear section organization", "Includes relevant technical skills"],
   "topTip": "The single most impactful change: add 2-3 metrics to your most recent role showing business impact (revenue, users, performance, cost savings)."
 }
+"cat" and "sev" must come from the categories and severities above; "score" is an integer from 0 to 100.`
 
-Categories: Impact, Language, Structure, Formatting, Alignment, Clarity, Punctuation, Length, ATS
-Severities: critical (blocks interviews), warning (weakens impression), info (nice to improve)
+// maxCritiqueRubricLength caps a custom rubric, in characters, to keep the
+// system prompt a reasonable size
+const maxCritiqueRubricLength = 8000
 
-Guidelines:
-- Give 4-8 issues, ordered by severity (critical first)
-- Give 2-5 strengths — find genuine positives
-- Score 0-100: 90+ is exceptional, 70-89 is solid, 50-69 needs work, <50 has major issues
-- Check for: quantifiable metrics, action verbs vs weak verbs (worked, helped, used, did, made, attended), clichés (fast-paced, team player, detail-oriented), ATS compatibility, consistent formatting, appropriate length
-- If a target role is provided, check skill alignment and tailor advice accordingly
-- topTip should be the single highest-impact change they can make`
+func buildCritiquePrompt(rubric string) string {
+	return critiquePromptIntro + "\n\n" + strings.TrimSpace(rubric) + "\n\n" + critiqueResponseFormat
+}
+
+// ValidateCritiqueRubric checks a custom critique rubric. The JSON response
+// format is always appended after the rubric, so a rubric may not try to
+// change it: code fences, requests for markdown, or its own response format
+// are rejected. It must also name the categories and severities the critique
+// may use.
+func ValidateCritiqueRubric(rubric string) error {
+	rubric = strings.TrimSpace(rubric)
+	if rubric == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(rubric) > maxCritiqueRubricLength {
+		return fmt.Errorf("critique rubric is too long (max %d characters)", maxCritiqueRubricLength)
+	}
+
+	lower := strings.ToLower(rubric)
+	if strings.Contains(rubric, "```") || strings.Contains(lower, "markdown") {
+		return fmt.Errorf("critique rubric must not ask for markdown; responses are always JSON")
+	}
+	if strings.Contains(lower, "respond with") || strings.Contains(lower, "output format") {
+		return fmt.Errorf("critique rubric must not set its own response format; the JSON format is added automatically")
+	}
+	for _, section := range []string{"categories:", "severities:"} {
+		if !strings.Contains(lower, section) {
+			return fmt.Errorf("critique rubric must include a %q line", section)
+		}
+	}
+	return nil
+}
 
 // CritiqueResume sends a resume to Claude for structured analysis
 func (c *ClaudeClient) CritiqueResume(ctx context.Context, resumeText, jobContext string) (*CritiqueResult, error) {
@@ -639,7 +870,7 @@ func (c *ClaudeClient) CritiqueResume(ctx context.Context, resumeText, jobContex
 		userContent += "\n\n---\n" + jobContext
 	}
 	var result CritiqueResult
-	if err := c.callClaude(ctx, critiqueSystemPrompt, userContent, 2000, &result); err != nil {
+	if err := c.callClaude(ctx, c.critiquePrompt, userContent, 2000, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -964,6 +1195,166 @@ func (c *ClaudeClient) CompareJobs(ctx context.Context, jobDescriptions string,
 	return &result, nil
 }
 
+// ── Cover Letter Generation ──────────────────────────
+
+// Cover letter tones accepted by GenerateCoverLetter
+const (
+	ToneProfessional = "professional"
+	ToneEnthusiastic = "enthusiastic"
+	ToneConcise      = "concise"
+)
+
+// ValidTone reports whether a cover letter tone is supported
+func ValidTone(tone string) bool {
+	switch tone {
+	case ToneProfessional, ToneEnthusiastic, ToneConcise:
+		return true
+	}
+	return false
+}
+
+var toneInstructions = map[string]string{
+	ToneProfessional: "Polished and confident. 3-4 paragraphs, about 250-350 words.",
+	ToneEnthusiastic: "Warm and energetic, showing genuine excitement for the company and role while staying credible. 3-4 paragraphs, about 250-350 words.",
+	ToneConcise:      "Direct and tight. 2-3 short paragraphs, under 200 words.",
+}
+
+type CoverLetterResult struct {
+	CoverLetter string `json:"coverLetter"`
+}
+
+const coverLetterSystemPrompt = `You are HireIQ's cover letter writer. Write a tailored cover letter for the candidate using ONLY facts from their resume.
+
+CRITICAL RULES:
+- Never invent employers, titles, metrics, degrees, or skills that aren't in the resume.
+- Connect 2-3 of the candidate's strongest, most relevant experiences to the job's requirements.
+- Open with a specific hook about the role or company — never "I am writing to apply for...".
+- Plain text only, paragraphs separated by a blank line. Start with "Dear Hiring Manager," unless a name is given, and end with a sign-off and the candidate's name if known.
+- No placeholders like [Company Name].
+
+Respond with ONLY a JSON object (no markdown, no backticks):
+{
+  "coverLetter": "the full letter text"
+}`
+
+// GenerateCoverLetter writes a cover letter for a job from the candidate's resume
+func (c *ClaudeClient) GenerateCoverLetter(ctx context.Context, resumeText, jobContext, tone string) (*CoverLetterResult, error) {
+	if !ValidTone(tone) {
+		tone = ToneProfessional
+	}
+	userContent := fmt.Sprintf(
+		"Tone: %s — %s\n\nResume:\n%s\n\n---\n%s",
+		tone, toneInstructions[tone], resumeText, jobContext,
+	)
+	var result CoverLetterResult
+	if err := c.callClaude(ctx, coverLetterSystemPrompt, userContent, 2000, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ── Interview Prep ───────────────────────────────────
+
+type InterviewQuestion struct {
+	Question      string   `json:"question"`
+	Why           string   `json:"why"`           // what the interviewer is probing for
+	TalkingPoints []string `json:"talkingPoints"` // points to hit in the answer
+}
+
+type InterviewPrepResult struct {
+	Technical  []InterviewQuestion `json:"technical"`
+	Behavioral []InterviewQuestion `json:"behavioral"`
+	Company    []InterviewQuestion `json:"company"`
+}
+
+const interviewPrepSystemPrompt = `You are HireIQ's interview coach. Given a job a candidate is pursuing, predict the questions they are most likely to be asked and how to prepare.
+
+Respond with ONLY a JSON object (no markdown, no backticks):
+{
+  "technical": [
+    {
+      "question": "How would you design a rate limiter for a public API?",
+      "why": "The role owns high-traffic backend services",
+      "talkingPoints": ["Token bucket vs sliding window", "Per-user vs global limits", "Where state lives when horizontally scaled"]
+    }
+  ],
+  "behavioral": [ ...same shape... ],
+  "company": [ ...same shape... ]
+}
+
+Rules:
+- 5-7 technical questions grounded in the job's required and preferred skills and responsibilities.
+- 4-5 behavioral questions matching the seniority and responsibilities described.
+- 2-3 company questions (why this company, product/market, mission) using only what the posting says about the company.
+- 2-4 concise talking points per question. If a candidate profile is provided, tie talking points to their actual experience.
+- Do not invent facts about the company beyond the posting.`
+
+// GenerateInterviewQuestions predicts likely interview questions for a job
+func (c *ClaudeClient) GenerateInterviewQuestions(ctx context.Context, jobContext, userProfile string) (*InterviewPrepResult, error) {
+	userContent := "Generate interview prep for this job and return the JSON:\n\n" + jobContext
+	if userProfile != "" {
+		userContent += "\n\n=== CANDIDATE PROFILE ===\n" + userProfile
+	}
+	var result InterviewPrepResult
+	if err := c.callClaude(ctx, interviewPrepSystemPrompt, userContent, 4000, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ── Skill Gap Learning Suggestions ──────────────────
+
+// LearningSuggestion is a study plan for one missing skill
+type LearningSuggestion struct {
+	Skill      string             `json:"skill"`
+	Why        string             `json:"why"`        // why it matters for this role
+	TimeToRamp string             `json:"timeToRamp"` // rough estimate, e.g. "2-4 weeks"
+	Resources  []LearningResource `json:"resources"`
+}
+
+type LearningResource struct {
+	Title string `json:"title"`
+	Type  string `json:"type"` // course, docs, book, project, video
+	URL   string `json:"url,omitempty"`
+}
+
+const learningSystemPrompt = `You are HireIQ's career coach. Given a target job and the skills a candidate is missing for it, suggest how to close each gap.
+
+Respond with ONLY valid JSON, no markdown fences:
+{
+  "suggestions": [
+    {
+      "skill": "the missing skill, as given",
+      "why": "1 sentence on why this role needs it",
+      "timeToRamp": "rough estimate, e.g. \"1-2 weeks\"",
+      "resources": [
+        {"title": "resource name", "type": "course|docs|book|project|video", "url": "https://... (only well-known, stable URLs; omit if unsure)"}
+      ]
+    }
+  ]
+}
+
+Rules:
+- One entry per missing skill, in the order given; 2-3 resources each.
+- Prefer official documentation and widely known free resources.
+- Suggest one small hands-on project where it helps.
+- Never invent URLs.`
+
+// SuggestLearningResources asks Claude how to close the candidate's skill gaps for a job
+func (c *ClaudeClient) SuggestLearningResources(ctx context.Context, jobTitle, company string, missingSkills []string) ([]LearningSuggestion, error) {
+	userContent := fmt.Sprintf(
+		"Target role: %s at %s\n\nMissing skills:\n- %s",
+		jobTitle, company, strings.Join(missingSkills, "\n- "),
+	)
+	var result struct {
+		Suggestions []LearningSuggestion `json:"suggestions"`
+	}
+	if err := c.callClaude(ctx, learningSystemPrompt, userContent, 2500, &result); err != nil {
+		return nil, err
+	}
+	return result.Suggestions, nil
+}
+
 // stripCodeFences removes markdown ```json ... ``` wrappers
 func stripCodeFences(text string) string {
 	if strings.HasPrefix(text, "```") {
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is assumed when a source doesn't report one
+const DefaultCurrency = "USD"
+
+// CurrencyConverter converts whole-unit amounts between ISO 4217 currencies.
+// ok is false when either currency is unknown.
+type CurrencyConverter interface {
+	Convert(amount int, from, to string) (converted int, ok bool)
+}
+
+// defaultUSDRates is USD per one unit of each currency. Approximate on
+// purpose: conversions feed match scoring, not anything shown as a price.
+var defaultUSDRates = map[string]float64{
+	"USD": 1.0,
+	"EUR": 1.08,
+	"GBP": 1.27,
+	"CAD": 0.73,
+	"AUD": 0.66,
+	"NZD": 0.60,
+	"CHF": 1.12,
+	"SEK": 0.095,
+	"NOK": 0.093,
+	"DKK": 0.145,
+	"PLN": 0.25,
+	"INR": 0.012,
+	"SGD": 0.74,
+	"JPY": 0.0067,
+	"BRL": 0.18,
+	"MXN": 0.055,
+	"ZAR": 0.054,
+}
+
+// StaticRates is a CurrencyConverter backed by a fixed USD rate table
+type StaticRates struct {
+	usdPer map[string]float64
+}
+
+// NewStaticRates returns the built-in table with overrides applied
+// (CURRENCY_RATES, e.g. "GBP=1.25,EUR=1.1")
+func NewStaticRates(overrides map[string]float64) *StaticRates {
+	rates := make(map[string]float64, len(defaultUSDRates)+len(overrides))
+	for code, rate := range defaultUSDRates {
+		rates[code] = rate
+	}
+	for code, rate := range overrides {
+		if rate > 0 {
+			rates[strings.ToUpper(code)] = rate
+		}
+	}
+	return &StaticRates{usdPer: rates}
+}
+
+func (r *StaticRates) Convert(amount int, from, to string) (int, bool) {
+	from, to = normalizeCurrency(from), normalizeCurrency(to)
+	if from == to {
+		return amount, true
+	}
+	fromRate, ok1 := r.usdPer[from]
+	toRate, ok2 := r.usdPer[to]
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return int(math.Round(float64(amount) * fromRate / toRate)), true
+}
+
+// normalizeCurrency upper-cases a code, defaulting empty to USD
+func normalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
+
+// adzunaCurrencies maps Adzuna country endpoints to the currency they report in
+var adzunaCurrencies = map[string]string{
+	"us": "USD", "gb": "GBP", "ca": "CAD", "au": "AUD", "nz": "NZD",
+	"in": "INR", "sg": "SGD", "za": "ZAR", "br": "BRL", "mx": "MXN",
+	"ch": "CHF", "pl": "PLN",
+	"de": "EUR", "fr": "EUR", "nl": "EUR", "it": "EUR", "es": "EUR",
+	"at": "EUR", "be": "EUR",
+}
+
+func adzunaCurrency(country string) string {
+	if c, ok := adzunaCurrencies[strings.ToLower(country)]; ok {
+		return c
+	}
+	return DefaultCurrency
+}
+
+// detectCurrency guesses the currency of free-text salary ("£60k-£70k",
+// "EUR 50,000"); USD when nothing else is recognizable
+func detectCurrency(text string) string {
+	upper := strings.ToUpper(text)
+	switch {
+	case strings.Contains(text, "£") || strings.Contains(upper, "GBP"):
+		return "GBP"
+	case strings.Contains(text, "€") || strings.Contains(upper, "EUR"):
+		return "EUR"
+	case strings.Contains(upper, "CAD") || strings.Contains(upper, "CA$"):
+		return "CAD"
+	case strings.Contains(upper, "AUD") || strings.Contains(upper, "A$"):
+		return "AUD"
+	case strings.Contains(text, "₹") || strings.Contains(upper, "INR"):
+		return "INR"
+	default:
+		return DefaultCurrency
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/config"
+)
+
+// EmailMessage is a plain-text email
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSender delivers email. SMTPSender is used in production; LogSender
+// stands in when SMTP isn't configured so local runs don't send real mail.
+type EmailSender interface {
+	Send(ctx context.Context, msg EmailMessage) error
+}
+
+// NewEmailSender returns an SMTP sender when SMTP_HOST is set, otherwise a
+// sender that only logs
+func NewEmailSender(cfg *config.Config) EmailSender {
+	if cfg.SMTPHost == "" {
+		return LogSender{}
+	}
+	return &SMTPSender{
+		addr: fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort),
+		host: cfg.SMTPHost,
+		user: cfg.SMTPUsername,
+		pass: cfg.SMTPPassword,
+		from: cfg.SMTPFrom,
+	}
+}
+
+// smtpTimeout bounds one send, dial included, when ctx has no earlier
+// deadline, so a stalled relay can't hang the caller
+const smtpTimeout = 30 * time.Second
+
+// SMTPSender sends mail through an SMTP relay, upgrading to STARTTLS when
+// the relay offers it
+type SMTPSender struct {
+	addr string
+	host string
+	user string
+	pass string
+	from string
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg EmailMessage) error {
+	var auth smtp.Auth
+	if s.user != "" {
+		auth = smtp.PlainAuth("", s.user, s.pass, s.host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	if err := s.sendMail(ctx, auth, envelopeAddress(s.from), msg.To, []byte(b.String())); err != nil {
+		return fmt.Errorf("sending email to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// sendMail is smtp.SendMail with a deadline: the dial honors ctx, every
+// read and write stops at ctx's deadline (or smtpTimeout), and cancelling
+// ctx closes the connection.
+func (s *SMTPSender) sendMail(ctx context.Context, auth smtp.Auth, from, to string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(auth); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// envelopeAddress extracts "a@b.com" from "Name <a@b.com>"
+func envelopeAddress(from string) string {
+	if start := strings.LastIndex(from, "<"); start != -1 {
+		if end := strings.LastIndex(from, ">"); end > start {
+			return from[start+1 : end]
+		}
+	}
+	return from
+}
+
+// LogSender logs emails instead of sending them
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, msg EmailMessage) error {
+	log.Info().
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Body).
+		Msg("Email (not sent: SMTP not configured)")
+	return nil
+}
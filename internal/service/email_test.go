@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSMTPSenderStalledServer(t *testing.T) {
+	// Accepts connections but never sends the SMTP greeting
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	sender := &SMTPSender{addr: ln.Addr().String(), host: "127.0.0.1", from: "noreply@example.com"}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sender.Send(ctx, EmailMessage{To: "user@example.com", Subject: "Hi", Body: "Hello"})
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error from a stalled server")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send hung on a stalled server")
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// StatusChangeSubscriber reacts to application status changes (notifications,
+// email, Slack...). Subscribers run synchronously after the change is committed;
+// an error is logged and never fails the request that caused it.
+type StatusChangeSubscriber interface {
+	Name() string
+	OnStatusChange(ctx context.Context, event model.StatusChangeEvent) error
+}
+
+// StatusEventBus fans application status changes out to registered subscribers
+type StatusEventBus struct {
+	mu          sync.RWMutex
+	subscribers []StatusChangeSubscriber
+}
+
+func NewStatusEventBus(subscribers ...StatusChangeSubscriber) *StatusEventBus {
+	return &StatusEventBus{subscribers: subscribers}
+}
+
+// Subscribe registers another subscriber
+func (b *StatusEventBus) Subscribe(s StatusChangeSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, s)
+}
+
+// Publish delivers the event to every subscriber in registration order.
+// No-op changes (same from/to status) are not published.
+func (b *StatusEventBus) Publish(ctx context.Context, event model.StatusChangeEvent) {
+	if event.FromStatus == event.ToStatus {
+		return
+	}
+
+	b.mu.RLock()
+	subs := b.subscribers
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		if err := s.OnStatusChange(ctx, event); err != nil {
+			log.Error().Err(err).
+				Str("subscriber", s.Name()).
+				Str("applicationId", event.ApplicationID.String()).
+				Str("toStatus", event.ToStatus).
+				Msg("Status change subscriber failed")
+		}
+	}
+}
@@ -2,24 +2,159 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
 	"github.com/yourusername/hireiq-api/internal/model"
 	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/requestid"
+	"github.com/yourusername/hireiq-api/internal/skills"
 )
 
 // FeedService orchestrates job feed refresh across multiple sources.
 type FeedService struct {
-	jsearch  *JSearchClient
-	remotive *RemotiveClient
-	adzuna   *AdzunaClient
-	feedRepo *repository.FeedRepo
-	userRepo *repository.UserRepo
+	jsearch   *JSearchClient
+	remotive  *RemotiveClient
+	adzuna    *AdzunaClient
+	feedRepo  *repository.FeedRepo
+	userRepo  *repository.UserRepo
+	subRepo   *repository.SubscriptionRepo
+	alertRepo *repository.JobAlertRepo
+	throttle  RefreshThrottle
+
+	// Upper bound on queries each source's builder may issue per refresh
+	maxQueries int
+
+	// Converts job salaries to the user's currency for scoring
+	rates CurrencyConverter
+
+	// In-memory progress of background refreshes, keyed by user
+	statusMu sync.Mutex
+	statuses map[uuid.UUID]*RefreshStatus
+}
+
+// Refresh states reported by GET /feed/refresh/status
+const (
+	RefreshStateIdle    = "idle"
+	RefreshStateRunning = "running"
+	RefreshStateDone    = "done"
+	RefreshStateFailed  = "failed"
+)
+
+// RefreshStatus is the progress of a user's most recent feed refresh
+type RefreshStatus struct {
+	State       string                  `json:"state"`
+	Fetched     int                     `json:"fetched"`
+	New         int                     `json:"new"`
+	Sources     map[string]SourceResult `json:"sources,omitempty"`
+	StartedAt   *time.Time              `json:"startedAt,omitempty"`
+	CompletedAt *time.Time              `json:"completedAt,omitempty"`
+	Error       string                  `json:"error,omitempty"`
+}
+
+// Feed source names, as reported in per-source refresh results
+const (
+	SourceJSearch  = "jsearch"
+	SourceRemotive = "remotive"
+	SourceAdzuna   = "adzuna"
+)
+
+// Per-source refresh outcomes
+const (
+	SourceStatusOK       = "ok"
+	SourceStatusPartial  = "partial"  // some queries failed
+	SourceStatusFailed   = "failed"   // every query failed
+	SourceStatusSkipped  = "skipped"  // nothing to search for this profile
+	SourceStatusDisabled = "disabled" // source not configured
+)
+
+// SourceResult is one source's contribution to a refresh
+type SourceResult struct {
+	Fetched int    `json:"fetched"`
+	New     int    `json:"new"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"` // user-safe summary
+	Err     error  `json:"-"`               // underlying error, for logs
+
+	// RateLimited means the source refused requests for quota reasons, so
+	// the UI can say it's temporarily unavailable rather than broken
+	RateLimited bool `json:"rateLimited,omitempty"`
+}
+
+// finish derives the status from how many of the source's queries failed
+func (r *SourceResult) finish(queries, failed int, lastErr error) {
+	switch {
+	case queries == 0:
+		r.Status = SourceStatusSkipped
+	case failed == 0:
+		r.Status = SourceStatusOK
+	case failed == queries:
+		r.Status = SourceStatusFailed
+		r.Err = lastErr
+		r.Error = "All queries failed"
+	default:
+		r.Status = SourceStatusPartial
+		r.Err = lastErr
+		r.Error = fmt.Sprintf("%d of %d queries failed", failed, queries)
+	}
+}
+
+// linkFailed marks the source failed when its jobs couldn't be linked to the
+// user: whatever it fetched never reached the feed
+func (r *SourceResult) linkFailed(err error) {
+	if err == nil {
+		return
+	}
+	r.New = 0
+	r.Status = SourceStatusFailed
+	r.Err = err
+	r.Error = "Failed to add jobs to your feed"
+}
+
+// RefreshResult is the outcome of RefreshUserFeed: totals plus a breakdown by source
+type RefreshResult struct {
+	Fetched   int
+	New       int
+	Sources   map[string]SourceResult
+	Throttled bool // skipped because the feed was refreshed recently
+}
+
+// RefreshThrottle is the minimum time between non-forced refreshes for each plan
+type RefreshThrottle struct {
+	Free    time.Duration
+	Pro     time.Duration
+	ProPlus time.Duration
+}
+
+// For returns the throttle window for a plan
+func (t RefreshThrottle) For(plan string) time.Duration {
+	switch plan {
+	case model.PlanProPlus:
+		return t.ProPlus
+	case model.PlanPro:
+		return t.Pro
+	default:
+		return t.Free
+	}
+}
+
+// DefaultMaxQueriesPerSource caps how many queries each source runs per refresh
+// when FEED_MAX_QUERIES_PER_SOURCE is unset. Each query is at least one
+// billable API call, so this trades feed breadth against cost.
+const DefaultMaxQueriesPerSource = 6
+
+// capQueries trims a source's query list to the configured maximum
+func capQueries[T any](queries []T, max int) []T {
+	if max > 0 && len(queries) > max {
+		return queries[:max]
+	}
+	return queries
 }
 
 func NewFeedService(
@@ -28,49 +163,178 @@ func NewFeedService(
 	adzuna *AdzunaClient,
 	feedRepo *repository.FeedRepo,
 	userRepo *repository.UserRepo,
+	subRepo *repository.SubscriptionRepo,
+	alertRepo *repository.JobAlertRepo,
+	throttle RefreshThrottle,
+	maxQueriesPerSource int,
+	rates CurrencyConverter,
 ) *FeedService {
+	if rates == nil {
+		rates = NewStaticRates(nil)
+	}
+	if maxQueriesPerSource <= 0 {
+		maxQueriesPerSource = DefaultMaxQueriesPerSource
+	}
+
 	return &FeedService{
-		jsearch:  jsearch,
-		remotive: remotive,
-		adzuna:   adzuna,
-		feedRepo: feedRepo,
-		userRepo: userRepo,
+		jsearch:    jsearch,
+		remotive:   remotive,
+		adzuna:     adzuna,
+		feedRepo:   feedRepo,
+		userRepo:   userRepo,
+		subRepo:    subRepo,
+		alertRepo:  alertRepo,
+		throttle:   throttle,
+		maxQueries: maxQueriesPerSource,
+		rates:      rates,
+		statuses:   make(map[uuid.UUID]*RefreshStatus),
+	}
+}
+
+// MaxAlerts is how many job alerts a refresh runs per source; alerts past it
+// would be stored but never searched
+func (s *FeedService) MaxAlerts() int {
+	return s.maxQueries
+}
+
+// StartBackgroundRefresh runs RefreshUserFeed in a detached goroutine and
+// tracks its progress for GetRefreshStatus. Returns false if a refresh is
+// already running for this user.
+func (s *FeedService) StartBackgroundRefresh(ctx context.Context, userID uuid.UUID, force bool) bool {
+	s.statusMu.Lock()
+	if st, ok := s.statuses[userID]; ok && st.State == RefreshStateRunning {
+		s.statusMu.Unlock()
+		return false
+	}
+	now := time.Now().UTC()
+	s.statuses[userID] = &RefreshStatus{State: RefreshStateRunning, StartedAt: &now}
+	s.statusMu.Unlock()
+
+	// Detached context so the refresh isn't cancelled when the HTTP response is
+	// sent; it keeps the request ID so its logs tie back to the request
+	go func() {
+		bgCtx, cancel := context.WithTimeout(requestid.Detach(ctx), 90*time.Second)
+		defer cancel()
+
+		result, err := s.RefreshUserFeed(bgCtx, userID, force)
+
+		done := time.Now().UTC()
+		st := &RefreshStatus{
+			State:       RefreshStateDone,
+			StartedAt:   &now,
+			CompletedAt: &done,
+		}
+		if err != nil {
+			st.State = RefreshStateFailed
+			st.Error = "Feed refresh failed"
+			requestid.Logger(bgCtx).Error().Err(err).Str("userId", userID.String()).Msg("Background feed refresh failed")
+		} else {
+			st.Fetched = result.Fetched
+			st.New = result.New
+			st.Sources = result.Sources
+		}
+
+		s.statusMu.Lock()
+		s.statuses[userID] = st
+		s.statusMu.Unlock()
+	}()
+
+	return true
+}
+
+// GetRefreshStatus returns the in-memory status of the user's latest refresh.
+// After a restart (or on another instance) it falls back to the most recent
+// feed_refresh_log row.
+func (s *FeedService) GetRefreshStatus(ctx context.Context, userID uuid.UUID) (*RefreshStatus, error) {
+	s.statusMu.Lock()
+	if st, ok := s.statuses[userID]; ok {
+		cp := *st
+		s.statusMu.Unlock()
+		return &cp, nil
+	}
+	s.statusMu.Unlock()
+
+	last, err := s.feedRepo.GetLatestRefreshLog(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if last == nil {
+		return &RefreshStatus{State: RefreshStateIdle}, nil
+	}
+	completed := last.RefreshedAt
+	st := &RefreshStatus{
+		State:       RefreshStateDone,
+		Fetched:     last.JobsFetched,
+		New:         last.JobsNew,
+		CompletedAt: &completed,
+	}
+	if len(last.Sources) > 0 {
+		if err := json.Unmarshal(last.Sources, &st.Sources); err != nil {
+			requestid.Logger(ctx).Warn().Err(err).Msg("Failed to decode logged refresh sources")
+		}
+	}
+	return st, nil
+}
+
+// refreshWindow resolves the user's plan and returns its throttle window.
+// Subscription lookup failures fall back to the free-tier window.
+func (s *FeedService) refreshWindow(ctx context.Context, userID uuid.UUID) time.Duration {
+	plan := model.PlanFree
+	if s.subRepo != nil {
+		sub, err := s.subRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			requestid.Logger(ctx).Warn().Err(err).Msg("Failed to look up subscription for refresh throttle")
+		} else {
+			plan = model.EffectivePlan(sub)
+		}
 	}
+	return s.throttle.For(plan)
 }
 
 // RefreshUserFeed fetches new jobs for a user based on their profile.
 // Set force=true to bypass the refresh throttle.
-// Sources are fetched concurrently to keep total latency manageable.
-func (s *FeedService) RefreshUserFeed(ctx context.Context, userID uuid.UUID, force bool) (int, int, error) {
+// Sources are fetched concurrently to keep total latency manageable; one
+// source failing doesn't fail the refresh, it shows up in the per-source results.
+func (s *FeedService) RefreshUserFeed(ctx context.Context, userID uuid.UUID, force bool) (*RefreshResult, error) {
 	// Get user profile
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil || user == nil {
-		return 0, 0, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	// Check if refresh is needed (throttle to once per 2 hours, skippable with force)
+	// Check if refresh is needed (throttle window depends on plan, skippable with force)
 	if !force {
+		window := s.refreshWindow(ctx, userID)
 		lastRefresh, err := s.feedRepo.GetLastRefresh(ctx, userID)
 		if err != nil {
-			log.Warn().Err(err).Msg("Failed to check last refresh, continuing anyway")
+			requestid.Logger(ctx).Warn().Err(err).Msg("Failed to check last refresh, continuing anyway")
 		}
-		if lastRefresh != nil && time.Since(*lastRefresh) < 2*time.Hour {
-			log.Info().
+		if lastRefresh != nil && time.Since(*lastRefresh) < window {
+			requestid.Logger(ctx).Info().
 				Str("userId", userID.String()).
 				Time("lastRefresh", *lastRefresh).
+				Dur("throttle", window).
 				Msg("Feed recently refreshed, skipping")
-			return 0, 0, nil
+			return &RefreshResult{Sources: map[string]SourceResult{}, Throttled: true}, nil
 		}
 	}
 
-	// Use a 90-second timeout for the entire refresh to prevent runaway requests
-	refreshCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
+	// Time out the entire refresh to prevent runaway requests. Scheduled
+	// refreshes also get the time their queries may spend queued on the
+	// shared source limiters, so users late in a batch aren't starved.
+	refreshCtx, cancel := context.WithTimeout(ctx, refreshTimeout+sourceQueueAllowance(ctx))
 	defer cancel()
 
 	// Run all sources concurrently
 	var mu sync.Mutex
-	totalFetched := 0
-	totalNew := 0
+	result := &RefreshResult{Sources: make(map[string]SourceResult)}
+	record := func(source string, r SourceResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Sources[source] = r
+		result.Fetched += r.Fetched
+		result.New += r.New
+	}
 
 	var wg sync.WaitGroup
 
@@ -79,12 +343,10 @@ func (s *FeedService) RefreshUserFeed(ctx context.Context, userID uuid.UUID, for
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			f, n := s.refreshFromJSearch(refreshCtx, user, userID)
-			mu.Lock()
-			totalFetched += f
-			totalNew += n
-			mu.Unlock()
+			record(SourceJSearch, s.refreshFromJSearch(refreshCtx, user, userID))
 		}()
+	} else {
+		record(SourceJSearch, SourceResult{Status: SourceStatusDisabled})
 	}
 
 	// ── Source 2: Remotive (always available, no key) ──
@@ -92,12 +354,10 @@ func (s *FeedService) RefreshUserFeed(ctx context.Context, userID uuid.UUID, for
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			f, n := s.refreshFromRemotive(refreshCtx, user, userID)
-			mu.Lock()
-			totalFetched += f
-			totalNew += n
-			mu.Unlock()
+			record(SourceRemotive, s.refreshFromRemotive(refreshCtx, user, userID))
 		}()
+	} else {
+		record(SourceRemotive, SourceResult{Status: SourceStatusDisabled})
 	}
 
 	// ── Source 3: Adzuna (only if configured) ──────────
@@ -105,42 +365,138 @@ func (s *FeedService) RefreshUserFeed(ctx context.Context, userID uuid.UUID, for
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			f, n := s.refreshFromAdzuna(refreshCtx, user, userID)
-			mu.Lock()
-			totalFetched += f
-			totalNew += n
-			mu.Unlock()
+			record(SourceAdzuna, s.refreshFromAdzuna(refreshCtx, user, userID))
+		}()
+	} else {
+		record(SourceAdzuna, SourceResult{Status: SourceStatusDisabled})
+	}
+
+	// ── Job alerts: the user's saved searches ─────────
+	if s.alertRepo != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record(SourceAlerts, s.refreshFromAlerts(refreshCtx, user, userID))
 		}()
 	}
 
 	wg.Wait()
 
-	// Log combined refresh
-	if err := s.feedRepo.LogRefresh(ctx, userID, "multi-source", totalFetched, totalNew); err != nil {
-		log.Warn().Err(err).Msg("Failed to log refresh")
+	// Log combined refresh, with the per-source breakdown so the status
+	// endpoint can still report failed sources after a restart
+	sourcesJSON, _ := json.Marshal(result.Sources)
+	trigger := repository.RefreshTriggerUser
+	if isScheduledRefresh(ctx) {
+		trigger = repository.RefreshTriggerScheduler
+	}
+	if err := s.feedRepo.LogRefresh(ctx, userID, "multi-source", result.Fetched, result.New, sourcesJSON, trigger); err != nil {
+		requestid.Logger(ctx).Warn().Err(err).Msg("Failed to log refresh")
 	}
 
-	log.Info().
+	sources := zerolog.Dict()
+	for source, r := range result.Sources {
+		sources.Dict(source, zerolog.Dict().
+			Str("status", r.Status).
+			Int("fetched", r.Fetched).
+			Int("new", r.New).
+			AnErr("error", r.Err))
+	}
+	requestid.Logger(ctx).Info().
 		Str("userId", userID.String()).
-		Int("fetched", totalFetched).
-		Int("new", totalNew).
+		Int("fetched", result.Fetched).
+		Int("new", result.New).
+		Dict("sources", sources).
 		Msg("Feed refresh complete (all sources)")
 
-	return totalFetched, totalNew, nil
+	return result, nil
+}
+
+// FeedQueryPreview is what a refresh would search for, per source
+type FeedQueryPreview struct {
+	JSearch  []JSearchQuery  `json:"jsearch"`
+	Remotive []RemotiveQuery `json:"remotive"` // empty for onsite-only profiles
+	Adzuna   []AdzunaQuery   `json:"adzuna"`
+}
+
+// PreviewQueries builds the queries a refresh would run for the user's current
+// profile without calling any source
+func (s *FeedService) PreviewQueries(ctx context.Context, userID uuid.UUID) (*FeedQueryPreview, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	preview := &FeedQueryPreview{
+		JSearch:  BuildQueriesFromProfile(user, s.maxQueries),
+		Remotive: BuildRemotiveQueries(user, s.maxQueries),
+		Adzuna:   BuildAdzunaQueries(user, s.maxQueries),
+	}
+	if preview.JSearch == nil {
+		preview.JSearch = []JSearchQuery{}
+	}
+	if preview.Remotive == nil {
+		preview.Remotive = []RemotiveQuery{}
+	}
+	if preview.Adzuna == nil {
+		preview.Adzuna = []AdzunaQuery{}
+	}
+	return preview, nil
 }
 
 // ── Per-source refresh helpers ───────────────────────
 
-func (s *FeedService) refreshFromJSearch(ctx context.Context, user *model.User, userID uuid.UUID) (int, int) {
-	queries := BuildQueriesFromProfile(user)
+// linkFlushTimeout bounds linking a source's jobs once its queries are done,
+// which runs even if the refresh budget is spent
+const linkFlushTimeout = 10 * time.Second
+
+// refreshTimeout is a refresh's budget for running its queries
+const refreshTimeout = 90 * time.Second
+
+// budgetSpent reports whether the refresh was cancelled or hit its deadline,
+// logging the queries it leaves unrun. Sources check it between queries so
+// an exhausted budget stops the loop instead of failing each query in turn.
+func budgetSpent(ctx context.Context, source string, skipped int) bool {
+	select {
+	case <-ctx.Done():
+		requestid.Logger(ctx).Warn().Err(ctx.Err()).Str("source", source).Int("skippedQueries", skipped).Msg("Refresh budget spent, skipping remaining queries")
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *FeedService) refreshFromJSearch(ctx context.Context, user *model.User, userID uuid.UUID) SourceResult {
+	queries := BuildQueriesFromProfile(user, s.maxQueries)
 	fetched, newJobs := 0, 0
+	failed, lastErr := 0, error(nil)
+	var links []repository.FeedLink
 
-	log.Info().Int("queryCount", len(queries)).Msg("JSearch: starting refresh")
+	requestid.Logger(ctx).Info().Int("queryCount", len(queries)).Msg("JSearch: starting refresh")
 
-	for _, q := range queries {
+	rateLimited := false
+	for i, q := range queries {
+		if budgetSpent(ctx, SourceJSearch, len(queries)-i) {
+			failed, lastErr = failed+len(queries)-i, ctx.Err()
+			break
+		}
+		if err := waitForSource(ctx, SourceJSearch); err != nil {
+			failed, lastErr = failed+1, err
+			continue
+		}
 		results, err := s.jsearch.Search(ctx, q)
+		if errors.Is(err, ErrRateLimited) {
+			// The quota is spent; the remaining queries would only fail the same way
+			requestid.Logger(ctx).Warn().Err(err).Str("source", "jsearch").Str("query", q.Query).Msg("Rate limited, skipping remaining queries")
+			failed, lastErr = failed+len(queries)-i, err
+			rateLimited = true
+			break
+		}
 		if err != nil {
-			log.Error().Err(err).Str("source", "jsearch").Str("query", q.Query).Msg("Query failed")
+			requestid.Logger(ctx).Error().Err(err).Str("source", "jsearch").Str("query", q.Query).Msg("Query failed")
+			failed, lastErr = failed+1, err
 			continue
 		}
 		fetched += len(results)
@@ -148,13 +504,14 @@ func (s *FeedService) refreshFromJSearch(ctx context.Context, user *model.User,
 		queryNew := 0
 		for _, jsJob := range results {
 			feedJob := convertJSearchJob(jsJob)
-			if s.upsertAndLink(ctx, userID, user, feedJob) {
+			if link, ok := s.upsertAndScore(ctx, user, feedJob, nil); ok {
+				links = append(links, link)
 				queryNew++
 			}
 		}
 		newJobs += queryNew
 
-		log.Info().
+		requestid.Logger(ctx).Info().
 			Str("source", "jsearch").
 			Str("query", q.Query).
 			Int("results", len(results)).
@@ -162,25 +519,45 @@ func (s *FeedService) refreshFromJSearch(ctx context.Context, user *model.User,
 			Msg("Query complete")
 	}
 
-	log.Info().Str("source", "jsearch").Int("fetched", fetched).Int("new", newJobs).Msg("JSearch refresh done")
-	return fetched, newJobs
+	linkErr := s.flushLinks(ctx, userID, SourceJSearch, links)
+
+	requestid.Logger(ctx).Info().Str("source", "jsearch").Int("fetched", fetched).Int("new", newJobs).Int("failedQueries", failed).Msg("JSearch refresh done")
+	res := SourceResult{Fetched: fetched, New: newJobs}
+	res.finish(len(queries), failed, lastErr)
+	if rateLimited {
+		res.RateLimited = true
+		res.Error = "JSearch is temporarily unavailable (rate limited)"
+	}
+	res.linkFailed(linkErr)
+	return res
 }
 
-func (s *FeedService) refreshFromRemotive(ctx context.Context, user *model.User, userID uuid.UUID) (int, int) {
-	queries := BuildRemotiveQueries(user)
+func (s *FeedService) refreshFromRemotive(ctx context.Context, user *model.User, userID uuid.UUID) SourceResult {
+	queries := BuildRemotiveQueries(user, s.maxQueries)
 	if len(queries) == 0 {
-		log.Info().Str("source", "remotive").Str("workStyle", user.WorkStyle).Msg("Remotive skipped (no queries)")
-		return 0, 0
+		requestid.Logger(ctx).Info().Str("source", "remotive").Str("workStyle", user.WorkStyle).Msg("Remotive skipped (no queries)")
+		return SourceResult{Status: SourceStatusSkipped}
 	}
 
 	fetched, newJobs := 0, 0
+	failed, lastErr := 0, error(nil)
+	var links []repository.FeedLink
 
-	log.Info().Int("queryCount", len(queries)).Str("workStyle", user.WorkStyle).Msg("Remotive: starting refresh")
+	requestid.Logger(ctx).Info().Int("queryCount", len(queries)).Str("workStyle", user.WorkStyle).Msg("Remotive: starting refresh")
 
-	for _, q := range queries {
+	for i, q := range queries {
+		if budgetSpent(ctx, SourceRemotive, len(queries)-i) {
+			failed, lastErr = failed+len(queries)-i, ctx.Err()
+			break
+		}
+		if err := waitForSource(ctx, SourceRemotive); err != nil {
+			failed, lastErr = failed+1, err
+			continue
+		}
 		results, err := s.remotive.Search(ctx, q)
 		if err != nil {
-			log.Error().Err(err).Str("source", "remotive").Str("search", q.Search).Str("category", q.Category).Msg("Query failed")
+			requestid.Logger(ctx).Error().Err(err).Str("source", "remotive").Str("search", q.Search).Str("category", q.Category).Msg("Query failed")
+			failed, lastErr = failed+1, err
 			continue
 		}
 		fetched += len(results)
@@ -188,13 +565,14 @@ func (s *FeedService) refreshFromRemotive(ctx context.Context, user *model.User,
 		queryNew := 0
 		for _, rjJob := range results {
 			feedJob := convertRemotiveJob(rjJob)
-			if s.upsertAndLink(ctx, userID, user, feedJob) {
+			if link, ok := s.upsertAndScore(ctx, user, feedJob, nil); ok {
+				links = append(links, link)
 				queryNew++
 			}
 		}
 		newJobs += queryNew
 
-		log.Info().
+		requestid.Logger(ctx).Info().
 			Str("source", "remotive").
 			Str("search", q.Search).
 			Str("category", q.Category).
@@ -204,34 +582,51 @@ func (s *FeedService) refreshFromRemotive(ctx context.Context, user *model.User,
 			Msg("Query complete")
 	}
 
-	log.Info().Str("source", "remotive").Int("fetched", fetched).Int("new", newJobs).Msg("Remotive refresh done")
-	return fetched, newJobs
+	linkErr := s.flushLinks(ctx, userID, SourceRemotive, links)
+
+	requestid.Logger(ctx).Info().Str("source", "remotive").Int("fetched", fetched).Int("new", newJobs).Int("failedQueries", failed).Msg("Remotive refresh done")
+	res := SourceResult{Fetched: fetched, New: newJobs}
+	res.finish(len(queries), failed, lastErr)
+	res.linkFailed(linkErr)
+	return res
 }
 
-func (s *FeedService) refreshFromAdzuna(ctx context.Context, user *model.User, userID uuid.UUID) (int, int) {
-	queries := BuildAdzunaQueries(user)
+func (s *FeedService) refreshFromAdzuna(ctx context.Context, user *model.User, userID uuid.UUID) SourceResult {
+	queries := BuildAdzunaQueries(user, s.maxQueries)
 	fetched, newJobs := 0, 0
+	failed, lastErr := 0, error(nil)
+	var links []repository.FeedLink
 
-	log.Info().Int("queryCount", len(queries)).Msg("Adzuna: starting refresh")
+	requestid.Logger(ctx).Info().Int("queryCount", len(queries)).Msg("Adzuna: starting refresh")
 
-	for _, q := range queries {
+	for i, q := range queries {
+		if budgetSpent(ctx, SourceAdzuna, len(queries)-i) {
+			failed, lastErr = failed+len(queries)-i, ctx.Err()
+			break
+		}
+		if err := waitForSource(ctx, SourceAdzuna); err != nil {
+			failed, lastErr = failed+1, err
+			continue
+		}
 		results, err := s.adzuna.Search(ctx, q)
 		if err != nil {
-			log.Error().Err(err).Str("source", "adzuna").Str("keywords", q.Keywords).Msg("Query failed")
+			requestid.Logger(ctx).Error().Err(err).Str("source", "adzuna").Str("keywords", q.Keywords).Msg("Query failed")
+			failed, lastErr = failed+1, err
 			continue
 		}
 		fetched += len(results)
 
 		queryNew := 0
 		for _, ajJob := range results {
-			feedJob := convertAdzunaJob(ajJob)
-			if s.upsertAndLink(ctx, userID, user, feedJob) {
+			feedJob := convertAdzunaJob(ajJob, q.Country)
+			if link, ok := s.upsertAndScore(ctx, user, feedJob, nil); ok {
+				links = append(links, link)
 				queryNew++
 			}
 		}
 		newJobs += queryNew
 
-		log.Info().
+		requestid.Logger(ctx).Info().
 			Str("source", "adzuna").
 			Str("keywords", q.Keywords).
 			Int("results", len(results)).
@@ -239,29 +634,48 @@ func (s *FeedService) refreshFromAdzuna(ctx context.Context, user *model.User, u
 			Msg("Query complete")
 	}
 
-	log.Info().Str("source", "adzuna").Int("fetched", fetched).Int("new", newJobs).Msg("Adzuna refresh done")
-	return fetched, newJobs
+	linkErr := s.flushLinks(ctx, userID, SourceAdzuna, links)
+
+	requestid.Logger(ctx).Info().Str("source", "adzuna").Int("fetched", fetched).Int("new", newJobs).Int("failedQueries", failed).Msg("Adzuna refresh done")
+	res := SourceResult{Fetched: fetched, New: newJobs}
+	res.finish(len(queries), failed, lastErr)
+	res.linkFailed(linkErr)
+	return res
 }
 
-// upsertAndLink is the shared upsert + score + link logic for all sources.
-func (s *FeedService) upsertAndLink(ctx context.Context, userID uuid.UUID, user *model.User, feedJob *model.FeedJob) bool {
+// upsertAndScore is the shared upsert + score logic for all sources. It
+// returns the user_feed link to write for the job; links are collected per
+// source and written together by flushLinks. alertID marks jobs found by a
+// job alert's query; nil for profile queries.
+func (s *FeedService) upsertAndScore(ctx context.Context, user *model.User, feedJob *model.FeedJob, alertID *uuid.UUID) (repository.FeedLink, bool) {
 	// Sanitize all string fields to ensure valid UTF-8 for PostgreSQL
 	sanitizeFeedJob(feedJob)
 
 	stored, err := s.feedRepo.UpsertFeedJob(ctx, feedJob)
 	if err != nil {
-		log.Error().Err(err).Str("source", feedJob.Source).Str("externalId", feedJob.ExternalID).Msg("Failed to upsert feed job")
-		return false
+		requestid.Logger(ctx).Error().Err(err).Str("source", feedJob.Source).Str("externalId", feedJob.ExternalID).Msg("Failed to upsert feed job")
+		return repository.FeedLink{}, false
 	}
 
-	score := calculateMatchScore(user, stored)
+	return repository.FeedLink{
+		FeedJobID:  stored.ID,
+		MatchScore: calculateMatchScore(user, stored, s.rates),
+		AlertID:    alertID,
+	}, true
+}
 
-	if err := s.feedRepo.LinkJobToUser(ctx, userID, stored.ID, score); err != nil {
-		log.Error().Err(err).Str("source", feedJob.Source).Msg("Failed to link job to user")
-		return false
-	}
+// flushLinks links a source's scored jobs to the user in one batch
+func (s *FeedService) flushLinks(ctx context.Context, userID uuid.UUID, source string, links []repository.FeedLink) error {
+	// The refresh deadline may already have passed; jobs fetched before it
+	// should still reach the feed
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), linkFlushTimeout)
+	defer cancel()
 
-	return true
+	if err := s.feedRepo.LinkJobsToUser(ctx, userID, links); err != nil {
+		requestid.Logger(ctx).Error().Err(err).Str("source", source).Int("jobs", len(links)).Msg("Failed to link jobs to user")
+		return err
+	}
+	return nil
 }
 
 // RescoreUserFeed recalculates match scores for all existing feed jobs
@@ -283,14 +697,14 @@ func (s *FeedService) RescoreUserFeed(ctx context.Context, userID uuid.UUID) (in
 
 	scores := make(map[uuid.UUID]int, len(jobs))
 	for i := range jobs {
-		scores[jobs[i].ID] = calculateMatchScore(user, &jobs[i])
+		scores[jobs[i].ID] = calculateMatchScore(user, &jobs[i], s.rates)
 	}
 
 	if err := s.feedRepo.BatchUpdateMatchScores(ctx, userID, scores); err != nil {
 		return 0, fmt.Errorf("batch updating scores: %w", err)
 	}
 
-	log.Info().
+	requestid.Logger(ctx).Info().
 		Str("userId", userID.String()).
 		Int("rescored", len(scores)).
 		Msg("Feed match scores recalculated")
@@ -315,23 +729,17 @@ func convertJSearchJob(js JSearchJob) *model.FeedJob {
 		}
 	}
 
-	// Parse salary
-	salaryMin := 0
-	salaryMax := 0
-	salaryText := ""
+	// Parse salary — text keeps the posted period, min/max are annualized for scoring
+	var rawMin, rawMax float64
 	if js.JobMinSalary != nil {
-		salaryMin = int(*js.JobMinSalary)
+		rawMin = *js.JobMinSalary
 	}
 	if js.JobMaxSalary != nil {
-		salaryMax = int(*js.JobMaxSalary)
-	}
-	if salaryMin > 0 || salaryMax > 0 {
-		if js.JobSalaryPeriod == "YEAR" {
-			salaryText = fmt.Sprintf("$%dk - $%dk/yr", salaryMin/1000, salaryMax/1000)
-		} else if js.JobSalaryPeriod == "HOUR" {
-			salaryText = fmt.Sprintf("$%d - $%d/hr", salaryMin, salaryMax)
-		}
+		rawMax = *js.JobMaxSalary
 	}
+	currency := normalizeCurrency(js.JobSalaryCurrency)
+	salaryText := formatSalaryText(int(rawMin), int(rawMax), js.JobSalaryPeriod, currency)
+	salaryMin, salaryMax := normalizeToAnnual(rawMin, rawMax, js.JobSalaryPeriod)
 
 	// Parse employment type
 	jobType := "full-time"
@@ -355,11 +763,6 @@ func convertJSearchJob(js JSearchJob) *model.FeedJob {
 	// Truncate description for storage (UTF-8 safe)
 	desc := truncateUTF8(js.JobDescription, 2000)
 
-	skills := js.JobRequiredSkills
-	if skills == nil {
-		skills = []string{}
-	}
-
 	return &model.FeedJob{
 		ExternalID:     js.JobID,
 		Source:         "jsearch",
@@ -369,15 +772,32 @@ func convertJSearchJob(js JSearchJob) *model.FeedJob {
 		SalaryMin:      salaryMin,
 		SalaryMax:      salaryMax,
 		SalaryText:     salaryText,
+		SalaryCurrency: currency,
 		JobType:        jobType,
 		Description:    desc,
-		RequiredSkills: skills,
+		RequiredSkills: skills.Normalize(js.JobRequiredSkills),
 		ApplyURL:       js.JobApplyLink,
 		CompanyLogo:    js.EmployerLogo,
 		PostedAt:       postedAt,
 	}
 }
 
+// isTargetCompany reports whether company is one of the user's target
+// companies, ignoring case, punctuation, and legal suffixes ("Stripe, Inc."
+// matches "stripe")
+func isTargetCompany(targets []string, company string) bool {
+	key := model.NormalizeCompanyName(company)
+	if key == "" {
+		return false
+	}
+	for _, t := range targets {
+		if model.NormalizeCompanyName(t) == key {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateMatchScore computes a 0-100 match score between a user and a feed job.
 // Scoring breakdown:
 //   - Target role match:  up to +25 points (highest weight)
@@ -385,8 +805,9 @@ func convertJSearchJob(js JSearchJob) *model.FeedJob {
 //   - Keyword mentions:   up to +10 points
 //   - Location match:     up to +5 points
 //   - Salary match:       up to +5 points
+//   - Target company:     +20 points
 //   - Base:               30 points
-func calculateMatchScore(user *model.User, job *model.FeedJob) int {
+func calculateMatchScore(user *model.User, job *model.FeedJob, rates CurrencyConverter) int {
 	score := 30 // Base score
 
 	jobTitleLower := strings.ToLower(job.Title)
@@ -433,15 +854,12 @@ func calculateMatchScore(user *model.User, job *model.FeedJob) int {
 
 	// ── Skill overlap (up to +25 points) ──
 	if len(user.Skills) > 0 {
-		userSkillSet := make(map[string]bool, len(user.Skills))
-		for _, s := range user.Skills {
-			userSkillSet[strings.ToLower(s)] = true
-		}
+		userSkillSet := skillSet(user.Skills)
 
 		if len(job.RequiredSkills) > 0 {
 			matches := 0
 			for _, jobSkill := range job.RequiredSkills {
-				if userSkillSet[strings.ToLower(jobSkill)] {
+				if userSkillSet[normalizeSkill(jobSkill)] {
 					matches++
 				}
 			}
@@ -452,7 +870,7 @@ func calculateMatchScore(user *model.User, job *model.FeedJob) int {
 		// Skill keyword mentions in title/description (up to +10 points)
 		skillMentions := 0
 		for _, skill := range user.Skills {
-			if strings.Contains(jobTextLower, strings.ToLower(skill)) {
+			if skills.Mentioned(jobTextLower, skill) {
 				skillMentions++
 			}
 		}
@@ -475,12 +893,21 @@ func calculateMatchScore(user *model.User, job *model.FeedJob) int {
 	}
 
 	// ── Salary match (+5 points) ──
+	// Compared in the user's currency; skipped when no rate is known
 	if user.SalaryMin > 0 && job.SalaryMax > 0 {
-		if job.SalaryMax >= user.SalaryMin {
+		jobMax, ok := rates.Convert(job.SalaryMax, job.SalaryCurrency, user.SalaryCurrency)
+		if ok && jobMax >= user.SalaryMin {
 			score += 5
 		}
 	}
 
+	// ── Target company match (+20 points) ──
+	// Users naming employers want their openings surfaced even when the other
+	// signals are weak
+	if isTargetCompany(user.TargetCompanies, job.Company) {
+		score += 20
+	}
+
 	// Cap at 100
 	if score > 100 {
 		score = 100
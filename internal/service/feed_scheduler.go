@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/requestid"
+	"golang.org/x/time/rate"
+)
+
+// feedSchedulerActiveWithin limits the nightly run to users who refreshed
+// their feed themselves recently; dormant accounts aren't worth the API spend
+const feedSchedulerActiveWithin = 14 * 24 * time.Hour
+
+// FeedSchedulerReport summarizes one scheduled refresh run
+type FeedSchedulerReport struct {
+	Users     int // users considered
+	Refreshed int // refreshes that ran
+	Throttled int // skipped because the user's feed was refreshed recently
+	Failed    int
+	Fetched   int
+	New       int
+	Duration  time.Duration
+}
+
+// FeedScheduler refreshes active users' feeds once a day. Users are refreshed
+// concurrency at a time, and every source call in the run waits on a limiter
+// shared across the whole batch, so a large user base can't burn through the
+// RapidAPI or Adzuna quota in one burst.
+type FeedScheduler struct {
+	feed        *FeedService
+	feedRepo    *repository.FeedRepo
+	concurrency int
+	perMinute   int // source queries per minute, per source, across the batch
+}
+
+func NewFeedScheduler(feed *FeedService, feedRepo *repository.FeedRepo, concurrency, perMinute int) *FeedScheduler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &FeedScheduler{feed: feed, feedRepo: feedRepo, concurrency: concurrency, perMinute: perMinute}
+}
+
+// RunOnce refreshes every recently active user, honoring each user's plan
+// throttle
+func (f *FeedScheduler) RunOnce(ctx context.Context) (*FeedSchedulerReport, error) {
+	start := time.Now()
+	report := &FeedSchedulerReport{}
+
+	userIDs, err := f.feedRepo.RecentlyRefreshedUsers(ctx, time.Now().Add(-feedSchedulerActiveWithin))
+	if err != nil {
+		return nil, err
+	}
+	report.Users = len(userIDs)
+
+	// Each user queues for at most their own queries (profile plus alerts)
+	// behind the other refreshes running alongside it
+	queriesPerUser := 2 * f.feed.maxQueries
+	ctx = withSourceLimiters(ctx, f.perMinute, f.concurrency*queriesPerUser)
+	ctx = context.WithValue(ctx, scheduledRefreshKey{}, true)
+
+	var refreshed, throttled, failed, fetched, newJobs atomic.Int64
+	sem := make(chan struct{}, f.concurrency)
+	var wg sync.WaitGroup
+	for _, userID := range userIDs {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(userID uuid.UUID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			userCtx := requestid.NewContext(ctx, "feed-scheduler-"+uuid.NewString())
+			result, err := f.feed.RefreshUserFeed(userCtx, userID, false)
+			switch {
+			case err != nil:
+				failed.Add(1)
+				requestid.Logger(userCtx).Warn().Err(err).Str("userId", userID.String()).Msg("Scheduled feed refresh failed")
+			case result.Throttled:
+				throttled.Add(1)
+			default:
+				refreshed.Add(1)
+				fetched.Add(int64(result.Fetched))
+				newJobs.Add(int64(result.New))
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	report.Refreshed = int(refreshed.Load())
+	report.Throttled = int(throttled.Load())
+	report.Failed = int(failed.Load())
+	report.Fetched = int(fetched.Load())
+	report.New = int(newJobs.Load())
+	report.Duration = time.Since(start)
+	return report, ctx.Err()
+}
+
+// StartDailyLoop runs RunOnce once a day at the given UTC hour until ctx is
+// done
+func (f *FeedScheduler) StartDailyLoop(ctx context.Context, hourUTC int) {
+	go func() {
+		for {
+			wait := time.Until(nextRunAt(time.Now(), hourUTC))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				// Leave an hour of slack before the next day's run
+				runCtx, cancel := context.WithTimeout(ctx, 23*time.Hour)
+				report, err := f.RunOnce(runCtx)
+				cancel()
+				if err != nil {
+					log.Error().Err(err).Msg("Scheduled feed refresh run failed")
+				}
+				if report == nil {
+					continue
+				}
+				usersPerMin := 0.0
+				if mins := report.Duration.Minutes(); mins > 0 {
+					usersPerMin = float64(report.Refreshed+report.Throttled+report.Failed) / mins
+				}
+				log.Info().
+					Int("users", report.Users).
+					Int("refreshed", report.Refreshed).
+					Int("throttled", report.Throttled).
+					Int("failed", report.Failed).
+					Int("fetched", report.Fetched).
+					Int("new", report.New).
+					Dur("duration", report.Duration).
+					Float64("usersPerMinute", usersPerMin).
+					Int("concurrency", f.concurrency).
+					Msg("Scheduled feed refresh complete")
+			}
+		}
+	}()
+}
+
+// scheduledRefreshKey marks contexts belonging to a FeedScheduler run, so
+// those refreshes are logged as scheduled rather than user activity
+type scheduledRefreshKey struct{}
+
+func isScheduledRefresh(ctx context.Context) bool {
+	scheduled, _ := ctx.Value(scheduledRefreshKey{}).(bool)
+	return scheduled
+}
+
+// ── Shared per-source limits ────────────────────────────
+
+type sourceLimitersKey struct{}
+
+// sourceLimits are the shared limiters of one scheduled run
+type sourceLimits struct {
+	limiters map[string]*rate.Limiter
+	// maxQueueWait is the longest one refresh can spend waiting on a
+	// limiter, which its budget is extended by
+	maxQueueWait time.Duration
+}
+
+// withSourceLimiters attaches one limiter per feed source to ctx, allowing
+// perMinute queries per minute to that source across everything using ctx.
+// maxQueued is how many queries can be waiting on one source ahead of a
+// refresh's last query; it sizes the extra budget each refresh gets for
+// queuing. perMinute <= 0 leaves calls unlimited.
+func withSourceLimiters(ctx context.Context, perMinute, maxQueued int) context.Context {
+	if perMinute <= 0 {
+		return ctx
+	}
+	interval := time.Minute / time.Duration(perMinute)
+	every := rate.Every(interval)
+	return context.WithValue(ctx, sourceLimitersKey{}, &sourceLimits{
+		limiters: map[string]*rate.Limiter{
+			SourceJSearch:  rate.NewLimiter(every, 1),
+			SourceRemotive: rate.NewLimiter(every, 1),
+			SourceAdzuna:   rate.NewLimiter(every, 1),
+		},
+		maxQueueWait: time.Duration(maxQueued) * interval,
+	})
+}
+
+// waitForSource blocks until the source's shared limiter allows another
+// query. Contexts without limiters (user-triggered refreshes) never wait.
+func waitForSource(ctx context.Context, source string) error {
+	limits, _ := ctx.Value(sourceLimitersKey{}).(*sourceLimits)
+	if limits == nil {
+		return nil
+	}
+	if l := limits.limiters[source]; l != nil {
+		return l.Wait(ctx)
+	}
+	return nil
+}
+
+// sourceQueueAllowance is how much longer than refreshTimeout a refresh may
+// run because its queries queue on shared limiters; zero without limiters
+func sourceQueueAllowance(ctx context.Context) time.Duration {
+	limits, _ := ctx.Value(sourceLimitersKey{}).(*sourceLimits)
+	if limits == nil {
+		return 0
+	}
+	return limits.maxQueueWait
+}
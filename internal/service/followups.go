@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// FollowUpReminder emails each user a daily digest of follow-ups that are
+// due today or overdue
+type FollowUpReminder struct {
+	appRepo     *repository.ApplicationRepo
+	sender      EmailSender
+	frontendURL string
+}
+
+func NewFollowUpReminder(appRepo *repository.ApplicationRepo, sender EmailSender, frontendURL string) *FollowUpReminder {
+	return &FollowUpReminder{appRepo: appRepo, sender: sender, frontendURL: frontendURL}
+}
+
+// SendDueReminders sends one email per user covering every follow-up dated
+// before the end of today (UTC). Each send is claimed in the database first,
+// so a user gets one digest a day however many replicas run this. Returns
+// the number of emails sent.
+func (f *FollowUpReminder) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	endOfToday := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+	due, err := f.appRepo.DueFollowUps(ctx, endOfToday)
+	if err != nil {
+		return 0, err
+	}
+
+	// Rows are ordered by user, so each run of the same user is one digest
+	sent := 0
+	for start := 0; start < len(due); {
+		end := start
+		for end < len(due) && due[end].UserID == due[start].UserID {
+			end++
+		}
+		group := due[start:end]
+		start = end
+
+		if group[0].UserEmail == "" {
+			continue
+		}
+		userID := group[0].UserID
+		claimed, err := f.appRepo.ClaimFollowUpDigest(ctx, userID, now)
+		if err != nil {
+			log.Error().Err(err).Str("userId", userID.String()).Msg("Failed to claim follow-up reminder")
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err := f.sender.Send(ctx, f.buildDigest(group, now)); err != nil {
+			log.Error().Err(err).Str("userId", userID.String()).Msg("Failed to send follow-up reminder")
+			if err := f.appRepo.ReleaseFollowUpDigest(context.WithoutCancel(ctx), userID, now); err != nil {
+				log.Error().Err(err).Str("userId", userID.String()).Msg("Failed to release follow-up reminder claim")
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (f *FollowUpReminder) buildDigest(items []model.DueFollowUp, now time.Time) EmailMessage {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	subject := "1 follow-up due today"
+	if len(items) > 1 {
+		subject = fmt.Sprintf("%d follow-ups due", len(items))
+	}
+
+	var b strings.Builder
+	greeting := "Hi"
+	if fields := strings.Fields(items[0].UserName); len(fields) > 0 {
+		greeting = "Hi " + fields[0]
+	}
+	fmt.Fprintf(&b, "%s,\n\nThese follow-ups need your attention:\n\n", greeting)
+
+	for _, item := range items {
+		when := "due today"
+		if item.FollowUpDate.Before(today) {
+			days := int(today.Sub(item.FollowUpDate.UTC().Truncate(24*time.Hour)).Hours() / 24)
+			when = fmt.Sprintf("%d day(s) overdue", days)
+		}
+		urgent := ""
+		if item.FollowUpUrgent {
+			urgent = " [URGENT]"
+		}
+		fmt.Fprintf(&b, "- %s at %s (%s)%s\n", item.JobTitle, item.Company, when, urgent)
+		if item.FollowUpType != "" {
+			fmt.Fprintf(&b, "  Type: %s\n", item.FollowUpType)
+		}
+		if item.NextStep != "" {
+			fmt.Fprintf(&b, "  Next step: %s\n", item.NextStep)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nOpen your pipeline: %s/pipeline\n", strings.TrimRight(f.frontendURL, "/"))
+
+	return EmailMessage{To: items[0].UserEmail, Subject: subject, Body: b.String()}
+}
+
+// StartDailyLoop runs SendDueReminders once a day at the given UTC hour until
+// ctx is done
+func (f *FollowUpReminder) StartDailyLoop(ctx context.Context, hourUTC int) {
+	go func() {
+		for {
+			wait := time.Until(nextRunAt(time.Now(), hourUTC))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				runCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+				sent, err := f.SendDueReminders(runCtx, time.Now())
+				cancel()
+				if err != nil {
+					log.Error().Err(err).Msg("Follow-up reminder run failed")
+					continue
+				}
+				log.Info().Int("sent", sent).Msg("Follow-up reminders sent")
+			}
+		}
+	}()
+}
+
+// nextRunAt returns the next time at hourUTC:00 strictly after now
+func nextRunAt(now time.Time, hourUTC int) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
@@ -0,0 +1,65 @@
+package service
+
+import "github.com/yourusername/hireiq-api/internal/model"
+
+// stageRank orders the forward pipeline stages; closed statuses have no rank
+var stageRank = map[string]int{
+	model.StatusSaved:     0,
+	model.StatusApplied:   1,
+	model.StatusScreening: 2,
+	model.StatusInterview: 3,
+	model.StatusOffer:     4,
+}
+
+// BuildFunnel computes pipeline counts and success rates. Each application
+// counts at the furthest stage it ever reached, so one rejected after an
+// interview still counts as interviewed. A rejection is a response; being
+// ghosted is not.
+func BuildFunnel(apps []model.ApplicationProgress) *model.FunnelMetrics {
+	f := &model.FunnelMetrics{
+		ByStatus:  make(map[string]int),
+		ByOutcome: make(map[string]int),
+	}
+
+	for _, a := range apps {
+		f.Total++
+		f.ByStatus[a.Status]++
+		if a.Outcome != "" {
+			f.ByOutcome[a.Outcome]++
+		}
+
+		furthest := stageRank[a.Status]
+		for _, s := range a.Reached {
+			if r, ok := stageRank[s]; ok && r > furthest {
+				furthest = r
+			}
+		}
+		// Closed without a forward history (e.g. created as rejected) still
+		// implies the application was sent
+		if furthest == 0 && (a.HasAppliedAt || a.Outcome == model.OutcomeRejected ||
+			a.Outcome == model.OutcomeGhosted || a.Status == model.StatusRejected) {
+			furthest = stageRank[model.StatusApplied]
+		}
+
+		if furthest < stageRank[model.StatusApplied] {
+			continue
+		}
+		f.Applied++
+		if furthest >= stageRank[model.StatusScreening] || a.Outcome == model.OutcomeRejected || a.Status == model.StatusRejected {
+			f.Responded++
+		}
+		if furthest >= stageRank[model.StatusInterview] {
+			f.Interviewed++
+		}
+		if furthest >= stageRank[model.StatusOffer] {
+			f.Offers++
+		}
+	}
+
+	if f.Applied > 0 {
+		f.ResponseRate = f.Responded * 100 / f.Applied
+		f.InterviewRate = f.Interviewed * 100 / f.Applied
+		f.OfferRate = f.Offers * 100 / f.Applied
+	}
+	return f
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// outboundTransport is shared by every outbound client so connections and
+// TLS sessions to the same hosts are reused across services and requests,
+// rather than each client keeping its own small idle pool. There's no
+// response header timeout here: Claude only sends headers once generation
+// finishes, so each client's overall timeout is the only bound on waiting.
+var outboundTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          200,
+	MaxIdleConnsPerHost:   20, // feed refreshes hit the same few APIs in parallel
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: time.Second,
+}
+
+// NewHTTPClient returns a client on the shared outbound transport with the
+// given overall request timeout
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: outboundTransport}
+}
+
+// publicTransport is for requests to URLs that come from third-party data,
+// such as apply links in job feeds. It refuses to connect to loopback,
+// private, link-local and other non-public addresses, so a crafted URL can't
+// reach internal services or the cloud metadata endpoint. The check runs on
+// the resolved address at connect time, covering redirects and DNS names
+// that point inward. There's no proxy, since the proxy would be what's
+// checked.
+var publicTransport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   refuseNonPublic,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   4,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: time.Second,
+}
+
+// NewPublicHTTPClient returns a client that only connects to public
+// addresses, for fetching untrusted URLs
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: publicTransport}
+}
+
+// sharedAddressSpace is carrier-grade NAT space (RFC 6598), which some clouds
+// use for internal endpoints
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// refuseNonPublic is a net.Dialer Control hook rejecting non-public addresses
+func refuseNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(addr) {
+		return fmt.Errorf("refusing to connect to non-public address %s", addr)
+	}
+	return nil
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
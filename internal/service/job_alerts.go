@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/requestid"
+)
+
+// SourceAlerts is the per-source result key for jobs found by the user's
+// job alerts, across whichever sources are configured
+const SourceAlerts = "alerts"
+
+// refreshFromAlerts runs each of the user's job alerts against every enabled
+// source. Each source gets at most maxQueries alert queries per refresh, the
+// same budget as its profile queries, so the oldest alerts run first.
+func (s *FeedService) refreshFromAlerts(ctx context.Context, user *model.User, userID uuid.UUID) SourceResult {
+	alerts, err := s.alertRepo.ListByUser(ctx, userID)
+	if err != nil {
+		requestid.Logger(ctx).Error().Err(err).Str("source", SourceAlerts).Msg("Failed to load job alerts")
+		return SourceResult{Status: SourceStatusFailed, Error: "Failed to load job alerts", Err: err}
+	}
+	alerts = capQueries(alerts, s.maxQueries)
+
+	// One query per alert per source it runs on
+	type alertQuery struct {
+		alert  model.JobAlert
+		source string
+	}
+	var queries []alertQuery
+	for _, alert := range alerts {
+		for _, source := range []string{SourceJSearch, SourceRemotive, SourceAdzuna} {
+			if s.alertRunsOn(source, alert) {
+				queries = append(queries, alertQuery{alert, source})
+			}
+		}
+	}
+
+	fetched, newJobs := 0, 0
+	failed, lastErr := 0, error(nil)
+	var links []repository.FeedLink
+
+	for i, q := range queries {
+		if budgetSpent(ctx, SourceAlerts, len(queries)-i) {
+			failed, lastErr = failed+len(queries)-i, ctx.Err()
+			break
+		}
+		alert, source := q.alert, q.source
+
+		if err := waitForSource(ctx, source); err != nil {
+			failed, lastErr = failed+1, err
+			continue
+		}
+		results, err := s.searchForAlert(ctx, source, alert)
+		if err != nil {
+			requestid.Logger(ctx).Error().Err(err).Str("source", source).Str("alert", alert.Name).Msg("Alert query failed")
+			failed, lastErr = failed+1, err
+			continue
+		}
+		fetched += len(results)
+
+		queryNew := 0
+		for _, feedJob := range results {
+			if !s.meetsSalaryFloor(feedJob, alert.SalaryMin, user.SalaryCurrency) {
+				continue
+			}
+			if link, ok := s.upsertAndScore(ctx, user, feedJob, &alert.ID); ok {
+				links = append(links, link)
+				queryNew++
+			}
+		}
+		newJobs += queryNew
+
+		requestid.Logger(ctx).Info().
+			Str("source", source).
+			Str("alert", alert.Name).
+			Str("keywords", alert.Keywords).
+			Int("results", len(results)).
+			Int("new", queryNew).
+			Msg("Alert query complete")
+	}
+
+	linkErr := s.flushLinks(ctx, userID, SourceAlerts, links)
+
+	requestid.Logger(ctx).Info().Str("source", SourceAlerts).Int("alerts", len(alerts)).Int("fetched", fetched).Int("new", newJobs).Int("failedQueries", failed).Msg("Job alerts refresh done")
+	res := SourceResult{Fetched: fetched, New: newJobs}
+	res.finish(len(queries), failed, lastErr)
+	res.linkFailed(linkErr)
+	return res
+}
+
+// alertRunsOn reports whether an alert's query should go to a source: the
+// source must be configured, and Remotive (remote-only listings) is skipped
+// for alerts pinned to a physical location
+func (s *FeedService) alertRunsOn(source string, alert model.JobAlert) bool {
+	switch source {
+	case SourceJSearch:
+		return s.jsearch != nil
+	case SourceRemotive:
+		return s.remotive != nil && (alert.Location == "" || isRemoteLocation(alert.Location))
+	case SourceAdzuna:
+		return s.adzuna != nil && s.adzuna.Enabled()
+	}
+	return false
+}
+
+// searchForAlert runs one alert's query against one source
+func (s *FeedService) searchForAlert(ctx context.Context, source string, alert model.JobAlert) ([]*model.FeedJob, error) {
+	remote := isRemoteLocation(alert.Location)
+	var jobs []*model.FeedJob
+
+	switch source {
+	case SourceJSearch:
+		q := JSearchQuery{Query: alert.Keywords, RemoteOnly: remote, NumPages: 1}
+		if !remote {
+			q.Location = alert.Location
+		}
+		results, err := s.jsearch.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			jobs = append(jobs, convertJSearchJob(r))
+		}
+
+	case SourceRemotive:
+		results, err := s.remotive.Search(ctx, RemotiveQuery{Search: alert.Keywords, Limit: 50})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			jobs = append(jobs, convertRemotiveJob(r))
+		}
+
+	case SourceAdzuna:
+		q := AdzunaQuery{
+			Keywords:       alert.Keywords,
+			Country:        "us",
+			ResultsPerPage: 50,
+			MaxDaysOld:     30,
+			SalaryMin:      alert.SalaryMin,
+		}
+		if remote {
+			q.Keywords += " remote"
+		} else {
+			q.Location = alert.Location
+		}
+		results, err := s.adzuna.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			jobs = append(jobs, convertAdzunaJob(r, q.Country))
+		}
+	}
+
+	return jobs, nil
+}
+
+// meetsSalaryFloor drops jobs whose advertised pay tops out below the alert's
+// floor (in the user's currency). Jobs with no salary, or a currency we can't
+// convert, are kept.
+func (s *FeedService) meetsSalaryFloor(job *model.FeedJob, floor int, currency string) bool {
+	if floor <= 0 {
+		return true
+	}
+	top := max(job.SalaryMin, job.SalaryMax)
+	if top <= 0 {
+		return true
+	}
+	converted, ok := s.rates.Convert(top, job.SalaryCurrency, currency)
+	return !ok || converted >= floor
+}
+
+func isRemoteLocation(location string) bool {
+	return strings.EqualFold(strings.TrimSpace(location), "remote")
+}
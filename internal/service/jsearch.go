@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,18 +16,20 @@ import (
 	"github.com/yourusername/hireiq-api/internal/model"
 )
 
+// ErrRateLimited is returned (wrapped) when RapidAPI answers 429, meaning the
+// plan's request quota is spent and further JSearch calls will fail too
+var ErrRateLimited = errors.New("JSearch rate limit exceeded")
+
 // JSearchClient wraps the JSearch API on RapidAPI
 type JSearchClient struct {
 	apiKey string
 	client *http.Client
 }
 
-func NewJSearchClient(apiKey string) *JSearchClient {
+func NewJSearchClient(apiKey string, timeout time.Duration) *JSearchClient {
 	return &JSearchClient{
 		apiKey: apiKey,
-		client: &http.Client{
-			Timeout: 20 * time.Second,
-		},
+		client: NewHTTPClient(timeout),
 	}
 }
 
@@ -61,10 +64,10 @@ type JSearchJob struct {
 // ── Search parameters ─────────────────────────────────
 
 type JSearchQuery struct {
-	Query      string // e.g. "React developer"
-	Location   string // e.g. "San Francisco" or "" for remote
-	RemoteOnly bool
-	NumPages   int // pages to fetch per query (default 1, max 3)
+	Query      string `json:"query"`    // e.g. "React developer"
+	Location   string `json:"location"` // e.g. "San Francisco" or "" for remote
+	RemoteOnly bool   `json:"remoteOnly"`
+	NumPages   int    `json:"numPages"` // pages to fetch per query (default 1, max 3)
 }
 
 // ── Search method ─────────────────────────────────────
@@ -112,8 +115,13 @@ func (c *JSearchClient) Search(ctx context.Context, q JSearchQuery) ([]JSearchJo
 
 		results, err := c.fetchPage(ctx, reqURL)
 		if err != nil {
+			// A failed first page means the query failed; later pages just
+			// stop paging and keep what was already fetched
+			if page == 1 {
+				return nil, err
+			}
 			log.Error().Err(err).Int("page", page).Str("query", query).Msg("JSearch page fetch failed")
-			break // stop paging on error (likely rate limit or no more results)
+			break
 		}
 
 		allResults = append(allResults, results...)
@@ -154,6 +162,9 @@ func (c *JSearchClient) fetchPage(ctx context.Context, reqURL string) ([]JSearch
 		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("%w: %s", ErrRateLimited, string(body[:min(len(body), 500)]))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("JSearch API returned %d: %s", resp.StatusCode, string(body[:min(len(body), 500)]))
 	}
@@ -166,10 +177,31 @@ func (c *JSearchClient) fetchPage(ctx context.Context, reqURL string) ([]JSearch
 	return result.Data, nil
 }
 
+// maxTargetCompanyQueries caps how many target companies get a dedicated
+// search, so they don't crowd out role and skill queries
+const maxTargetCompanyQueries = 3
+
+// targetCompanyQuery searches one employer, narrowed to the user's first
+// target role when they have one ("Backend Engineer at Stripe")
+func targetCompanyQuery(user *model.User, company string) string {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return ""
+	}
+	for _, role := range user.TargetRoles {
+		if role = strings.TrimSpace(role); role != "" {
+			return role + " at " + company
+		}
+	}
+	return "jobs at " + company
+}
+
 // BuildQueriesFromProfile generates JSearch queries from the user profile.
-// Target roles are the PRIMARY search driver (highest page counts).
+// Target roles are the PRIMARY search driver (highest page counts), followed
+// by a one-page search per target company.
 // Skills and experience titles are SECONDARY for broader coverage.
-func BuildQueriesFromProfile(user *model.User) []JSearchQuery {
+// At most maxQueries are returned.
+func BuildQueriesFromProfile(user *model.User, maxQueries int) []JSearchQuery {
 	remoteOnly := strings.EqualFold(user.WorkStyle, "remote")
 	location := user.Location
 	seen := make(map[string]bool)
@@ -199,6 +231,11 @@ func BuildQueriesFromProfile(user *model.User) []JSearchQuery {
 		}
 	}
 
+	// ── Target companies: openings at specific employers ──
+	for i := 0; i < len(user.TargetCompanies) && i < maxTargetCompanyQueries; i++ {
+		queries = add(queries, targetCompanyQuery(user, user.TargetCompanies[i]), 1)
+	}
+
 	// ── SECONDARY: Skills-based queries (fill remaining slots) ──
 	if len(user.Skills) > 0 && len(queries) < 4 {
 		topSkills := user.Skills
@@ -234,10 +271,5 @@ func BuildQueriesFromProfile(user *model.User) []JSearchQuery {
 		})
 	}
 
-	// Safety cap
-	if len(queries) > 8 {
-		queries = queries[:8]
-	}
-
-	return queries
+	return capQueries(queries, maxQueries)
 }
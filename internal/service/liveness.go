@@ -0,0 +1,345 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+const (
+	// livenessTopN is how many of each user's best-matching feed jobs are checked
+	livenessTopN = 20
+	// livenessRecheckAfter is how long a live posting goes before it's rechecked
+	livenessRecheckAfter = 24 * time.Hour
+	// livenessHostDelay spaces out requests to the same host
+	livenessHostDelay = 2 * time.Second
+	// robotsTTL is how long a host's robots.txt rules are cached
+	robotsTTL = 24 * time.Hour
+)
+
+// LivenessReport summarizes one liveness pass
+type LivenessReport struct {
+	Checked  int // apply URLs requested
+	Expired  int // 404/410 postings marked expired
+	Blocked  int // skipped because robots.txt disallows them
+	Failed   int // bad URLs or network errors; retried with backoff
+	Duration time.Duration
+}
+
+// LivenessChecker finds feed postings that were taken down before they
+// expired (the apply URL now returns 404 or 410) and expires them early.
+// Request volume is bounded per pass and per host, it identifies itself with
+// the plain HireIQ user-agent, and it honors robots.txt.
+type LivenessChecker struct {
+	feedRepo  *repository.FeedRepo
+	client    *http.Client
+	maxChecks int
+
+	robotsMu sync.Mutex
+	robots   map[string]*robotsRules // by scheme://host
+}
+
+func NewLivenessChecker(feedRepo *repository.FeedRepo, maxChecks int) *LivenessChecker {
+	return &LivenessChecker{
+		feedRepo:  feedRepo,
+		client:    NewPublicHTTPClient(10 * time.Second), // apply URLs come from third-party feeds
+		maxChecks: maxChecks,
+		robots:    make(map[string]*robotsRules),
+	}
+}
+
+// CheckOnce checks up to maxChecks saved and top-of-feed postings
+func (l *LivenessChecker) CheckOnce(ctx context.Context) (*LivenessReport, error) {
+	start := time.Now()
+	report := &LivenessReport{}
+
+	candidates, err := l.feedRepo.LivenessCandidates(ctx, livenessTopN, time.Now().Add(-livenessRecheckAfter), l.maxChecks)
+	if err != nil {
+		return nil, err
+	}
+
+	var checked, dead, failed []uuid.UUID
+	lastHit := make(map[string]time.Time)
+	for _, c := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+
+		u, err := url.Parse(c.ApplyURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			report.Failed++
+			failed = append(failed, c.FeedJobID)
+			continue
+		}
+
+		if !l.allowedByRobots(ctx, u) {
+			report.Blocked++
+			// Don't ask again until the next recheck window
+			checked = append(checked, c.FeedJobID)
+			continue
+		}
+
+		// Be polite to hosts that show up many times (e.g. one ATS)
+		if wait := livenessHostDelay - time.Since(lastHit[u.Host]); wait > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(wait):
+			}
+		}
+		lastHit[u.Host] = time.Now()
+
+		status, err := l.probe(ctx, c.ApplyURL)
+		report.Checked++
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// Cut off by the pass deadline, not the host's fault
+		case err != nil:
+			report.Failed++
+			failed = append(failed, c.FeedJobID)
+			log.Debug().Err(err).Str("url", c.ApplyURL).Msg("Liveness: request failed")
+		case status == http.StatusNotFound || status == http.StatusGone:
+			dead = append(dead, c.FeedJobID)
+		default:
+			checked = append(checked, c.FeedJobID)
+		}
+	}
+
+	expired, err := l.feedRepo.MarkExpired(ctx, dead)
+	if err != nil {
+		return report, err
+	}
+	report.Expired = expired
+
+	if err := l.feedRepo.MarkLivenessChecked(ctx, checked); err != nil {
+		return report, err
+	}
+	if err := l.feedRepo.MarkLivenessFailed(ctx, failed); err != nil {
+		return report, err
+	}
+
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// probe returns the final status of a URL, trying HEAD first and falling back
+// to GET for servers that don't support HEAD. A HEAD 404/410 is confirmed
+// with GET too, since many job boards answer HEAD with 404 for live pages.
+func (l *LivenessChecker) probe(ctx context.Context, rawURL string) (int, error) {
+	status, err := l.request(ctx, http.MethodHead, rawURL)
+	if err != nil {
+		return 0, err
+	}
+	switch status {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusForbidden,
+		http.StatusNotFound, http.StatusGone:
+		return l.request(ctx, http.MethodGet, rawURL)
+	}
+	return status, nil
+}
+
+func (l *LivenessChecker) request(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", plainScraperUserAgent)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// StartLoop runs CheckOnce every interval until ctx is done
+func (l *LivenessChecker) StartLoop(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runCtx, cancel := context.WithTimeout(ctx, interval)
+				report, err := l.CheckOnce(runCtx)
+				cancel()
+				if err != nil {
+					log.Error().Err(err).Msg("Feed liveness check failed")
+				}
+				if report != nil {
+					log.Info().
+						Int("checked", report.Checked).
+						Int("expired", report.Expired).
+						Int("blocked", report.Blocked).
+						Int("failed", report.Failed).
+						Dur("duration", report.Duration).
+						Msg("Feed liveness check complete")
+				}
+			}
+		}
+	}()
+}
+
+// ── robots.txt ──────────────────────────────────────────
+
+// robotsRules are the Allow/Disallow lines that apply to our user-agent
+type robotsRules struct {
+	rules     []robotsRule
+	expiresAt time.Time
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int // pattern length; the longest match wins
+	pattern *regexp.Regexp
+}
+
+// allowed applies the longest matching rule; Allow wins ties
+func (r *robotsRules) allowed(path string) bool {
+	best, allow := -1, true
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > best || (rule.length == best && rule.allow) {
+			best, allow = rule.length, rule.allow
+		}
+	}
+	return allow
+}
+
+func (l *LivenessChecker) allowedByRobots(ctx context.Context, u *url.URL) bool {
+	origin := u.Scheme + "://" + u.Host
+
+	l.robotsMu.Lock()
+	rules, ok := l.robots[origin]
+	l.robotsMu.Unlock()
+	if !ok || time.Now().After(rules.expiresAt) {
+		rules = l.fetchRobots(ctx, origin)
+		l.robotsMu.Lock()
+		l.robots[origin] = rules
+		l.robotsMu.Unlock()
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return rules.allowed(path)
+}
+
+// fetchRobots loads a host's robots.txt. A missing file (4xx) allows
+// everything; an unreachable one (5xx, network error) disallows everything
+// until the next fetch, as robots.txt convention asks.
+func (l *LivenessChecker) fetchRobots(ctx context.Context, origin string) *robotsRules {
+	disallowAll := &robotsRules{
+		rules:     []robotsRule{{allow: false, length: 1, pattern: regexp.MustCompile(`^/`)}},
+		expiresAt: time.Now().Add(time.Hour),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return disallowAll
+	}
+	req.Header.Set("User-Agent", plainScraperUserAgent)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return disallowAll
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return disallowAll
+	case resp.StatusCode >= 400:
+		return &robotsRules{expiresAt: time.Now().Add(robotsTTL)}
+	}
+
+	rules := parseRobots(io.LimitReader(resp.Body, 512<<10), "hireiq")
+	rules.expiresAt = time.Now().Add(robotsTTL)
+	return rules
+}
+
+// parseRobots extracts the rules for agent from a robots.txt body. Groups
+// naming the agent take precedence over the "*" group.
+func parseRobots(body io.Reader, agent string) *robotsRules {
+	var specific, wildcard []robotsRule
+	var groupAgents []string
+	inRules := false // true once the current group has seen a rule line
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				groupAgents, inRules = nil, false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // empty Disallow allows everything
+			}
+			rule := robotsRule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: robotsPattern(value),
+			}
+			for _, a := range groupAgents {
+				switch {
+				case a == "*":
+					wildcard = append(wildcard, rule)
+				case strings.Contains(agent, a) || strings.Contains(a, agent):
+					specific = append(specific, rule)
+				}
+			}
+		}
+	}
+
+	if len(specific) > 0 {
+		return &robotsRules{rules: specific}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// robotsPattern compiles a robots.txt path pattern: a prefix match where "*"
+// matches any run of characters and a trailing "$" anchors the end
+func robotsPattern(p string) *regexp.Regexp {
+	anchored := strings.HasSuffix(p, "$")
+	p = strings.TrimSuffix(p, "$")
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(p), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
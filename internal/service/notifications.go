@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/repository"
+)
+
+// notifyStatuses are the stages worth an in-app notification
+var notifyStatuses = map[string]string{
+	model.StatusInterview: "Interview stage",
+	model.StatusOffer:     "Offer received",
+}
+
+// NotificationSubscriber writes a notification row when an application
+// reaches interview or offer
+type NotificationSubscriber struct {
+	notifRepo *repository.NotificationRepo
+	jobRepo   *repository.JobRepo
+}
+
+func NewNotificationSubscriber(notifRepo *repository.NotificationRepo, jobRepo *repository.JobRepo) *NotificationSubscriber {
+	return &NotificationSubscriber{notifRepo: notifRepo, jobRepo: jobRepo}
+}
+
+func (s *NotificationSubscriber) Name() string { return "notifications" }
+
+func (s *NotificationSubscriber) OnStatusChange(ctx context.Context, event model.StatusChangeEvent) error {
+	title, ok := notifyStatuses[event.ToStatus]
+	if !ok {
+		return nil
+	}
+
+	body := ""
+	job, err := s.jobRepo.FindByID(ctx, event.JobID, event.UserID)
+	if err != nil {
+		return fmt.Errorf("loading job for notification: %w", err)
+	}
+	if job != nil {
+		title = fmt.Sprintf("%s: %s", title, job.Company)
+		body = fmt.Sprintf("%s at %s moved to %s", job.Title, job.Company, event.ToStatus)
+	}
+
+	appID, jobID := event.ApplicationID, event.JobID
+	_, err = s.notifRepo.Create(ctx, &model.Notification{
+		UserID:        event.UserID,
+		Type:          model.NotificationApplicationStatus,
+		Title:         title,
+		Body:          body,
+		ApplicationID: &appID,
+		JobID:         &jobID,
+	})
+	return err
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// AggregateQuestions merges interview questions recorded for one company into
+// distinct questions. Questions match ignoring case, spacing, and trailing
+// punctuation, so "Tell me about yourself." and "tell me about yourself"
+// count as the same question asked twice. The most-asked questions come
+// first, then the most recently asked. Input is expected newest first, as the
+// repository returns it; the newest wording and category win.
+func AggregateQuestions(questions []model.InterviewQuestion) []model.CompanyQuestion {
+	index := make(map[string]int)
+	var out []model.CompanyQuestion
+
+	for _, q := range questions {
+		key := questionKey(q.Question)
+		i, ok := index[key]
+		if !ok {
+			i = len(out)
+			index[key] = i
+			out = append(out, model.CompanyQuestion{
+				Question:    q.Question,
+				Stages:      []string{},
+				LastAskedAt: q.CreatedAt,
+			})
+		}
+
+		agg := &out[i]
+		agg.TimesAsked++
+		agg.Entries = append(agg.Entries, q)
+		if agg.Category == "" {
+			agg.Category = q.Category
+		}
+		if q.CreatedAt.After(agg.LastAskedAt) {
+			agg.LastAskedAt = q.CreatedAt
+		}
+		if q.Stage != "" && !containsFold(agg.Stages, q.Stage) {
+			agg.Stages = append(agg.Stages, q.Stage)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].TimesAsked != out[j].TimesAsked {
+			return out[i].TimesAsked > out[j].TimesAsked
+		}
+		return out[i].LastAskedAt.After(out[j].LastAskedAt)
+	})
+	return out
+}
+
+// questionKey normalizes question text for matching
+func questionKey(q string) string {
+	q = strings.ToLower(strings.Join(strings.Fields(q), " "))
+	return strings.TrimRight(q, "?.!")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/yourusername/hireiq-api/internal/model"
+	"github.com/yourusername/hireiq-api/internal/skills"
 )
 
 // RemotiveClient wraps the Remotive free remote jobs API.
@@ -21,11 +22,9 @@ type RemotiveClient struct {
 	client *http.Client
 }
 
-func NewRemotiveClient() *RemotiveClient {
+func NewRemotiveClient(timeout time.Duration) *RemotiveClient {
 	return &RemotiveClient{
-		client: &http.Client{
-			Timeout: 20 * time.Second,
-		},
+		client: NewHTTPClient(timeout),
 	}
 }
 
@@ -54,9 +53,10 @@ type RemotiveJob struct {
 // ── Search parameters ────────────────────────────────
 
 type RemotiveQuery struct {
-	Search   string // keyword search term
-	Category string // slug like "software-dev", "data", "devops-sysadmin"
-	Limit    int    // max results (default 20)
+	Search      string `json:"search"`      // keyword search term
+	Category    string `json:"category"`    // slug like "software-dev", "data", "devops-sysadmin"
+	CompanyName string `json:"companyName"` // only this employer's listings
+	Limit       int    `json:"limit"`       // max results (default 20)
 }
 
 // ── Search method ────────────────────────────────────
@@ -69,6 +69,9 @@ func (c *RemotiveClient) Search(ctx context.Context, q RemotiveQuery) ([]Remotiv
 	if q.Category != "" {
 		params.Set("category", q.Category)
 	}
+	if q.CompanyName != "" {
+		params.Set("company_name", q.CompanyName)
+	}
 	limit := q.Limit
 	if limit <= 0 {
 		limit = 20
@@ -80,6 +83,7 @@ func (c *RemotiveClient) Search(ctx context.Context, q RemotiveQuery) ([]Remotiv
 	log.Info().
 		Str("search", q.Search).
 		Str("category", q.Category).
+		Str("company", q.CompanyName).
 		Int("limit", limit).
 		Msg("Searching Remotive API")
 
@@ -120,50 +124,61 @@ func (c *RemotiveClient) Search(ctx context.Context, q RemotiveQuery) ([]Remotiv
 
 // ── Query builder ────────────────────────────────────
 
-// remotiveCategoryMap maps common skill keywords to Remotive category slugs.
-var remotiveCategoryMap = map[string]string{
-	"react":            "software-dev",
-	"javascript":       "software-dev",
-	"python":           "software-dev",
-	"go":               "software-dev",
-	"golang":           "software-dev",
-	"java":             "software-dev",
-	"typescript":       "software-dev",
-	"rust":             "software-dev",
-	"node":             "software-dev",
-	"node.js":          "software-dev",
-	"ruby":             "software-dev",
-	"swift":            "software-dev",
-	"kotlin":           "software-dev",
-	"c++":              "software-dev",
-	"c#":               "software-dev",
-	".net":             "software-dev",
-	"php":              "software-dev",
-	"vue":              "software-dev",
-	"angular":          "software-dev",
-	"figma":            "design",
-	"ui/ux":            "design",
-	"design":           "design",
-	"devops":           "devops-sysadmin",
-	"kubernetes":       "devops-sysadmin",
-	"docker":           "devops-sysadmin",
-	"terraform":        "devops-sysadmin",
-	"aws":              "devops-sysadmin",
-	"azure":            "devops-sysadmin",
-	"gcp":              "devops-sysadmin",
-	"data science":     "data",
-	"machine learning": "data",
-	"sql":              "data",
-	"analytics":        "data",
-	"product":          "product",
-	"qa":               "qa",
-	"testing":          "qa",
+// remotiveCategories maps taxonomy skill categories to Remotive category slugs
+var remotiveCategories = map[string]string{
+	skills.CategoryEngineering: "software-dev",
+	skills.CategoryDesign:      "design",
+	skills.CategoryDevOps:      "devops-sysadmin",
+	skills.CategoryData:        "data",
+	skills.CategoryProduct:     "product",
+	skills.CategoryQA:          "qa",
+}
+
+// RemotiveCategorySlugs are the category slugs Remotive accepts, in the
+// order its API lists them
+var RemotiveCategorySlugs = []string{
+	"software-dev", "customer-support", "design", "marketing", "sales-business",
+	"product", "business", "data", "devops-sysadmin", "finance-legal", "hr",
+	"qa", "writing", "all-others",
+}
+
+// ValidRemotiveCategory reports whether slug is a Remotive category
+func ValidRemotiveCategory(slug string) bool {
+	for _, s := range RemotiveCategorySlugs {
+		if s == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCompanyHint splits a target role like "Backend Engineer at Stripe" (or
+// "... @ Stripe") into the role and the company, but only when the company is
+// one of the user's target companies. Otherwise " at " is likely part of the
+// title ("Engineer at Scale", "Director at Large"), so the role comes back
+// unchanged with an empty company.
+func splitCompanyHint(role string, targetCompanies []string) (string, string) {
+	lower := strings.ToLower(role)
+	for _, sep := range []string{" at ", " @ "} {
+		if i := strings.LastIndex(lower, sep); i > 0 {
+			title := strings.TrimSpace(role[:i])
+			company := strings.TrimSpace(role[i+len(sep):])
+			if title != "" && isTargetCompany(targetCompanies, company) {
+				return title, company
+			}
+		}
+	}
+	return role, ""
 }
 
 // BuildRemotiveQueries generates Remotive queries from a user profile.
-// Target roles are the PRIMARY search driver.
+// Target roles are the PRIMARY search driver; a role naming one of the user's
+// target companies ("Backend Engineer at Stripe") is searched within that
+// company. The categories the user picked come next, then target companies,
+// ahead of anything derived from skills.
 // Only skips if user explicitly prefers onsite-only work.
-func BuildRemotiveQueries(user *model.User) []RemotiveQuery {
+// At most maxQueries are returned.
+func BuildRemotiveQueries(user *model.User, maxQueries int) []RemotiveQuery {
 	// Only skip if user explicitly wants onsite-only work
 	if strings.EqualFold(user.WorkStyle, "onsite") {
 		return nil
@@ -174,13 +189,39 @@ func BuildRemotiveQueries(user *model.User) []RemotiveQuery {
 
 	// ── PRIMARY: Target roles ──
 	for _, role := range user.TargetRoles {
-		role = strings.TrimSpace(role)
-		key := strings.ToLower(role)
-		if role != "" && !seen[key] {
+		search, company := splitCompanyHint(strings.TrimSpace(role), user.TargetCompanies)
+		key := strings.ToLower(search + "|" + company)
+		if search != "" && !seen[key] {
 			seen[key] = true
 			queries = append(queries, RemotiveQuery{
-				Search: role,
-				Limit:  50,
+				Search:      search,
+				CompanyName: company,
+				Limit:       50,
+			})
+		}
+	}
+
+	// ── Categories the user picked, in their order ──
+	categoryUsed := make(map[string]bool)
+	for _, cat := range user.RemotiveCategories {
+		if ValidRemotiveCategory(cat) && !categoryUsed[cat] {
+			categoryUsed[cat] = true
+			queries = append(queries, RemotiveQuery{
+				Category: cat,
+				Limit:    50,
+			})
+		}
+	}
+
+	// ── Target companies: everything remote they're hiring for ──
+	for i := 0; i < len(user.TargetCompanies) && i < maxTargetCompanyQueries; i++ {
+		company := strings.TrimSpace(user.TargetCompanies[i])
+		key := strings.ToLower("|" + company)
+		if company != "" && !seen[key] {
+			seen[key] = true
+			queries = append(queries, RemotiveQuery{
+				CompanyName: company,
+				Limit:       50,
 			})
 		}
 	}
@@ -202,13 +243,12 @@ func BuildRemotiveQueries(user *model.User) []RemotiveQuery {
 		}
 	}
 
-	// ── TERTIARY: Skills mapped to Remotive categories ──
-	categoryUsed := make(map[string]bool)
+	// ── TERTIARY: Skills mapped to Remotive categories (in skill order) ──
 	for _, skill := range user.Skills {
 		if len(queries) >= 5 {
 			break
 		}
-		if cat, ok := remotiveCategoryMap[strings.ToLower(skill)]; ok && !categoryUsed[cat] {
+		if cat, ok := remotiveCategories[skills.Category(skill)]; ok && !categoryUsed[cat] {
 			categoryUsed[cat] = true
 			queries = append(queries, RemotiveQuery{
 				Category: cat,
@@ -230,20 +270,18 @@ func BuildRemotiveQueries(user *model.User) []RemotiveQuery {
 		}
 	}
 
-	// Cap at 6 queries
-	if len(queries) > 6 {
-		queries = queries[:6]
-	}
-
-	return queries
+	return capQueries(queries, maxQueries)
 }
 
 // ── Converter ────────────────────────────────────────
 
 // convertRemotiveJob transforms a Remotive API result into our FeedJob model.
 func convertRemotiveJob(rj RemotiveJob) *model.FeedJob {
-	// Parse salary — Remotive returns freeform text like "$120k-$160k" or ""
+	// Parse salary — Remotive returns freeform text like "$120k-$160k" or "".
+	// parseSalaryText annualizes what it can read and leaves min/max at 0
+	// when the text has no recognizable amount.
 	salaryText := rj.Salary
+	salaryMin, salaryMax := parseSalaryText(salaryText)
 
 	// Parse job type
 	jobType := "full-time"
@@ -279,23 +317,19 @@ func convertRemotiveJob(rj RemotiveJob) *model.FeedJob {
 	// Strip HTML from description (Remotive returns HTML), then truncate (UTF-8 safe)
 	desc := truncateUTF8(stripHTML(rj.Description), 2000)
 
-	skills := rj.Tags
-	if skills == nil {
-		skills = []string{}
-	}
-
 	return &model.FeedJob{
 		ExternalID:     fmt.Sprintf("remotive-%d", rj.ID),
 		Source:         "remotive",
 		Title:          rj.Title,
 		Company:        rj.CompanyName,
 		Location:       location,
-		SalaryMin:      0,
-		SalaryMax:      0,
+		SalaryMin:      salaryMin,
+		SalaryMax:      salaryMax,
 		SalaryText:     salaryText,
+		SalaryCurrency: detectCurrency(salaryText),
 		JobType:        jobType,
 		Description:    desc,
-		RequiredSkills: skills,
+		RequiredSkills: skills.Normalize(rj.Tags),
 		ApplyURL:       rj.URL,
 		CompanyLogo:    rj.CompanyLogo,
 		PostedAt:       postedAt,
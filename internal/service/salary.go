@@ -0,0 +1,262 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// Salary periods as reported by JSearch (job_salary_period). Other sources
+// map onto these before normalizing.
+const (
+	SalaryPeriodYear  = "YEAR"
+	SalaryPeriodMonth = "MONTH"
+	SalaryPeriodWeek  = "WEEK"
+	SalaryPeriodDay   = "DAY"
+	SalaryPeriodHour  = "HOUR"
+)
+
+// annualMultiplier converts a per-period amount to a yearly one, assuming a
+// standard 40-hour, 52-week full-time schedule.
+func annualMultiplier(period string) int {
+	switch strings.ToUpper(strings.TrimSpace(period)) {
+	case SalaryPeriodHour:
+		return 2080
+	case SalaryPeriodDay:
+		return 260
+	case SalaryPeriodWeek:
+		return 52
+	case SalaryPeriodMonth:
+		return 12
+	default:
+		// YEAR, or unknown — treat as already annual
+		return 1
+	}
+}
+
+// normalizeToAnnual converts a salary range quoted per period into annual
+// figures so stored salary_min/max are always comparable to the user's
+// annual expectations in calculateMatchScore. Amounts stay fractional until
+// annualized, so "$45.50/hr" becomes 94640 rather than 45 * 2080.
+func normalizeToAnnual(min, max float64, period string) (int, int) {
+	m := float64(annualMultiplier(period))
+	return int(math.Round(min * m)), int(math.Round(max * m))
+}
+
+// formatSalaryText renders a raw (un-normalized) range in its original period
+// and currency, e.g. "$120k - $160k/yr", "£45 - £60/hr", or "CHF 110k/yr".
+// Returns "" when there's no salary.
+func formatSalaryText(min, max int, period, currency string) string {
+	if min <= 0 && max <= 0 {
+		return ""
+	}
+
+	var suffix string
+	thousands := false
+	switch strings.ToUpper(strings.TrimSpace(period)) {
+	case SalaryPeriodHour:
+		suffix = "/hr"
+	case SalaryPeriodDay:
+		suffix = "/day"
+	case SalaryPeriodWeek:
+		suffix = "/wk"
+	case SalaryPeriodMonth:
+		suffix = "/mo"
+	default:
+		suffix = "/yr"
+		thousands = true
+	}
+
+	prefix := model.CurrencyPrefix(currency)
+	format := func(v int) string {
+		if thousands {
+			return fmt.Sprintf("%s%dk", prefix, v/1000)
+		}
+		return fmt.Sprintf("%s%d", prefix, v)
+	}
+
+	switch {
+	case min > 0 && max > 0 && min != max:
+		return format(min) + " - " + format(max) + suffix
+	case max > 0:
+		return format(max) + suffix
+	default:
+		return format(min) + suffix
+	}
+}
+
+// salaryCurrencyPattern matches a currency marker next to an amount: a
+// symbol ("CA$" and "A$" end in "$") or an ISO code we have rates for
+var salaryCurrencyPattern = func() string {
+	codes := make([]string, 0, len(defaultUSDRates))
+	for code := range defaultUSDRates {
+		codes = append(codes, strings.ToLower(code))
+	}
+	sort.Strings(codes)
+	return `(?:[$€£¥₹]|\b(?:` + strings.Join(codes, "|") + `)\b)`
+}()
+
+// salaryRangeRe matches one amount or a range of two, each with an optional
+// currency before or after and an optional k/m multiplier: "$120k - $160k",
+// "100000-130000 USD", "€50,000", "45 to 60". Amounts may use thousands
+// separators or decimals ("50,000", "1.5M", "85.000").
+var salaryRangeRe = func() *regexp.Regexp {
+	cur := salaryCurrencyPattern
+	amount := `(` + cur + `\s*)?(\d+(?:[.,]\d+)*)\s*([km])?\b(\s*` + cur + `)?`
+	return regexp.MustCompile(`(?i)` + amount + `(?:\s*(?:-|–|—|to)\s*` + amount + `)?`)
+}()
+
+// salaryPeriodHints maps free-text period markers to a salary period.
+// Checked in order, so the longer "per hour" style phrases win over "/h".
+var salaryPeriodHints = []struct {
+	hint   string
+	period string
+}{
+	{"per hour", SalaryPeriodHour}, {"hourly", SalaryPeriodHour}, {"an hour", SalaryPeriodHour},
+	{"/hour", SalaryPeriodHour}, {"/hr", SalaryPeriodHour}, {"/h", SalaryPeriodHour},
+	{"per day", SalaryPeriodDay}, {"daily", SalaryPeriodDay}, {"/day", SalaryPeriodDay},
+	{"per week", SalaryPeriodWeek}, {"weekly", SalaryPeriodWeek}, {"/week", SalaryPeriodWeek}, {"/wk", SalaryPeriodWeek},
+	{"per month", SalaryPeriodMonth}, {"monthly", SalaryPeriodMonth}, {"/month", SalaryPeriodMonth}, {"/mo", SalaryPeriodMonth},
+}
+
+// salaryRange is one amount or range found in salary text
+type salaryRange struct {
+	amounts  []float64
+	anchored bool // a currency or k/m multiplier marks it as money
+}
+
+// parseSalaryText extracts an annualized min/max from free-text salary such
+// as "$120k - $160k", "€50,000", "100000-130000 USD", or "$45-60/hr".
+// A single figure yields min == max. Ranges marked as money by a currency or
+// k/m multiplier win over bare numbers, so "3+ years, $90k-$110k" reads as
+// 90000-110000. Without one, the first bare figure of 1000 or more is used,
+// or with a period given ("45-60 per hour") the first bare range or figure.
+// Figures under 1000 with no period are too ambiguous to guess at; with
+// nothing usable it returns 0, 0.
+func parseSalaryText(text string) (int, int) {
+	lower := strings.ToLower(text)
+
+	period := SalaryPeriodYear
+	hasPeriod := false
+	for _, h := range salaryPeriodHints {
+		if strings.Contains(lower, h.hint) {
+			period, hasPeriod = h.period, true
+			break
+		}
+	}
+
+	// Benefits text like "401k match" isn't a salary figure
+	cleaned := strings.NewReplacer("401k", "", "401K", "", "401(k)", "").Replace(text)
+
+	var bare []salaryRange
+	for _, m := range salaryRangeRe.FindAllStringSubmatch(cleaned, -1) {
+		r, ok := readSalaryRange(m)
+		if !ok {
+			continue
+		}
+		// Under 1000 with no period ("$5 gift card") isn't a salary either
+		if r.anchored && (hasPeriod || r.amounts[len(r.amounts)-1] >= 1000) {
+			return annualizeRange(r.amounts, period)
+		}
+		if r.anchored {
+			continue
+		}
+		bare = append(bare, r)
+	}
+
+	for _, r := range bare {
+		if r.amounts[len(r.amounts)-1] >= 1000 {
+			return annualizeRange(r.amounts, period)
+		}
+	}
+	if hasPeriod && len(bare) > 0 {
+		for _, r := range bare {
+			if len(r.amounts) == 2 {
+				return annualizeRange(r.amounts, period)
+			}
+		}
+		return annualizeRange(bare[0].amounts, period)
+	}
+	return 0, 0
+}
+
+// readSalaryRange turns a salaryRangeRe match into amounts with their k/m
+// multipliers applied
+func readSalaryRange(m []string) (salaryRange, bool) {
+	var r salaryRange
+	var multipliers []string
+	// Each amount is four groups: currency before, number, multiplier, currency after
+	for i := 1; i+3 < len(m); i += 4 {
+		if m[i+1] == "" {
+			continue
+		}
+		v, ok := parseSalaryAmount(m[i+1])
+		if !ok || v <= 0 {
+			return salaryRange{}, false
+		}
+		r.amounts = append(r.amounts, v)
+		multipliers = append(multipliers, strings.ToLower(m[i+2]))
+		if m[i] != "" || m[i+2] != "" || m[i+3] != "" {
+			r.anchored = true
+		}
+	}
+	if len(r.amounts) == 0 {
+		return salaryRange{}, false
+	}
+
+	// "120-160k" puts the multiplier on the upper bound only
+	if len(r.amounts) == 2 && multipliers[0] == "" && multipliers[1] != "" && r.amounts[0] < 1000 {
+		multipliers[0] = multipliers[1]
+	}
+	for i, mult := range multipliers {
+		switch mult {
+		case "k":
+			r.amounts[i] *= 1000
+		case "m":
+			r.amounts[i] *= 1_000_000
+		}
+	}
+	return r, true
+}
+
+// annualizeRange orders one or two amounts as min/max and annualizes them.
+// Amounts aren't rounded until after annualizing.
+func annualizeRange(amounts []float64, period string) (int, int) {
+	min, max := amounts[0], amounts[0]
+	if len(amounts) == 2 {
+		max = amounts[1]
+		if min > max {
+			min, max = max, min
+		}
+	}
+	return normalizeToAnnual(min, max, period)
+}
+
+// parseSalaryAmount reads a number whose "," or "." may be a thousands
+// separator ("50,000", "50.000") or a decimal point ("120.5"). A separator
+// followed by exactly three digits is treated as grouping.
+func parseSalaryAmount(s string) (float64, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != ',' && c != '.' {
+			b.WriteByte(c)
+			continue
+		}
+		rest := len(s) - i - 1
+		if next := strings.IndexAny(s[i+1:], ",."); next >= 0 {
+			rest = next
+		}
+		if rest == 3 {
+			continue // grouping separator
+		}
+		b.WriteByte('.')
+	}
+	v, err := strconv.ParseFloat(b.String(), 64)
+	return v, err == nil
+}
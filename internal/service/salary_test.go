@@ -0,0 +1,71 @@
+package service
+
+import "testing"
+
+func TestParseSalaryText(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max int
+	}{
+		{"$120k - $160k", 120000, 160000},
+		{"$120k-$160k", 120000, 160000},
+		{"€50,000", 50000, 50000},
+		{"100000-130000 USD", 100000, 130000},
+		{"120-160k", 120000, 160000},
+		{"$45-60/hr", 45 * 2080, 60 * 2080},
+		{"45-60 per hour", 45 * 2080, 60 * 2080},
+		{"$45.50/hr", 94640, 94640},
+		{"$45.50 - $60.75 per hour", 94640, 126360},
+		{"$5,000 - $6,000 per month", 60000, 72000},
+		{"£1.5M", 1500000, 1500000},
+		{"85.000 EUR", 85000, 85000},
+		{"$100k to $120k", 100000, 120000},
+		{"$160k - $120k", 120000, 160000},
+
+		// Figures that aren't the salary
+		{"3+ years, $90k-$110k", 90000, 110000},
+		{"5 days a week, 2 weeks PTO, $95,000", 95000, 95000},
+		{"401k match, $130k", 130000, 130000},
+		{"Team of 12, 80000-95000", 80000, 95000},
+		{"$5 gift card, $70k", 70000, 70000},
+
+		// Nothing usable
+		{"", 0, 0},
+		{"Competitive", 0, 0},
+		{"3+ years experience", 0, 0},
+		{"401k match", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			min, max := parseSalaryText(tt.text)
+			if min != tt.min || max != tt.max {
+				t.Errorf("parseSalaryText(%q) = %d, %d; want %d, %d", tt.text, min, max, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestNormalizeToAnnual(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		period   string
+		wantMin  int
+		wantMax  int
+	}{
+		{"hourly with cents", 45.50, 60.25, SalaryPeriodHour, 94640, 125320},
+		{"daily with cents", 400.10, 500.90, SalaryPeriodDay, 104026, 130234},
+		{"annual", 120000, 160000, SalaryPeriodYear, 120000, 160000},
+		{"unknown period", 90000.6, 90000.6, "", 90001, 90001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max := normalizeToAnnual(tt.min, tt.max, tt.period)
+			if min != tt.wantMin || max != tt.wantMax {
+				t.Errorf("normalizeToAnnual(%v, %v, %q) = %d, %d; want %d, %d", tt.min, tt.max, tt.period, min, max, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidShareToken covers malformed, tampered, and expired share tokens
+var ErrInvalidShareToken = errors.New("invalid or expired share token")
+
+// ShareSigner mints and verifies job-share tokens. A token is
+// base64url(jobID | userID | expiry) + "." + base64url(HMAC-SHA256), so it
+// needs no database row and can't be altered to point at another job.
+type ShareSigner struct {
+	secret []byte
+}
+
+func NewShareSigner(secret string) *ShareSigner {
+	return &ShareSigner{secret: []byte(secret)}
+}
+
+// Enabled reports whether a signing secret is configured
+func (s *ShareSigner) Enabled() bool {
+	return len(s.secret) > 0
+}
+
+// Sign returns a token granting read-only access to a user's job until expiresAt
+func (s *ShareSigner) Sign(jobID, userID uuid.UUID, expiresAt time.Time) string {
+	payload := make([]byte, 0, 40)
+	payload = append(payload, jobID[:]...)
+	payload = append(payload, userID[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(expiresAt.Unix()))
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(s.mac(payload))
+}
+
+// Verify checks a token's signature and expiry and returns the job and owner it grants
+func (s *ShareSigner) Verify(token string) (jobID, userID uuid.UUID, err error) {
+	payloadPart, sigPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, uuid.Nil, ErrInvalidShareToken
+	}
+
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(payloadPart)
+	if err != nil || len(payload) != 40 {
+		return uuid.Nil, uuid.Nil, ErrInvalidShareToken
+	}
+	sig, err := enc.DecodeString(sigPart)
+	if err != nil || !hmac.Equal(sig, s.mac(payload)) {
+		return uuid.Nil, uuid.Nil, ErrInvalidShareToken
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[32:])), 0)
+	if time.Now().After(expiresAt) {
+		return uuid.Nil, uuid.Nil, ErrInvalidShareToken
+	}
+
+	copy(jobID[:], payload[:16])
+	copy(userID[:], payload[16:32])
+	return jobID, userID, nil
+}
+
+func (s *ShareSigner) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
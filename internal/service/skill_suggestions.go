@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/hireiq-api/internal/repository"
+	"github.com/yourusername/hireiq-api/internal/skills"
+)
+
+// SkillSuggestions is the curated list of skill names offered by the profile
+// skill typeahead: every canonical name in the skills taxonomy.
+var SkillSuggestions = func() []string {
+	all := skills.All()
+	names := make([]string, len(all))
+	for i, s := range all {
+		names[i] = s.Name
+	}
+	sort.Strings(names)
+	return names
+}()
+
+const (
+	// popularSkillsTTL is how long the feed-wide skill counts are cached
+	popularSkillsTTL = time.Hour
+	// popularSkillsWindow is how far back feed jobs count toward popularity
+	popularSkillsWindow = 30 * 24 * time.Hour
+	// popularSkillsLimit caps how many distinct feed skills are considered
+	popularSkillsLimit = 500
+	// popularSkillMinJobs is how many feed jobs must list a skill outside the
+	// taxonomy before it's suggested, to keep one-off typos out
+	popularSkillMinJobs = 3
+)
+
+// SkillSuggester ranks skill suggestions for the profile typeahead: the
+// curated SkillSuggestions plus skills commonly required by feed jobs, all
+// normalized to their canonical names so aliases don't show up twice.
+type SkillSuggester struct {
+	feedRepo *repository.FeedRepo
+
+	mu        sync.Mutex
+	popular   map[string]int // canonical key -> feed jobs listing it
+	names     map[string]string
+	expiresAt time.Time
+}
+
+func NewSkillSuggester(feedRepo *repository.FeedRepo) *SkillSuggester {
+	return &SkillSuggester{feedRepo: feedRepo}
+}
+
+// Suggest returns up to limit canonical skill names matching query. Names
+// starting with the query rank first, then names with an alias starting with
+// it ("golang" finds "Go"), then names with any word starting with it; ties
+// go to skills more feed jobs ask for. An empty query returns the most
+// requested skills.
+func (s *SkillSuggester) Suggest(ctx context.Context, query string, limit int) []string {
+	popular, names := s.popularSkills(ctx)
+	q := skills.Key(query)
+
+	type candidate struct {
+		name string
+		rank int
+		jobs int
+	}
+	var matches []candidate
+
+	consider := func(name string) {
+		rank := skillMatchRank(name, q)
+		if rank < 0 {
+			return
+		}
+		matches = append(matches, candidate{name, rank, popular[skills.CanonicalKey(name)]})
+	}
+
+	for _, name := range SkillSuggestions {
+		consider(name)
+	}
+	for key, name := range names {
+		if _, known := skills.Lookup(name); !known && popular[key] >= popularSkillMinJobs {
+			consider(name)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.jobs != b.jobs {
+			return a.jobs > b.jobs
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.name
+	}
+	return out
+}
+
+// skillMatchRank scores how well a skill name matches a normalized query:
+// 0 for a name prefix, 1 for an alias prefix, 2 for a word prefix, -1 for no
+// match. Every skill matches an empty query with rank 0.
+func skillMatchRank(name, q string) int {
+	key := skills.Key(name)
+	if strings.HasPrefix(key, q) {
+		return 0
+	}
+	if s, ok := skills.Lookup(name); ok {
+		for _, alias := range s.Aliases {
+			if strings.HasPrefix(skills.Key(alias), q) {
+				return 1
+			}
+		}
+	}
+	for _, word := range strings.Fields(key) {
+		if strings.HasPrefix(word, q) {
+			return 2
+		}
+	}
+	return -1
+}
+
+// popularSkills returns feed-wide skill counts keyed by canonical key, with
+// the display name for each key. Counts are cached; on a failed reload the
+// previous counts (possibly none) are kept until the next attempt.
+func (s *SkillSuggester) popularSkills(ctx context.Context) (map[string]int, map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Now().Before(s.expiresAt) {
+		return s.popular, s.names
+	}
+
+	demand, err := s.feedRepo.PopularSkills(ctx, time.Now().Add(-popularSkillsWindow), popularSkillsLimit)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load popular feed skills for suggestions")
+		s.expiresAt = time.Now().Add(time.Minute)
+		return s.popular, s.names
+	}
+
+	popular := make(map[string]int, len(demand))
+	names := make(map[string]string, len(demand))
+	for _, d := range demand {
+		name := skills.Canonical(d.Skill)
+		key := skills.Key(name)
+		popular[key] += d.Jobs
+		if _, ok := names[key]; !ok {
+			names[key] = name
+		}
+	}
+
+	s.popular, s.names = popular, names
+	s.expiresAt = time.Now().Add(popularSkillsTTL)
+	return popular, names
+}
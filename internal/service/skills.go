@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/yourusername/hireiq-api/internal/skills"
+)
+
+// normalizeSkill is the comparison key for a skill: case and whitespace
+// insensitive, with taxonomy aliases folded into their canonical skill
+// ("golang" and "Go" match)
+func normalizeSkill(s string) string {
+	return skills.CanonicalKey(s)
+}
+
+// skillSet builds a lookup of normalized skills
+func skillSet(skills []string) map[string]bool {
+	set := make(map[string]bool, len(skills))
+	for _, s := range skills {
+		if k := normalizeSkill(s); k != "" {
+			set[k] = true
+		}
+	}
+	return set
+}
+
+// SkillGap compares a candidate's skills against a job's skill lists
+type SkillGap struct {
+	Matched          []string `json:"matched"`          // job skills the user has (required first)
+	MissingRequired  []string `json:"missingRequired"`  // required skills the user lacks
+	MissingPreferred []string `json:"missingPreferred"` // nice-to-haves the user lacks
+	// Coverage is the percentage of required skills the user has (preferred
+	// skills are used when the job lists no required ones); -1 if the job
+	// lists no skills at all
+	Coverage int `json:"coverage"`
+}
+
+// AnalyzeSkillGap matches skills case- and whitespace-insensitively. Job skill
+// names are returned as the job spells them.
+func AnalyzeSkillGap(userSkills, required, preferred []string) *SkillGap {
+	have := skillSet(userSkills)
+	gap := &SkillGap{
+		Matched:          []string{},
+		MissingRequired:  []string{},
+		MissingPreferred: []string{},
+		Coverage:         -1,
+	}
+
+	seen := make(map[string]bool)
+	check := func(skills []string, missing *[]string) (matched, total int) {
+		for _, s := range skills {
+			k := normalizeSkill(s)
+			if k == "" || seen[k] {
+				continue
+			}
+			seen[k] = true
+			total++
+			if have[k] {
+				matched++
+				gap.Matched = append(gap.Matched, strings.TrimSpace(s))
+			} else {
+				*missing = append(*missing, strings.TrimSpace(s))
+			}
+		}
+		return matched, total
+	}
+
+	reqMatched, reqTotal := check(required, &gap.MissingRequired)
+	prefMatched, prefTotal := check(preferred, &gap.MissingPreferred)
+
+	switch {
+	case reqTotal > 0:
+		gap.Coverage = reqMatched * 100 / reqTotal
+	case prefTotal > 0:
+		gap.Coverage = prefMatched * 100 / prefTotal
+	}
+	return gap
+}
+
+// maxExtractedSkills caps how many skills are pulled from a job description
+const maxExtractedSkills = 15
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -169,6 +170,38 @@ func (s *StripeService) CreatePortalSession(ctx context.Context, userID uuid.UUI
 	return sess.URL, nil
 }
 
+// CancelSubscription immediately cancels the user's Stripe subscription
+// unless it's already canceled. Used before account deletion so a deleted user isn't billed again.
+// A subscription Stripe no longer has counts as canceled.
+func (s *StripeService) CancelSubscription(ctx context.Context, userID uuid.UUID) error {
+	sub, err := s.subRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("looking up subscription: %w", err)
+	}
+	if sub == nil || sub.StripeSubID == "" || sub.Status == model.SubStatusCanceled {
+		return nil
+	}
+	if s.cfg.StripeSecretKey == "" {
+		log.Warn().Str("userId", userID.String()).Msg("Stripe not configured; subscription left as is")
+		return nil
+	}
+
+	_, err = stripesub.Cancel(sub.StripeSubID, &stripe.SubscriptionCancelParams{})
+	var stripeErr *stripe.Error
+	if errors.As(err, &stripeErr) && stripeErr.Code == stripe.ErrorCodeResourceMissing {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("canceling stripe subscription: %w", err)
+	}
+
+	log.Info().
+		Str("userId", userID.String()).
+		Str("stripeSubId", sub.StripeSubID).
+		Msg("Subscription canceled for account deletion")
+	return nil
+}
+
 // VerifyWebhook verifies the Stripe webhook signature and returns the event
 func (s *StripeService) VerifyWebhook(body io.Reader, signature string) (*stripe.Event, error) {
 	payload, err := io.ReadAll(body)
@@ -199,6 +232,29 @@ func (s *StripeService) VerifyWebhook(body io.Reader, signature string) (*stripe
 	return &event, nil
 }
 
+// ParseTestEvent decodes a raw event JSON without signature verification, for
+// the dev-only webhook test endpoint. Refuses outside development.
+func (s *StripeService) ParseTestEvent(body io.Reader) (*stripe.Event, error) {
+	if s.cfg.Env != "development" {
+		return nil, fmt.Errorf("unverified webhook events are only accepted in development")
+	}
+
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("reading test event body: %w", err)
+	}
+
+	var event stripe.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("parsing test event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("test event is missing a type")
+	}
+
+	return &event, nil
+}
+
 func truncate(s string, n int) string {
 	if len(s) <= n {
 		return s
@@ -311,21 +367,7 @@ func (s *StripeService) handleSubscriptionUpsert(ctx context.Context, event *str
 	// Determine plan from price ID
 	plan := s.planFromPriceID(sub.Items.Data[0].Price.ID)
 
-	var periodEnd *time.Time
-	if sub.CurrentPeriodEnd != 0 {
-		t := time.Unix(sub.CurrentPeriodEnd, 0)
-		periodEnd = &t
-	}
-
-	_, err = s.subRepo.Upsert(ctx, &model.Subscription{
-		UserID:            custRecord.UserID,
-		StripeSubID:       sub.ID,
-		StripePriceID:     sub.Items.Data[0].Price.ID,
-		Plan:              plan,
-		Status:            string(sub.Status),
-		CurrentPeriodEnd:  periodEnd,
-		CancelAtPeriodEnd: sub.CancelAtPeriodEnd,
-	})
+	_, err = s.subRepo.Upsert(ctx, subscriptionFromStripe(custRecord.UserID, &sub, plan))
 	if err != nil {
 		return fmt.Errorf("upserting subscription: %w", err)
 	}
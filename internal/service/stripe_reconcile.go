@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/stripe/stripe-go/v81"
+	stripesub "github.com/stripe/stripe-go/v81/subscription"
+	"github.com/yourusername/hireiq-api/internal/model"
+)
+
+// reconcileConcurrency bounds how many subscriptions are reconciled against
+// the database at once while paging through Stripe
+const reconcileConcurrency = 5
+
+// ReconcileReport summarizes one reconciliation pass
+type ReconcileReport struct {
+	Checked  int
+	Created  int
+	Updated  int
+	Skipped  int
+	Failed   int
+	Duration time.Duration
+}
+
+// ReconcileSubscriptions pages through every subscription in Stripe and fixes
+// local rows that drifted because a webhook was missed (status, plan, period
+// end, cancel flag). Stripe is treated as the source of truth.
+func (s *StripeService) ReconcileSubscriptions(ctx context.Context) (*ReconcileReport, error) {
+	start := time.Now()
+	report := &ReconcileReport{}
+	var mu sync.Mutex
+
+	params := &stripe.SubscriptionListParams{Status: stripe.String("all")}
+	params.Context = ctx
+	params.Limit = stripe.Int64(100)
+
+	sem := make(chan struct{}, reconcileConcurrency)
+	var wg sync.WaitGroup
+
+	iter := stripesub.List(params)
+	for iter.Next() {
+		sub := iter.Subscription()
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			outcome, err := s.reconcileOne(ctx, sub)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checked++
+			if err != nil {
+				report.Failed++
+				log.Error().Err(err).Str("stripeSubId", sub.ID).Msg("Reconcile: failed to reconcile subscription")
+				return
+			}
+			switch outcome {
+			case reconcileCreated:
+				report.Created++
+			case reconcileUpdated:
+				report.Updated++
+			default:
+				report.Skipped++
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := iter.Err(); err != nil {
+		return report, fmt.Errorf("listing Stripe subscriptions: %w", err)
+	}
+
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+type reconcileOutcome int
+
+const (
+	reconcileSkipped reconcileOutcome = iota
+	reconcileCreated
+	reconcileUpdated
+)
+
+func (s *StripeService) reconcileOne(ctx context.Context, sub *stripe.Subscription) (reconcileOutcome, error) {
+	if sub.Customer == nil || sub.Items == nil || len(sub.Items.Data) == 0 {
+		return reconcileSkipped, nil
+	}
+
+	custRecord, err := s.custRepo.FindByStripeID(ctx, sub.Customer.ID)
+	if err != nil {
+		return reconcileSkipped, fmt.Errorf("looking up customer: %w", err)
+	}
+	if custRecord == nil {
+		// Customer created outside the app (e.g. in the dashboard)
+		return reconcileSkipped, nil
+	}
+
+	local, err := s.subRepo.FindByUserID(ctx, custRecord.UserID)
+	if err != nil {
+		return reconcileSkipped, fmt.Errorf("looking up local subscription: %w", err)
+	}
+
+	remote := subscriptionFromStripe(custRecord.UserID, sub, s.planFromPriceID(sub.Items.Data[0].Price.ID))
+	remoteLive := isLiveStatus(remote.Status)
+
+	outcome := reconcileUpdated
+	switch {
+	case local == nil:
+		// Missed creation — only worth recording if it grants something
+		if !remoteLive {
+			return reconcileSkipped, nil
+		}
+		outcome = reconcileCreated
+	case local.StripeSubID != sub.ID:
+		// One row per user: an old canceled sub must not replace the current one,
+		// but a live sub replaces a dead local row
+		if !remoteLive || isLiveStatus(local.Status) {
+			return reconcileSkipped, nil
+		}
+	case !subscriptionDrifted(local, remote):
+		return reconcileSkipped, nil
+	}
+
+	if _, err := s.subRepo.Upsert(ctx, remote); err != nil {
+		return reconcileSkipped, fmt.Errorf("upserting reconciled subscription: %w", err)
+	}
+
+	event := log.Warn().
+		Str("userId", custRecord.UserID.String()).
+		Str("stripeSubId", sub.ID).
+		Str("plan", remote.Plan).
+		Str("status", remote.Status)
+	if local != nil {
+		event = event.
+			Str("localPlan", local.Plan).
+			Str("localStatus", local.Status).
+			Str("localStripeSubId", local.StripeSubID)
+	}
+	event.Msg("Reconcile: corrected subscription drift")
+
+	return outcome, nil
+}
+
+// subscriptionFromStripe maps a Stripe subscription onto our model
+func subscriptionFromStripe(userID uuid.UUID, sub *stripe.Subscription, plan string) *model.Subscription {
+	var periodEnd *time.Time
+	if sub.CurrentPeriodEnd != 0 {
+		t := time.Unix(sub.CurrentPeriodEnd, 0)
+		periodEnd = &t
+	}
+	return &model.Subscription{
+		UserID:            userID,
+		StripeSubID:       sub.ID,
+		StripePriceID:     sub.Items.Data[0].Price.ID,
+		Plan:              plan,
+		Status:            string(sub.Status),
+		CurrentPeriodEnd:  periodEnd,
+		CancelAtPeriodEnd: sub.CancelAtPeriodEnd,
+	}
+}
+
+func subscriptionDrifted(local, remote *model.Subscription) bool {
+	if local.Status != remote.Status || local.Plan != remote.Plan ||
+		local.StripePriceID != remote.StripePriceID ||
+		local.CancelAtPeriodEnd != remote.CancelAtPeriodEnd {
+		return true
+	}
+	switch {
+	case local.CurrentPeriodEnd == nil && remote.CurrentPeriodEnd == nil:
+		return false
+	case local.CurrentPeriodEnd == nil || remote.CurrentPeriodEnd == nil:
+		return true
+	default:
+		return !local.CurrentPeriodEnd.Equal(*remote.CurrentPeriodEnd)
+	}
+}
+
+// isLiveStatus reports whether a subscription status still grants (or is
+// about to grant) the paid plan
+func isLiveStatus(status string) bool {
+	return status == model.SubStatusActive || status == model.SubStatusTrialing || status == model.SubStatusPastDue
+}
+
+// StartReconcileLoop runs ReconcileSubscriptions every interval until ctx is done
+func (s *StripeService) StartReconcileLoop(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+				report, err := s.ReconcileSubscriptions(runCtx)
+				cancel()
+				if err != nil {
+					log.Error().Err(err).Msg("Subscription reconciliation failed")
+				}
+				if report != nil {
+					log.Info().
+						Int("checked", report.Checked).
+						Int("created", report.Created).
+						Int("updated", report.Updated).
+						Int("failed", report.Failed).
+						Dur("duration", report.Duration).
+						Msg("Subscription reconciliation complete")
+				}
+			}
+		}
+	}()
+}
@@ -29,6 +29,7 @@ type CompanyIntel struct {
 	Ratings       CompanyRatings  `json:"ratings"`
 	Earnings      []QuarterData   `json:"earnings"`
 	Officers      []Officer       `json:"officers,omitempty"`
+	Ownership     *CompanyOwnership `json:"ownership,omitempty"` // public companies only
 }
 
 type CompanyProfile struct {
@@ -88,6 +89,44 @@ type Officer struct {
 	Age   int    `json:"age,omitempty"`
 }
 
+// CompanyOwnership is who holds the stock and whether insiders are buying
+type CompanyOwnership struct {
+	TopHolders []InstitutionalHolder `json:"topHolders"`
+	Insiders   InsiderSummary        `json:"insiders"`
+}
+
+type InstitutionalHolder struct {
+	Name       string  `json:"name"`
+	PctHeld    float64 `json:"pctHeld"` // fraction of shares outstanding
+	Shares     int64   `json:"shares"`
+	Value      int64   `json:"value"`
+	ValueFmt   string  `json:"valueFmt"`
+	ReportDate string  `json:"reportDate"`
+}
+
+// InsiderSummary totals the insider transactions Yahoo reports (roughly the
+// last several months). Awards, gifts, and option exercises count as neither
+// buys nor sells.
+type InsiderSummary struct {
+	Buys         int            `json:"buys"`
+	Sells        int            `json:"sells"`
+	SharesBought int64          `json:"sharesBought"`
+	SharesSold   int64          `json:"sharesSold"`
+	ValueBought  int64          `json:"valueBought"`
+	ValueSold    int64          `json:"valueSold"`
+	Recent       []InsiderTrade `json:"recent"`
+}
+
+type InsiderTrade struct {
+	Name     string `json:"name"`
+	Relation string `json:"relation"`
+	Type     string `json:"type"` // "buy", "sell", or "other"
+	Text     string `json:"text"`
+	Shares   int64  `json:"shares"`
+	Value    int64  `json:"value"`
+	Date     string `json:"date"`
+}
+
 // ── Yahoo Finance Client ────────────────────────────────
 
 type YahooFinanceClient struct {
@@ -111,14 +150,17 @@ const (
 	userAgent    = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36"
 )
 
-func NewYahooFinanceClient() *YahooFinanceClient {
+// CompanyIntelCacheTTL is how long fetched intel is served from cache;
+// callers use it for AI estimates and client cache lifetimes
+const CompanyIntelCacheTTL = cacheTTL
+
+func NewYahooFinanceClient(timeout time.Duration) *YahooFinanceClient {
 	jar, _ := cookiejar.New(nil)
+	client := NewHTTPClient(timeout)
+	client.Jar = jar
 	return &YahooFinanceClient{
-		client: &http.Client{
-			Timeout: 15 * time.Second,
-			Jar:     jar,
-		},
-		cache: make(map[string]*cachedIntel),
+		client: client,
+		cache:  make(map[string]*cachedIntel),
 	}
 }
 
@@ -236,7 +278,7 @@ func (yf *YahooFinanceClient) fetchWithCrumb(ctx context.Context, ticker string)
 		return nil, fmt.Errorf("obtaining crumb: %w", err)
 	}
 
-	modules := "assetProfile,financialData,defaultKeyStatistics,summaryDetail,price,earnings,recommendationTrend"
+	modules := "assetProfile,financialData,defaultKeyStatistics,summaryDetail,price,earnings,recommendationTrend,institutionOwnership,insiderTransactions"
 	url := fmt.Sprintf("%s/v10/finance/quoteSummary/%s?modules=%s&crumb=%s",
 		yahooBaseURL, ticker, modules, crumb)
 
@@ -378,7 +420,7 @@ func parseYahooResponse(ticker string, body []byte) (*CompanyIntel, error) {
 		Ticker:   ticker,
 		IsPublic: true,
 		Source:   "yahoo_finance",
-		FetchedAt: time.Now(),
+		FetchedAt: time.Now().UTC(),
 	}
 
 	// Parse assetProfile
@@ -551,9 +593,105 @@ func parseYahooResponse(ticker string, body []byte) (*CompanyIntel, error) {
 		}
 	}
 
+	// Parse institutionOwnership for the largest holders
+	if data, ok := modules["institutionOwnership"]; ok {
+		var own struct {
+			OwnershipList []struct {
+				Organization string `json:"organization"`
+				PctHeld      yfVal  `json:"pctHeld"`
+				Position     yfVal  `json:"position"`
+				Value        yfVal  `json:"value"`
+				ReportDate   yfVal  `json:"reportDate"`
+			} `json:"ownershipList"`
+		}
+		if err := json.Unmarshal(data, &own); err == nil && len(own.OwnershipList) > 0 {
+			ownership := ensureOwnership(intel)
+			for _, h := range own.OwnershipList {
+				if len(ownership.TopHolders) >= 10 {
+					break
+				}
+				ownership.TopHolders = append(ownership.TopHolders, InstitutionalHolder{
+					Name:       h.Organization,
+					PctHeld:    h.PctHeld.Raw,
+					Shares:     int64(h.Position.Raw),
+					Value:      int64(h.Value.Raw),
+					ValueFmt:   h.Value.Fmt,
+					ReportDate: h.ReportDate.Fmt,
+				})
+			}
+		}
+	}
+
+	// Parse insiderTransactions into a buy/sell summary
+	if data, ok := modules["insiderTransactions"]; ok {
+		var it struct {
+			Transactions []struct {
+				FilerName       string `json:"filerName"`
+				FilerRelation   string `json:"filerRelation"`
+				TransactionText string `json:"transactionText"`
+				Shares          yfVal  `json:"shares"`
+				Value           yfVal  `json:"value"`
+				StartDate       yfVal  `json:"startDate"`
+			} `json:"transactions"`
+		}
+		if err := json.Unmarshal(data, &it); err == nil && len(it.Transactions) > 0 {
+			summary := &ensureOwnership(intel).Insiders
+			for _, t := range it.Transactions {
+				trade := InsiderTrade{
+					Name:     t.FilerName,
+					Relation: t.FilerRelation,
+					Type:     insiderTradeType(t.TransactionText),
+					Text:     t.TransactionText,
+					Shares:   int64(t.Shares.Raw),
+					Value:    int64(t.Value.Raw),
+					Date:     t.StartDate.Fmt,
+				}
+				switch trade.Type {
+				case "buy":
+					summary.Buys++
+					summary.SharesBought += trade.Shares
+					summary.ValueBought += trade.Value
+				case "sell":
+					summary.Sells++
+					summary.SharesSold += trade.Shares
+					summary.ValueSold += trade.Value
+				}
+				if len(summary.Recent) < 5 {
+					summary.Recent = append(summary.Recent, trade)
+				}
+			}
+		}
+	}
+
 	return intel, nil
 }
 
+// ensureOwnership returns intel.Ownership, creating it on first use so
+// companies without ownership data omit the section
+func ensureOwnership(intel *CompanyIntel) *CompanyOwnership {
+	if intel.Ownership == nil {
+		intel.Ownership = &CompanyOwnership{
+			TopHolders: []InstitutionalHolder{},
+			Insiders:   InsiderSummary{Recent: []InsiderTrade{}},
+		}
+	}
+	return intel.Ownership
+}
+
+// insiderTradeType classifies Yahoo's free-text transaction description
+// ("Purchase at price 12.50 per share.", "Sale at price ...", "Stock Gift ...")
+func insiderTradeType(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.HasPrefix(lower, "purchase") || strings.HasPrefix(lower, "buy"):
+		return "buy"
+	case strings.HasPrefix(lower, "sale") || strings.HasPrefix(lower, "sell"):
+		return "sell"
+	default:
+		return "other"
+	}
+}
+
 // ── Yahoo Finance value wrapper (raw + formatted) ───────
 
 type yfVal struct {
@@ -0,0 +1,233 @@
+// Package skills is the shared skill taxonomy: canonical skill names, the
+// aliases people write them as, and a coarse category for each. Feed scoring,
+// skill extraction from job descriptions, and query building all read it, so
+// "golang" on a profile matches "Go" on a job everywhere.
+//
+// The taxonomy lives in taxonomy.json, embedded at build time. Each entry has
+// a name, optional aliases, a category, and an optional "extract" list: the
+// spellings that are safe to look for in free text. When extract is omitted,
+// the name and aliases are used; set it for skills whose name is also an
+// everyday word ("Go", "Excel", "Design"), or to [] to never extract one.
+// Skills whose name is worth extracting but also reads as plain English
+// ("React", "Swift", "Agile") instead list "context" words: the bare name only
+// counts when one of them appears too ("react quickly" alone isn't React).
+package skills
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Skill categories used in taxonomy.json
+const (
+	CategoryEngineering = "engineering"
+	CategoryData        = "data"
+	CategoryDevOps      = "devops"
+	CategoryDesign      = "design"
+	CategoryProduct     = "product"
+	CategoryQA          = "qa"
+	CategoryBusiness    = "business"
+)
+
+// Skill is one taxonomy entry
+type Skill struct {
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Category string   `json:"category"`
+	Extract  []string `json:"extract,omitempty"`
+	Context  []string `json:"context,omitempty"`
+}
+
+//go:embed taxonomy.json
+var taxonomyJSON []byte
+
+var (
+	taxonomy []Skill
+	byKey    map[string]int // normalized name or alias -> index in taxonomy
+)
+
+func init() {
+	if err := json.Unmarshal(taxonomyJSON, &taxonomy); err != nil {
+		panic(fmt.Sprintf("skills: parsing taxonomy.json: %v", err))
+	}
+
+	byKey = make(map[string]int)
+	for i := range taxonomy {
+		s := &taxonomy[i]
+		if s.Extract == nil {
+			s.Extract = append([]string{s.Name}, s.Aliases...)
+		}
+		for j, e := range s.Extract {
+			s.Extract[j] = Key(e)
+		}
+		for j, w := range s.Context {
+			s.Context[j] = Key(w)
+		}
+		for _, k := range append([]string{s.Name}, s.Aliases...) {
+			key := Key(k)
+			if prev, dup := byKey[key]; dup {
+				panic(fmt.Sprintf("skills: %q is listed under both %q and %q", k, taxonomy[prev].Name, s.Name))
+			}
+			byKey[key] = i
+		}
+	}
+}
+
+// Key is the comparison form of a skill name: lowercase, trimmed, internal
+// whitespace collapsed ("Node.js ", "node.js" match)
+func Key(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// All returns every taxonomy entry
+func All() []Skill {
+	out := make([]Skill, len(taxonomy))
+	copy(out, taxonomy)
+	return out
+}
+
+// Lookup finds the taxonomy entry for a skill name or alias
+func Lookup(name string) (Skill, bool) {
+	i, ok := byKey[Key(name)]
+	if !ok {
+		return Skill{}, false
+	}
+	return taxonomy[i], true
+}
+
+// Canonical returns the taxonomy name for a skill ("golang" -> "Go"), or the
+// trimmed input when the skill isn't in the taxonomy
+func Canonical(name string) string {
+	if s, ok := Lookup(name); ok {
+		return s.Name
+	}
+	return strings.TrimSpace(name)
+}
+
+// CanonicalKey is Key(Canonical(name)): the form to compare skills by so that
+// aliases of the same skill are equal
+func CanonicalKey(name string) string {
+	return Key(Canonical(name))
+}
+
+// Normalize canonicalizes a list of skills for storage: each is replaced by
+// its taxonomy name ("golang" -> "Go", "JS" -> "JavaScript"), unknown skills
+// are trimmed with internal whitespace collapsed, empties are dropped, and
+// aliases of a skill already listed are removed. Order is kept. Always returns
+// a non-nil slice so it stores as '{}' rather than NULL.
+func Normalize(names []string) []string {
+	out := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		name := strings.Join(strings.Fields(Canonical(n)), " ")
+		key := Key(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, name)
+	}
+	return out
+}
+
+// Category returns a skill's category, or "" if it isn't in the taxonomy
+func Category(name string) string {
+	if s, ok := Lookup(name); ok {
+		return s.Category
+	}
+	return ""
+}
+
+// Mentioned reports whether text mentions the skill as a whole word. Known
+// skills match on their extract spellings; unknown ones on their own name.
+func Mentioned(text, name string) bool {
+	lower := strings.ToLower(text)
+	spellings := []string{Key(name)}
+	if s, ok := Lookup(name); ok {
+		spellings = s.Extract
+	}
+	for _, sp := range spellings {
+		if sp != "" && indexWord(lower, sp) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract finds taxonomy skills mentioned in free text (e.g. a job
+// description from a source that doesn't list skills). Matching is
+// case-insensitive on whole words, so "java" doesn't match "javascript", and
+// a skill with context words only matches on its bare name when the text
+// also has one of them. Canonical names come back in order of first mention,
+// at most max of them (max <= 0 means no cap).
+func Extract(text string, max int) []string {
+	lower := strings.ToLower(text)
+
+	type hit struct {
+		name string
+		pos  int
+	}
+	var hits []hit
+	for _, s := range taxonomy {
+		first := -1
+		inContext := len(s.Context) == 0 || mentionsAny(lower, s.Context)
+		for _, sp := range s.Extract {
+			if !inContext && sp == Key(s.Name) {
+				continue
+			}
+			if pos := indexWord(lower, sp); pos >= 0 && (first < 0 || pos < first) {
+				first = pos
+			}
+		}
+		if first >= 0 {
+			hits = append(hits, hit{s.Name, first})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].pos < hits[j].pos })
+	if max > 0 && len(hits) > max {
+		hits = hits[:max]
+	}
+
+	names := make([]string, len(hits))
+	for i, h := range hits {
+		names[i] = h.name
+	}
+	return names
+}
+
+// mentionsAny reports whether lowercased text has any of words as a whole word
+func mentionsAny(text string, words []string) bool {
+	for _, w := range words {
+		if indexWord(text, w) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// indexWord returns the first index of word in text where it isn't part of a
+// longer word, or -1. Symbols inside the word ("c++", ".net") are literal; a
+// trailing "." is allowed so sentence-final mentions still count.
+func indexWord(text, word string) int {
+	isWordByte := func(b byte) bool {
+		return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '+' || b == '#'
+	}
+	for start := 0; ; {
+		i := strings.Index(text[start:], word)
+		if i < 0 {
+			return -1
+		}
+		i += start
+		end := i + len(word)
+		before := i == 0 || !isWordByte(text[i-1]) && text[i-1] != '.'
+		after := end == len(text) || !isWordByte(text[end])
+		if before && after {
+			return i
+		}
+		start = i + 1
+	}
+}
@@ -0,0 +1,51 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"whole words only", "Java and JavaScript", []string{"Java", "JavaScript"}},
+		{"no partial matches", "javanese golangish", []string{}},
+		{"aliases map to canonical", "We use golang and k8s.", []string{"Go", "Kubernetes"}},
+		{"sentence-final mention", "Our stack is Python.", []string{"Python"}},
+		{"order of first mention", "Docker, then AWS, then Docker again", []string{"Docker", "AWS"}},
+
+		// Everyday words aren't skills without context
+		{"react as a verb", "You react quickly to customer feedback.", []string{}},
+		{"swift as an adjective", "We value swift delivery and ownership.", []string{}},
+		{"agile as an adjective", "An agile, fast-moving team.", []string{}},
+		{"rust as a noun", "Inspect pipes for rust and corrosion.", []string{}},
+
+		// ...but count when the text is about them
+		{"react with frontend context", "Build frontend features in React and TypeScript.", []string{"React", "TypeScript"}},
+		{"swift with ios context", "Ship iOS apps in Swift.", []string{"Swift"}},
+		{"agile with scrum process", "We work agile with two-week sprints.", []string{"Agile"}},
+		{"rust with systems context", "Systems programming in Rust with tokio.", []string{"Rust"}},
+		{"unambiguous alias needs no context", "Experience with reactjs and SwiftUI.", []string{"React", "Swift"}},
+		{"alias of agile", "Scrum master experience", []string{"Agile"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Extract(tt.text, 0)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractMax(t *testing.T) {
+	got := Extract("Python, Go via golang, Docker, AWS", 2)
+	want := []string{"Python", "Go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract with max 2 = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,7 @@
+-- 007: Link contacts to the job posting they were extracted from
+-- Run with: psql $DATABASE_URL -f migrations/007_contact_job_link.sql
+
+ALTER TABLE contacts
+    ADD COLUMN job_id UUID REFERENCES jobs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_job ON contacts(job_id) WHERE job_id IS NOT NULL;
@@ -0,0 +1,17 @@
+-- 008: Personal API tokens for scripting / CLI access
+-- Run with: psql $DATABASE_URL -f migrations/008_api_tokens.sql
+
+CREATE TABLE api_tokens (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name          TEXT NOT NULL,
+    token_prefix  TEXT NOT NULL,             -- first characters, shown in listings
+    token_hash    TEXT UNIQUE NOT NULL,      -- SHA-256 of the full token; the token itself is never stored
+    scopes        TEXT[] NOT NULL DEFAULT '{}',
+    last_used_at  TIMESTAMPTZ,
+    expires_at    TIMESTAMPTZ,
+    revoked_at    TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id);
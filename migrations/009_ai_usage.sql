@@ -0,0 +1,10 @@
+-- 009: Daily AI usage counters for per-plan quotas
+-- Run with: psql $DATABASE_URL -f migrations/009_ai_usage.sql
+
+CREATE TABLE ai_usage (
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category    TEXT NOT NULL,            -- parse, compare, resume, company_intel
+    usage_date  DATE NOT NULL,            -- UTC day; quotas reset at midnight UTC
+    count       INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, category, usage_date)
+);
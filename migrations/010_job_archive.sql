@@ -0,0 +1,7 @@
+-- 010: Soft-delete (archive) for jobs so DELETE /jobs/:id is recoverable
+-- Run with: psql $DATABASE_URL -f migrations/010_job_archive.sql
+
+ALTER TABLE jobs
+    ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_user_active ON jobs(user_id) WHERE archived_at IS NULL;
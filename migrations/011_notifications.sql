@@ -0,0 +1,16 @@
+-- 011: In-app notifications (written by application status-change subscribers)
+-- Run with: psql $DATABASE_URL -f migrations/011_notifications.sql
+
+CREATE TABLE notifications (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type            TEXT NOT NULL,        -- e.g. application_status
+    title           TEXT NOT NULL,
+    body            TEXT NOT NULL DEFAULT '',
+    application_id  UUID REFERENCES applications(id) ON DELETE CASCADE,
+    job_id          UUID REFERENCES jobs(id) ON DELETE CASCADE,
+    read_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_notifications_user_unread ON notifications (user_id, created_at DESC) WHERE read_at IS NULL;
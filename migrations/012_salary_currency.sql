@@ -0,0 +1,5 @@
+-- 012: Salary currency for feed jobs and user salary expectations
+-- Run with: psql $DATABASE_URL -f migrations/012_salary_currency.sql
+
+ALTER TABLE feed_jobs ADD COLUMN IF NOT EXISTS salary_currency TEXT NOT NULL DEFAULT 'USD';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS salary_currency TEXT NOT NULL DEFAULT 'USD';
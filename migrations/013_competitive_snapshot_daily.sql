@@ -0,0 +1,11 @@
+-- 013: One competitive snapshot per job per day
+-- Run with: psql $DATABASE_URL -f migrations/013_competitive_snapshot_daily.sql
+
+DELETE FROM competitive_snapshots a
+USING competitive_snapshots b
+WHERE a.job_id = b.job_id
+  AND a.snapshot_date = b.snapshot_date
+  AND a.created_at < b.created_at;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_competitive_job_date
+    ON competitive_snapshots(job_id, snapshot_date);
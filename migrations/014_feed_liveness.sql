@@ -0,0 +1,6 @@
+-- 014: Track when a feed job's apply URL was last checked for liveness
+-- Run with: psql $DATABASE_URL -f migrations/014_feed_liveness.sql
+
+ALTER TABLE feed_jobs ADD COLUMN IF NOT EXISTS liveness_checked_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_feed_jobs_liveness ON feed_jobs(liveness_checked_at NULLS FIRST);
@@ -0,0 +1,9 @@
+-- 015: Application outcomes (rejected, ghosted, withdrawn, accepted, declined_offer)
+-- Run with: psql $DATABASE_URL -f migrations/015_application_outcome.sql
+
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS outcome TEXT NOT NULL DEFAULT '';
+ALTER TABLE status_history ADD COLUMN IF NOT EXISTS outcome TEXT NOT NULL DEFAULT '';
+
+-- Existing closed applications get the outcome their status implies
+UPDATE applications SET outcome = status
+WHERE outcome = '' AND status IN ('rejected', 'withdrawn');
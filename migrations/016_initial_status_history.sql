@@ -0,0 +1,20 @@
+-- 016: Initial status_history row for applications created before
+-- ApplicationRepo.Create started recording one
+-- Run with: psql $DATABASE_URL -f migrations/016_initial_status_history.sql
+
+-- The starting status is the first change's from_status, or the current
+-- status if it never changed
+INSERT INTO status_history (application_id, from_status, to_status, note, changed_at)
+SELECT a.id, '',
+       COALESCE(
+           (SELECT h.from_status FROM status_history h
+            WHERE h.application_id = a.id
+            ORDER BY h.changed_at ASC
+            LIMIT 1),
+           a.status),
+       'Application created', a.created_at
+FROM applications a
+WHERE NOT EXISTS (
+    SELECT 1 FROM status_history h
+    WHERE h.application_id = a.id AND h.from_status = ''
+);
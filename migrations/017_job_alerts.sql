@@ -0,0 +1,18 @@
+-- 017: Saved searches (job alerts) run alongside the profile queries on each
+-- feed refresh, and which alert surfaced a feed job
+-- Run with: psql $DATABASE_URL -f migrations/017_job_alerts.sql
+
+CREATE TABLE job_alerts (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    keywords    TEXT NOT NULL,
+    location    TEXT NOT NULL DEFAULT '',  -- empty = anywhere
+    salary_min  INTEGER NOT NULL DEFAULT 0, -- 0 = no floor
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_job_alerts_user ON job_alerts (user_id, created_at);
+
+ALTER TABLE user_feed
+    ADD COLUMN alert_id UUID REFERENCES job_alerts(id) ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+-- 018: Add target_companies so feed jobs at employers the user wants get boosted
+-- Run with: psql $DATABASE_URL -f migrations/018_target_companies.sql
+
+ALTER TABLE users
+    ADD COLUMN target_companies TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+-- 019: Let users mark tracked jobs they applied to outside HireIQ
+-- Run with: psql $DATABASE_URL -f migrations/019_applied_externally.sql
+
+ALTER TABLE jobs
+    ADD COLUMN applied_externally BOOLEAN NOT NULL DEFAULT false;
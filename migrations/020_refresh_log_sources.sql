@@ -0,0 +1,6 @@
+-- 020: Keep the per-source breakdown of each feed refresh (status, counts,
+-- errors such as rate limits) so refresh status survives restarts
+-- Run with: psql $DATABASE_URL -f migrations/020_refresh_log_sources.sql
+
+ALTER TABLE feed_refresh_log
+    ADD COLUMN sources JSONB;
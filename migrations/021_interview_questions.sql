@@ -0,0 +1,18 @@
+-- 021: Interview questions the user was asked, per company and optionally per
+-- application, for the company question bank
+-- Run with: psql $DATABASE_URL -f migrations/021_interview_questions.sql
+
+CREATE TABLE interview_questions (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    application_id  UUID REFERENCES applications(id) ON DELETE SET NULL,
+    company         TEXT NOT NULL,
+    question        TEXT NOT NULL,
+    category        TEXT NOT NULL DEFAULT '',  -- empty = uncategorized
+    stage           TEXT NOT NULL DEFAULT '',  -- e.g. "phone screen", "onsite"
+    notes           TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_interview_questions_user_company ON interview_questions (user_id, LOWER(company));
@@ -0,0 +1,6 @@
+-- 022: Let users snooze a feed job until a date; it's hidden from the feed
+-- until then and resurfaces on its own
+-- Run with: psql $DATABASE_URL -f migrations/022_feed_snooze.sql
+
+ALTER TABLE user_feed
+    ADD COLUMN snoozed_until TIMESTAMPTZ;
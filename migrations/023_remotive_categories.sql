@@ -0,0 +1,6 @@
+-- 023: Let users pick Remotive categories to search explicitly, on top of
+-- the ones derived from their skills
+-- Run with: psql $DATABASE_URL -f migrations/023_remotive_categories.sql
+
+ALTER TABLE users
+    ADD COLUMN remotive_categories TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+-- 024: Count consecutive failed liveness checks so unreachable apply URLs
+-- back off instead of being retried at the front of every pass
+-- Run with: psql $DATABASE_URL -f migrations/024_liveness_backoff.sql
+
+ALTER TABLE feed_jobs ADD COLUMN IF NOT EXISTS liveness_failures INT NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- 025: Record which users have been sent their follow-up digest each day, so
+-- every replica can run the reminder loop without sending duplicates
+-- Run with: psql $DATABASE_URL -f migrations/025_followup_digests.sql
+
+CREATE TABLE followup_digests (
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    digest_date  DATE NOT NULL,            -- UTC day the digest covers
+    sent_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, digest_date)
+);
@@ -0,0 +1,6 @@
+-- 026: Record what started each feed refresh, so the nightly scheduler only
+-- keeps refreshing users who still refresh their feed themselves
+-- Run with: psql $DATABASE_URL -f migrations/026_refresh_log_trigger.sql
+
+ALTER TABLE feed_refresh_log
+    ADD COLUMN triggered_by TEXT NOT NULL DEFAULT 'user';   -- user, scheduler